@@ -18,14 +18,16 @@ import (
 
 	apiv2 "github.com/bmstu-itstech/scriptum-back/internal/api/v2"
 	"github.com/bmstu-itstech/scriptum-back/internal/app"
-	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
 	"github.com/bmstu-itstech/scriptum-back/internal/config"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
 	"github.com/bmstu-itstech/scriptum-back/internal/infra/bcrypt"
 	"github.com/bmstu-itstech/scriptum-back/internal/infra/docker"
 	"github.com/bmstu-itstech/scriptum-back/internal/infra/jwt"
 	"github.com/bmstu-itstech/scriptum-back/internal/infra/local"
 	"github.com/bmstu-itstech/scriptum-back/internal/infra/postgres"
 	"github.com/bmstu-itstech/scriptum-back/internal/infra/watermill"
+	"github.com/bmstu-itstech/scriptum-back/internal/worker"
 	"github.com/bmstu-itstech/scriptum-back/pkg/jwtauth"
 	"github.com/bmstu-itstech/scriptum-back/pkg/logs"
 	"github.com/bmstu-itstech/scriptum-back/pkg/logs/sl"
@@ -48,29 +50,71 @@ func main() {
 
 	l.Debug(fmt.Sprintf("config: %+v", cfg))
 
+	// Очередь в памяти не разделяется между процессами, поэтому задачи из неё может выполнить только
+	// встроенный исполнитель.
+	if !cfg.Worker.Embedded && cfg.Queue.Driver != config.QueueDriverPostgres {
+		panic(fmt.Sprintf("queue driver %q requires embedded worker", cfg.Queue.Driver))
+	}
+
 	repos := postgres.MustNewRepository(cfg.Postgres)
-	runner := docker.MustNewRunner(cfg.Docker, l)
 	storage := local.MustNewStorage(cfg.Storage, l)
 	hasher := bcrypt.NewPasswordHasher(bcryptPasswordHasherCost)
 	tokenService := jwt.MustNewTokenService(cfg.JWT)
 
-	jPub, jSub := watermill.NewJobPubSubGoChannels(l)
+	// Без встроенного исполнителя процессу не нужен доступ к Docker.
+	var runner ports.Runner
+	if cfg.Worker.Embedded {
+		runner = docker.MustNewRunner(cfg.Docker, l)
+	}
+
+	queues := worker.MustNewQueues(cfg.Queue, repos, l)
+	lPub, eSub := watermill.NewJobEventPubSubGoChannels(l)
+	var ePub ports.JobEventPublisher = lPub
+	var eListener *postgres.JobEventListener
+	if cfg.Queue.Driver == config.QueueDriverPostgres {
+		// Задача может выполняться другим экземпляром сервиса, поэтому события рассылаются через Postgres.
+		ePub = postgres.NewJobEventNotifier(repos, l)
+		eListener = postgres.NewJobEventListener(cfg.Postgres, lPub, l)
+	}
 
 	infra := app.Infra{
-		BlueprintProvider:   repos,
-		BlueprintRepository: repos,
-		FileReader:          storage,
-		FileUploader:        storage,
-		JobProvider:         repos,
-		JobPublisher:        jPub,
-		JobRepository:       repos,
-		PasswordHasher:      hasher,
-		Runner:              runner,
-		TokenService:        tokenService,
-		UserProvider:        repos,
-		UserRepository:      repos,
+		BatchProvider:        repos,
+		BatchRepository:      repos,
+		BlueprintPublisher:   queues.BlueprintPublisher,
+		BlueprintProvider:    repos,
+		BlueprintRepository:  repos,
+		BuildLogProvider:     repos,
+		BuildLogRepository:   repos,
+		DeadLetterProvider:   repos,
+		DeadLetterRepository: repos,
+		FileReader:           storage,
+		FileRepository:       repos,
+		FileUploader:         storage,
+		JobEventPublisher:    ePub,
+		JobEventSubscriber:   eSub,
+		JobLogProvider:       repos,
+		JobLogRepository:     repos,
+		JobProvider:          repos,
+		JobReconcileProvider: repos,
+		JobRepository:        repos,
+		PasswordHasher:       hasher,
+		PipelineRepository:   repos,
+		Runner:               runner,
+		ScheduleProvider:     repos,
+		ScheduleRepository:   repos,
+		TokenService:         tokenService,
+		UserProvider:         repos,
+		UserRepository:       repos,
+		WorkerStatsProvider:  queues.JobSubscriber,
+	}
+	policy := app.Policy{
+		MaxLimits: value.MustNewResourceLimits(
+			cfg.Docker.Limits.MemoryMB, cfg.Docker.Limits.CPUs, cfg.Docker.Limits.Pids, cfg.Docker.Limits.TmpfsMB,
+		),
+		AllowNetwork: cfg.Docker.Security.AllowNetwork,
+		MaxTimeout:   cfg.Docker.MaxTimeout,
 	}
-	a := app.NewApp(infra, l)
+	a := app.NewApp(infra, policy, l)
 
 	root := chi.NewRouter()
 	root.Use(middleware.RequestID)
@@ -104,12 +148,21 @@ func main() {
 	defer cancel()
 	errCh := make(chan error, 1)
 
-	go func() {
-		err := jSub.Listen(ctx, func(ctx2 context.Context, jobID string) error {
-			return a.Commands.RunJob.Handle(ctx2, request.RunJob{JobID: jobID})
-		})
-		errCh <- err
-	}()
+	if cfg.Worker.Embedded {
+		relay := postgres.NewOutboxRelay(repos, queues.JobPublisher, cfg.Queue.RelayInterval, l)
+		w := worker.New(a, queues, relay, repos, cfg.Queue, cfg.Worker, l)
+		go func() {
+			err := w.Run(ctx)
+			errCh <- err
+		}()
+	}
+
+	if eListener != nil {
+		go func() {
+			err := eListener.Run(ctx)
+			errCh <- err
+		}()
+	}
 
 	go func() {
 		l.Info("starting http server", slog.String("addr", s.Addr))
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app"
+	"github.com/bmstu-itstech/scriptum-back/internal/config"
+	"github.com/bmstu-itstech/scriptum-back/internal/infra/docker"
+	"github.com/bmstu-itstech/scriptum-back/internal/infra/local"
+	"github.com/bmstu-itstech/scriptum-back/internal/infra/postgres"
+	"github.com/bmstu-itstech/scriptum-back/internal/worker"
+	"github.com/bmstu-itstech/scriptum-back/pkg/logs"
+)
+
+func main() {
+	var cfgPath string
+	flag.StringVar(&cfgPath, "config", "", "path to config file")
+	flag.Parse()
+	if cfgPath == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+	cfg := config.MustLoad(cfgPath)
+
+	l := logs.NewLogger(cfg.Logging.Level)
+
+	l.Debug(fmt.Sprintf("config: %+v", cfg))
+
+	// Отдельный процесс получает задачи от HTTP-сервера только через Postgres.
+	if cfg.Queue.Driver != config.QueueDriverPostgres {
+		panic(fmt.Sprintf("worker requires queue driver %q, got %q", config.QueueDriverPostgres, cfg.Queue.Driver))
+	}
+
+	repos := postgres.MustNewRepository(cfg.Postgres)
+	runner := docker.MustNewRunner(cfg.Docker, l)
+	storage := local.MustNewStorage(cfg.Storage, l)
+
+	queues := worker.MustNewQueues(cfg.Queue, repos, l)
+	relay := postgres.NewOutboxRelay(repos, queues.JobPublisher, cfg.Queue.RelayInterval, l)
+
+	// Исполнителю нужны только выполнение задач, сборка образов и расписания, остальные зависимости приложения
+	// не заданы.
+	infra := app.Infra{
+		BlueprintPublisher:   queues.BlueprintPublisher,
+		BlueprintProvider:    repos,
+		BlueprintRepository:  repos,
+		BuildLogRepository:   repos,
+		DeadLetterRepository: repos,
+		FileReader:           storage,
+		FileRepository:       repos,
+		FileUploader:         storage,
+		JobEventPublisher:    postgres.NewJobEventNotifier(repos, l),
+		JobLogRepository:     repos,
+		JobProvider:          repos,
+		JobReconcileProvider: repos,
+		JobRepository:        repos,
+		PipelineRepository:   repos,
+		Runner:               runner,
+		ScheduleProvider:     repos,
+		ScheduleRepository:   repos,
+	}
+	a := app.NewApp(infra, app.Policy{}, l)
+
+	w := worker.New(a, queues, relay, repos, cfg.Queue, cfg.Worker, l)
+
+	// start
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	l.Info("starting worker", slog.Int("max_concurrent_jobs", cfg.Queue.MaxConcurrentJobs))
+	err := w.Run(ctx)
+	if errors.Is(err, context.Canceled) {
+		l.Info("received cancel signal, shutting down")
+	} else if err != nil {
+		l.Error("worker error", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
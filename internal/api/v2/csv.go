@@ -0,0 +1,61 @@
+package apiv2
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+)
+
+// writeBatchResultsCSV записывает результаты задач пакета таблицей: по строке на задачу со столбцами job_id,
+// state, входными полями, exit_code и выходными полями. Все задачи пакета собраны по одному шаблону, поэтому
+// имена столбцов берутся из первой задачи. Значения незавершённых задач остаются пустыми.
+func writeBatchResultsCSV(w io.Writer, js []dto.Job) error {
+	cw := csv.NewWriter(w)
+
+	if len(js) > 0 {
+		header := []string{"job_id", "state"}
+		for _, f := range js[0].In {
+			header = append(header, f.Name)
+		}
+		header = append(header, "exit_code")
+		for _, f := range js[0].Out {
+			header = append(header, f.Name)
+		}
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+
+	for _, j := range js {
+		if err := cw.Write(batchResultRecord(j)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func batchResultRecord(j dto.Job) []string {
+	record := make([]string, 0, 3+len(j.In)+len(j.Out))
+	record = append(record, j.ID, j.State)
+	for _, v := range j.Input {
+		record = append(record, v.Value)
+	}
+	code := ""
+	if j.ResultCode != nil {
+		code = strconv.Itoa(*j.ResultCode)
+	}
+	record = append(record, code)
+	// Вывод есть только у успешно завершённых задач; для остальных столбцы заполняются пустыми значениями.
+	for i := range j.Out {
+		v := ""
+		if i < len(j.Output) {
+			v = j.Output[i].Value
+		}
+		record = append(record, v)
+	}
+	return record
+}
@@ -12,6 +12,7 @@ func renderInvalidInputError(w http.ResponseWriter, r *http.Request, iiErr domai
 	e := InvalidInputError{
 		Code:    iiErr.Code,
 		Message: nilOnEmpty(iiErr.Message),
+		Field:   nilOnEmpty(iiErr.Field),
 	}
 	render.Status(r, code)
 	render.JSON(w, r, e)
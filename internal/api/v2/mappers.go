@@ -1,6 +1,8 @@
 package apiv2
 
 import (
+	"time"
+
 	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
 	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
 )
@@ -30,11 +32,26 @@ func valuesToAPI(vs []dto.Value) []Value {
 func fieldsToDTO(fs []Field) []dto.Field {
 	res := make([]dto.Field, len(fs))
 	for i, v := range fs {
+		var options []string
+		if v.Options != nil {
+			options = *v.Options
+		}
 		res[i] = dto.Field{
-			Name: v.Name,
-			Type: string(v.Type),
-			Desc: nilOnNilOrEmpty(v.Desc),
-			Unit: nilOnNilOrEmpty(v.Unit),
+			Name:    v.Name,
+			Type:    string(v.Type),
+			Desc:    nilOnNilOrEmpty(v.Desc),
+			Unit:    nilOnNilOrEmpty(v.Unit),
+			Options: options,
+			Default: v.Default,
+		}
+		if c := v.Constraints; c != nil {
+			res[i].Constraints = &dto.FieldConstraints{
+				Min:       nilOnNilOrEmpty(c.Min),
+				Max:       nilOnNilOrEmpty(c.Max),
+				MinLength: c.MinLength,
+				MaxLength: c.MaxLength,
+				Pattern:   nilOnNilOrEmpty(c.Pattern),
+			}
 		}
 	}
 	return res
@@ -43,28 +60,88 @@ func fieldsToDTO(fs []Field) []dto.Field {
 func fieldsToAPI(fs []dto.Field) []Field {
 	res := make([]Field, len(fs))
 	for i, v := range fs {
+		var options *[]string
+		if len(v.Options) > 0 {
+			options = &v.Options
+		}
 		res[i] = Field{
-			Name: v.Name,
-			Desc: v.Desc,
-			Type: ValueType(v.Type),
-			Unit: v.Unit,
+			Name:    v.Name,
+			Desc:    v.Desc,
+			Type:    ValueType(v.Type),
+			Unit:    v.Unit,
+			Options: options,
+			Default: v.Default,
+		}
+		if c := v.Constraints; c != nil {
+			res[i].Constraints = &FieldConstraints{
+				Min:       c.Min,
+				Max:       c.Max,
+				MinLength: c.MinLength,
+				MaxLength: c.MaxLength,
+				Pattern:   c.Pattern,
+			}
 		}
 	}
 	return res
 }
 
+func resourceLimitsToDTO(l *ResourceLimits) dto.ResourceLimits {
+	if l == nil {
+		return dto.ResourceLimits{}
+	}
+	return dto.ResourceLimits{
+		MemoryMB: zeroOnNil(l.MemoryMB),
+		CPUs:     zeroOnNil(l.Cpus),
+		Pids:     zeroOnNil(l.Pids),
+		TmpfsMB:  zeroOnNil(l.TmpfsMB),
+	}
+}
+
+func resourceLimitsToAPI(l dto.ResourceLimits) ResourceLimits {
+	return ResourceLimits{
+		MemoryMB: nilOnZero(l.MemoryMB),
+		Cpus:     nilOnZero(l.CPUs),
+		Pids:     nilOnZero(l.Pids),
+		TmpfsMB:  nilOnZero(l.TmpfsMB),
+	}
+}
+
+func retryPolicyToDTO(p *RetryPolicy) dto.RetryPolicy {
+	if p == nil {
+		return dto.RetryPolicy{}
+	}
+	return dto.RetryPolicy{
+		MaxAttempts: p.MaxAttempts,
+		Backoff:     time.Duration(zeroOnNil(p.BackoffSeconds)) * time.Second,
+		On:          string(zeroOnNil(p.RetryOn)),
+	}
+}
+
+func retryPolicyToAPI(p dto.RetryPolicy) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    p.MaxAttempts,
+		BackoffSeconds: nilOnZero(int64(p.Backoff / time.Second)),
+		RetryOn:        nilOnZero(RetryOn(p.On)),
+	}
+}
+
 func blueprintToAPI(b dto.BlueprintWithUser) Blueprint {
 	return Blueprint{
-		ArchiveID:  b.ArchiveID,
-		CreatedAt:  b.CreatedAt,
-		Desc:       nilOnNilOrEmpty(b.Desc),
-		Id:         b.ID,
-		In:         fieldsToAPI(b.In),
-		Name:       b.Name,
-		Out:        fieldsToAPI(b.Out),
-		OwnerID:    b.OwnerID,
-		OwnerName:  b.OwnerName,
-		Visibility: Visibility(b.Visibility),
+		ArchiveID:      b.ArchiveID,
+		BuildStatus:    BuildStatus(b.BuildStatus),
+		CreatedAt:      b.CreatedAt,
+		Desc:           nilOnNilOrEmpty(b.Desc),
+		Id:             b.ID,
+		Limits:         resourceLimitsToAPI(b.Limits),
+		Network:        b.Network,
+		TimeoutSeconds: nilOnZero(int64(b.Timeout / time.Second)),
+		RetryPolicy:    retryPolicyToAPI(b.RetryPolicy),
+		In:             fieldsToAPI(b.In),
+		Name:           b.Name,
+		Out:            fieldsToAPI(b.Out),
+		OwnerID:        b.OwnerID,
+		OwnerName:      b.OwnerName,
+		Visibility:     Visibility(b.Visibility),
 	}
 }
 
@@ -76,19 +153,40 @@ func blueprintsToAPI(bs []dto.BlueprintWithUser) []Blueprint {
 	return res
 }
 
+func jobAttemptsToAPI(as []dto.JobAttempt) []JobAttempt {
+	res := make([]JobAttempt, len(as))
+	for i, a := range as {
+		res[i] = JobAttempt{
+			Number:        a.Number,
+			StartedAt:     a.StartedAt,
+			FinishedAt:    a.FinishedAt,
+			ResultCode:    a.ResultCode,
+			ResultMsg:     a.ResultMsg,
+			FailureReason: (*JobFailureReason)(a.ResultReason),
+		}
+	}
+	return res
+}
+
 func jobToAPI(j dto.Job) Job {
 	return Job{
+		Attempts:      jobAttemptsToAPI(j.Attempts),
 		BlueprintID:   j.BlueprintID,
 		BlueprintName: j.BlueprintName,
 		CreatedAt:     j.CreatedAt,
 		FinishedAt:    j.FinishedAt,
 		Id:            j.ID,
+		ParentJobID:   j.ParentJobID,
+		BatchID:       j.BatchID,
+		ScheduleID:    j.ScheduleID,
 		In:            fieldsToAPI(j.In),
 		Input:         valuesToAPI(j.Input),
 		Out:           fieldsToAPI(j.Out),
 		Output:        valuesToAPI(j.Output),
 		ResultCode:    j.ResultCode,
 		ResultMsg:     j.ResultMsg,
+		FailureReason: (*JobFailureReason)(j.ResultReason),
+		NextAttemptAt: j.NextAttemptAt,
 		StartedAt:     j.StartedAt,
 		State:         JobState(j.State),
 	}
@@ -110,15 +208,98 @@ func startJobRequestToDTO(r StartJobRequest, uid string, blueprintID string) req
 	}
 }
 
+func rerunJobRequestToDTO(r RerunJobRequest, uid string, jobID string) request.RerunJob {
+	var overrides map[int]dto.Value
+	if r.Overrides != nil {
+		overrides = make(map[int]dto.Value, len(*r.Overrides))
+		for _, o := range *r.Overrides {
+			overrides[o.Index] = dto.Value{
+				Type:  string(o.Value.Type),
+				Value: emptyOnNil(o.Value.Value),
+			}
+		}
+	}
+	return request.RerunJob{
+		ActorID:   uid,
+		JobID:     jobID,
+		Overrides: overrides,
+	}
+}
+
+func startBatchRequestToDTO(r StartBatchRequest, uid string, blueprintID string) request.StartBatch {
+	var valueSets [][]dto.Value
+	if r.ValueSets != nil {
+		valueSets = make([][]dto.Value, len(*r.ValueSets))
+		for i, vs := range *r.ValueSets {
+			valueSets[i] = valuesToDTO(vs)
+		}
+	}
+	var sweep []dto.SweepAxis
+	if r.Sweep != nil {
+		sweep = make([]dto.SweepAxis, len(*r.Sweep))
+		for i, a := range *r.Sweep {
+			sweep[i] = sweepAxisToDTO(a)
+		}
+	}
+	return request.StartBatch{
+		ActorID:     uid,
+		BlueprintID: blueprintID,
+		ValueSets:   valueSets,
+		Sweep:       sweep,
+	}
+}
+
+func sweepAxisToDTO(a SweepAxis) dto.SweepAxis {
+	var values []string
+	if a.Values != nil {
+		values = *a.Values
+	}
+	var rng *dto.ValueRange
+	if a.Range != nil {
+		rng = &dto.ValueRange{
+			Start: a.Range.Start,
+			Stop:  a.Range.Stop,
+			Step:  a.Range.Step,
+		}
+	}
+	return dto.SweepAxis{
+		Values: values,
+		Range:  rng,
+	}
+}
+
+func batchToAPI(b dto.Batch) Batch {
+	return Batch{
+		BlueprintID:   b.BlueprintID,
+		BlueprintName: b.BlueprintName,
+		CreatedAt:     b.CreatedAt,
+		Id:            b.ID,
+		JobIDs:        b.JobIDs,
+		OwnerID:       b.OwnerID,
+		Progress: BatchProgress{
+			Cancelled: b.Progress.Cancelled,
+			Failed:    b.Progress.Failed,
+			Finished:  b.Progress.Finished,
+			Pending:   b.Progress.Pending,
+			Running:   b.Progress.Running,
+		},
+		Size: b.Size,
+	}
+}
+
 func createBlueprintToDTO(r CreateBlueprintRequest, uid string) request.CreateBlueprint {
 	return request.CreateBlueprint{
-		ActorID:    uid,
-		ArchiveID:  r.ArchiveID,
-		Name:       r.Name,
-		Desc:       nilOnNilOrEmpty(r.Desc),
-		In:         fieldsToDTO(r.In),
-		Out:        fieldsToDTO(r.Out),
-		Visibility: string(r.Visibility),
+		ActorID:     uid,
+		ArchiveID:   r.ArchiveID,
+		Name:        r.Name,
+		Desc:        nilOnNilOrEmpty(r.Desc),
+		In:          fieldsToDTO(r.In),
+		Out:         fieldsToDTO(r.Out),
+		Limits:      resourceLimitsToDTO(r.Limits),
+		Network:     zeroOnNil(r.Network),
+		Timeout:     time.Duration(zeroOnNil(r.TimeoutSeconds)) * time.Second,
+		RetryPolicy: retryPolicyToDTO(r.RetryPolicy),
+		Visibility:  string(r.Visibility),
 	}
 }
 
@@ -160,3 +341,176 @@ func patchUserToDTO(r PatchUserRequest, actorID string, userID string) request.U
 		Role:     r.Role,
 	}
 }
+
+func createScheduleRequestToDTO(r CreateScheduleRequest, uid string) request.CreateSchedule {
+	return request.CreateSchedule{
+		ActorID:       uid,
+		BlueprintID:   r.BlueprintID,
+		Values:        valuesToDTO(r.Values),
+		Cron:          r.Cron,
+		Timezone:      emptyOnNil(r.Timezone),
+		OverlapPolicy: (*string)(r.OverlapPolicy),
+		Enabled:       r.Enabled,
+	}
+}
+
+func patchScheduleRequestToDTO(r PatchScheduleRequest, uid string, scheduleID string) request.UpdateSchedule {
+	var values *[]dto.Value
+	if r.Values != nil {
+		vs := valuesToDTO(*r.Values)
+		values = &vs
+	}
+	return request.UpdateSchedule{
+		ActorID:       uid,
+		ScheduleID:    scheduleID,
+		Values:        values,
+		Cron:          r.Cron,
+		Timezone:      r.Timezone,
+		OverlapPolicy: (*string)(r.OverlapPolicy),
+		Enabled:       r.Enabled,
+	}
+}
+
+func scheduleToAPI(s dto.Schedule) Schedule {
+	return Schedule{
+		BlueprintID:   s.BlueprintID,
+		CreatedAt:     s.CreatedAt,
+		Cron:          s.Cron,
+		Enabled:       s.Enabled,
+		Id:            s.ID,
+		LastJobID:     s.LastJobID,
+		NextRunAt:     s.NextRunAt,
+		OverlapPolicy: OverlapPolicy(s.OverlapPolicy),
+		OwnerID:       s.OwnerID,
+		Timezone:      s.Timezone,
+		Values:        valuesToAPI(s.Input),
+	}
+}
+
+func schedulesToAPI(ss []dto.Schedule) []Schedule {
+	res := make([]Schedule, len(ss))
+	for i, s := range ss {
+		res[i] = scheduleToAPI(s)
+	}
+	return res
+}
+
+func createPipelineRequestToDTO(r CreatePipelineRequest, uid string) request.CreatePipeline {
+	steps := make([]dto.PipelineStep, len(r.Steps))
+	for i, step := range r.Steps {
+		input := make([]dto.StepInput, len(step.Input))
+		for k, in := range step.Input {
+			input[k] = dto.StepInput{
+				SourceStep:  in.SourceStep,
+				SourceField: in.SourceField,
+			}
+			if in.Value != nil {
+				v := dto.Value{
+					Type:  string(in.Value.Type),
+					Value: emptyOnNil(in.Value.Value),
+				}
+				input[k].Value = &v
+			}
+		}
+		steps[i] = dto.PipelineStep{
+			Name:        step.Name,
+			BlueprintID: step.BlueprintID,
+			Input:       input,
+		}
+	}
+	return request.CreatePipeline{
+		ActorID: uid,
+		Name:    r.Name,
+		Desc:    nilOnNilOrEmpty(r.Desc),
+		Steps:   steps,
+	}
+}
+
+func pipelineToAPI(p dto.Pipeline) Pipeline {
+	steps := make([]PipelineStep, len(p.Steps))
+	for i, step := range p.Steps {
+		input := make([]StepInput, len(step.Input))
+		for k, in := range step.Input {
+			input[k] = StepInput{
+				SourceStep:  in.SourceStep,
+				SourceField: in.SourceField,
+			}
+			if in.Value != nil {
+				v := Value{
+					Type:  ValueType(in.Value.Type),
+					Value: nilOnEmpty(in.Value.Value),
+				}
+				input[k].Value = &v
+			}
+		}
+		steps[i] = PipelineStep{
+			BlueprintID: step.BlueprintID,
+			Input:       input,
+			Name:        step.Name,
+		}
+	}
+	return Pipeline{
+		CreatedAt: p.CreatedAt,
+		Desc:      p.Desc,
+		Id:        p.ID,
+		Name:      p.Name,
+		OwnerID:   p.OwnerID,
+		Steps:     steps,
+	}
+}
+
+func pipelinesToAPI(ps []dto.Pipeline) []Pipeline {
+	res := make([]Pipeline, len(ps))
+	for i, p := range ps {
+		res[i] = pipelineToAPI(p)
+	}
+	return res
+}
+
+func pipelineRunToAPI(r dto.PipelineRun) PipelineRun {
+	steps := make([]StepRun, len(r.Steps))
+	for i, s := range r.Steps {
+		steps[i] = StepRun{
+			JobID:   s.JobID,
+			Message: s.Message,
+			Name:    s.Name,
+			State:   StepState(s.State),
+		}
+	}
+	return PipelineRun{
+		CreatedAt:  r.CreatedAt,
+		FinishedAt: r.FinishedAt,
+		Id:         r.ID,
+		OwnerID:    r.OwnerID,
+		PipelineID: r.PipelineID,
+		State:      PipelineRunState(r.State),
+		Steps:      steps,
+	}
+}
+
+func pipelineRunsToAPI(rs []dto.PipelineRun) []PipelineRun {
+	res := make([]PipelineRun, len(rs))
+	for i, r := range rs {
+		res[i] = pipelineRunToAPI(r)
+	}
+	return res
+}
+
+func deadLetterToAPI(d dto.DeadLetter) DeadLetter {
+	return DeadLetter{
+		JobID:     d.JobID,
+		OwnerID:   d.OwnerID,
+		State:     JobState(d.State),
+		Error:     d.Error,
+		Attempts:  d.Attempts,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+func deadLettersToAPI(ds []dto.DeadLetter) []DeadLetter {
+	res := make([]DeadLetter, len(ds))
+	for i, d := range ds {
+		res[i] = deadLetterToAPI(d)
+	}
+	return res
+}
@@ -13,11 +13,58 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for BuildStatus.
+const (
+	BuildStatusBuilding BuildStatus = "building"
+	BuildStatusFailed   BuildStatus = "failed"
+	BuildStatusQueued   BuildStatus = "queued"
+	BuildStatusReady    BuildStatus = "ready"
+)
+
+// Defines values for JobFailureReason.
+const (
+	BuildFailed         JobFailureReason = "build_failed"
+	InfrastructureError JobFailureReason = "infrastructure_error"
+	InputFileMissing    JobFailureReason = "input_file_missing"
+	Interrupted         JobFailureReason = "interrupted"
+	OutOfMemory         JobFailureReason = "out_of_memory"
+	OutputParseFailed   JobFailureReason = "output_parse_failed"
+	RuntimeError        JobFailureReason = "runtime_error"
+	Timeout             JobFailureReason = "timeout"
+)
+
+// Defines values for JobOutputStream.
+const (
+	Stderr JobOutputStream = "stderr"
+	Stdout JobOutputStream = "stdout"
+)
+
 // Defines values for JobState.
 const (
-	Finished JobState = "finished"
-	Pending  JobState = "pending"
-	Running  JobState = "running"
+	JobStateCancelled JobState = "cancelled"
+	JobStateFinished  JobState = "finished"
+	JobStatePending   JobState = "pending"
+	JobStateRunning   JobState = "running"
+)
+
+// Defines values for OverlapPolicy.
+const (
+	Allow   OverlapPolicy = "allow"
+	Forbid  OverlapPolicy = "forbid"
+	Replace OverlapPolicy = "replace"
+)
+
+// Defines values for PipelineRunState.
+const (
+	PipelineRunStateFailed    PipelineRunState = "failed"
+	PipelineRunStateRunning   PipelineRunState = "running"
+	PipelineRunStateSucceeded PipelineRunState = "succeeded"
+)
+
+// Defines values for RetryOn.
+const (
+	AnyFailure     RetryOn = "any_failure"
+	Infrastructure RetryOn = "infrastructure"
 )
 
 // Defines values for Role.
@@ -26,8 +73,20 @@ const (
 	RoleUser  Role = "user"
 )
 
+// Defines values for StepState.
+const (
+	StepStateFailed    StepState = "failed"
+	StepStateRunning   StepState = "running"
+	StepStateSkipped   StepState = "skipped"
+	StepStateSucceeded StepState = "succeeded"
+	StepStateWaiting   StepState = "waiting"
+)
+
 // Defines values for ValueType.
 const (
+	Boolean ValueType = "boolean"
+	Enum    ValueType = "enum"
+	File    ValueType = "file"
 	Integer ValueType = "integer"
 	Real    ValueType = "real"
 	String  ValueType = "string"
@@ -39,27 +98,74 @@ const (
 	Public  Visibility = "public"
 )
 
+// Batch defines model for Batch.
+type Batch struct {
+	BlueprintID   string        `json:"blueprintID"`
+	BlueprintName string        `json:"blueprintName"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	Id            string        `json:"id"`
+	JobIDs        []string      `json:"jobIDs"`
+	OwnerID       string        `json:"ownerID"`
+	Progress      BatchProgress `json:"progress"`
+	Size          int           `json:"size"`
+}
+
+// BatchProgress Число задач пакета в каждом состоянии; завершённые задачи делятся на успешные и неуспешные.
+type BatchProgress struct {
+	Cancelled int `json:"cancelled"`
+	Failed    int `json:"failed"`
+	Finished  int `json:"finished"`
+	Pending   int `json:"pending"`
+	Running   int `json:"running"`
+}
+
 // Blueprint defines model for Blueprint.
 type Blueprint struct {
-	ArchiveID  string     `json:"archiveID"`
-	CreatedAt  time.Time  `json:"createdAt"`
-	Desc       *string    `json:"desc,omitempty"`
-	Id         string     `json:"id"`
-	In         []Field    `json:"in"`
-	Name       string     `json:"name"`
-	Out        []Field    `json:"out"`
-	OwnerID    string     `json:"ownerID"`
-	OwnerName  string     `json:"ownerName"`
+	ArchiveID   string         `json:"archiveID"`
+	BuildStatus BuildStatus    `json:"buildStatus"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	Desc        *string        `json:"desc,omitempty"`
+	Id          string         `json:"id"`
+	In          []Field        `json:"in"`
+	Limits      ResourceLimits `json:"limits"`
+	Name        string         `json:"name"`
+
+	// Network Запрашивает ли шаблон доступ к сети для своих задач.
+	Network bool `json:"network"`
+
+	Out         []Field     `json:"out"`
+	OwnerID     string      `json:"ownerID"`
+	OwnerName   string      `json:"ownerName"`
+	RetryPolicy RetryPolicy `json:"retryPolicy"`
+
+	// TimeoutSeconds Ограничение времени выполнения задачи в секундах. Отсутствует, если действует ограничение по умолчанию.
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
 	Visibility Visibility `json:"visibility"`
 }
 
+// BuildStatus Состояние сборки образа blueprint. Образ собирается асинхронно после создания blueprint; запуск задач возможен только в состоянии ready.
+type BuildStatus string
+
 // CreateBlueprintRequest defines model for CreateBlueprintRequest.
 type CreateBlueprintRequest struct {
-	ArchiveID  string     `json:"archiveID"`
-	Desc       *string    `json:"desc,omitempty"`
-	In         []Field    `json:"in"`
-	Name       string     `json:"name"`
-	Out        []Field    `json:"out"`
+	ArchiveID string          `json:"archiveID"`
+	Desc      *string         `json:"desc,omitempty"`
+	In        []Field         `json:"in"`
+	Limits    *ResourceLimits `json:"limits,omitempty"`
+	Name      string          `json:"name"`
+
+	// Network Запросить доступ к сети для задач шаблона. По умолчанию задачи запускаются без сети; запрос допустим, только если доступ к сети разрешён администратором.
+	Network *bool `json:"network,omitempty"`
+
+	// Out Выходные поля. Значения полей всех типов, кроме file, скрипт выводит в stdout по одному на строку в порядке полей. Файл поля типа file скрипт записывает в /out/<имя поля>: каталог /out добавляется в образ при сборке и доступен для записи пользователю, от имени которого выполняется задача. Файл, не записанный к успешному завершению скрипта, завершает задачу с ошибкой output_parse_failed. Имя поля типа file должно быть допустимым именем файла (без '/', не '.' и не '..').
+	Out []Field `json:"out"`
+
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// TimeoutSeconds Ограничение времени выполнения задачи в секундах; не может превышать максимум, заданный администратором. Если не указано, действует ограничение по умолчанию. По его истечении задача завершается с причиной timeout.
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
 	Visibility Visibility `json:"visibility"`
 }
 
@@ -68,6 +174,39 @@ type CreateBlueprintResponse struct {
 	BlueprintID string `json:"blueprintID"`
 }
 
+// CreatePipelineRequest defines model for CreatePipelineRequest.
+type CreatePipelineRequest struct {
+	Desc  *string        `json:"desc,omitempty"`
+	Name  string         `json:"name"`
+	Steps []PipelineStep `json:"steps"`
+}
+
+// CreatePipelineResponse defines model for CreatePipelineResponse.
+type CreatePipelineResponse struct {
+	PipelineID string `json:"pipelineID"`
+}
+
+// CreateScheduleRequest defines model for CreateScheduleRequest.
+type CreateScheduleRequest struct {
+	BlueprintID string `json:"blueprintID"`
+	Cron        string `json:"cron"`
+
+	// Enabled По умолчанию true.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	OverlapPolicy *OverlapPolicy `json:"overlapPolicy,omitempty"`
+
+	// Timezone Часовой пояс из базы IANA; по умолчанию UTC.
+	Timezone *string `json:"timezone,omitempty"`
+
+	Values []Value `json:"values"`
+}
+
+// CreateScheduleResponse defines model for CreateScheduleResponse.
+type CreateScheduleResponse struct {
+	ScheduleID string `json:"scheduleID"`
+}
+
 // CreateUserRequest defines model for CreateUserRequest.
 type CreateUserRequest struct {
 	Email    string `json:"email"`
@@ -81,23 +220,105 @@ type CreateUserResponse struct {
 	UserID string `json:"userID"`
 }
 
+// DeadLetter defines model for DeadLetter.
+type DeadLetter struct {
+	// Attempts Число неуспешных попыток обработки задачи.
+	Attempts int `json:"attempts"`
+
+	// CreatedAt Момент, когда задача попала в недоставленные.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Error Ошибка последней попытки обработки задачи.
+	Error string `json:"error"`
+
+	JobID   string   `json:"jobID"`
+	OwnerID string   `json:"ownerID"`
+	State   JobState `json:"state"`
+}
+
 // Field defines model for Field.
 type Field struct {
-	Desc *string   `json:"desc,omitempty"`
-	Name string    `json:"name"`
+	Constraints *FieldConstraints `json:"constraints,omitempty"`
+
+	// Default Значение необязательного входного поля по умолчанию. Значения последних необязательных полей можно не передавать при запуске задачи -- вместо них подставляются значения по умолчанию. Необязательные поля должны следовать после всех обязательных входных полей.
+	Default *string `json:"default,omitempty"`
+
+	Desc *string `json:"desc,omitempty"`
+	Name string  `json:"name"`
+
+	// Options Допустимые значения поля типа enum; для полей остальных типов не указывается.
+	Options *[]string `json:"options,omitempty"`
+
 	Type ValueType `json:"type"`
 	Unit *string   `json:"unit,omitempty"`
 }
 
+// FieldConstraints Ограничения значений входного поля; неуказанные ограничения не проверяются. Значение, нарушающее ограничение, отклоняется при запуске задачи с ошибкой, в которой указано поле.
+type FieldConstraints struct {
+	// Max Наибольшее допустимое значение поля типа integer или real.
+	Max *string `json:"max,omitempty"`
+
+	// MaxLength Наибольшая длина значения поля типа string в символах.
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// Min Наименьшее допустимое значение поля типа integer или real.
+	Min *string `json:"min,omitempty"`
+
+	// MinLength Наименьшая длина значения поля типа string в символах.
+	MinLength *int `json:"minLength,omitempty"`
+
+	// Pattern Регулярное выражение (синтаксис RE2), которому должно целиком соответствовать значение поля типа string.
+	Pattern *string `json:"pattern,omitempty"`
+}
+
+// GetBatchResponse defines model for GetBatchResponse.
+type GetBatchResponse = Batch
+
 // GetBlueprintResponse defines model for GetBlueprintResponse.
 type GetBlueprintResponse = Blueprint
 
 // GetBlueprintsResponse defines model for GetBlueprintsResponse.
 type GetBlueprintsResponse = []Blueprint
 
+// GetBuildLogResponse defines model for GetBuildLogResponse.
+type GetBuildLogResponse struct {
+	BuildStatus BuildStatus `json:"buildStatus"`
+	Log         string      `json:"log"`
+}
+
+// GetDeadLettersResponse defines model for GetDeadLettersResponse.
+type GetDeadLettersResponse = []DeadLetter
+
+// GetJobLogResponse defines model for GetJobLogResponse.
+type GetJobLogResponse struct {
+	Log   string   `json:"log"`
+	State JobState `json:"state"`
+}
+
+// GetJobResponse defines model for GetJobResponse.
+type GetJobResponse = Job
+
 // GetJobsResponse defines model for GetJobsResponse.
 type GetJobsResponse = []Job
 
+// GetPipelineResponse defines model for GetPipelineResponse.
+type GetPipelineResponse = Pipeline
+
+// GetPipelineRunResponse defines model for GetPipelineRunResponse.
+type GetPipelineRunResponse = PipelineRun
+
+// GetPipelineRunsResponse defines model for GetPipelineRunsResponse.
+type GetPipelineRunsResponse = []PipelineRun
+
+// GetPipelinesResponse defines model for GetPipelinesResponse.
+type GetPipelinesResponse = []Pipeline
+
+// GetScheduleResponse defines model for GetScheduleResponse.
+type GetScheduleResponse = Schedule
+
+// GetSchedulesResponse defines model for GetSchedulesResponse.
+type GetSchedulesResponse = []Schedule
+
 // GetUserMeResponse defines model for GetUserMeResponse.
 type GetUserMeResponse = User
 
@@ -112,31 +333,85 @@ type InvalidInputError struct {
 	// Code Уникальный код ошибки.
 	Code string `json:"code"`
 
+	// Field Название поля входных данных, к которому относится ошибка.
+	Field *string `json:"field,omitempty"`
+
 	// Message Сообщение об ошибке.
 	Message *string `json:"message,omitempty"`
 }
 
 // Job defines model for Job.
 type Job struct {
-	BlueprintID   string     `json:"blueprintID"`
-	BlueprintName string     `json:"blueprintName"`
-	CreatedAt     time.Time  `json:"createdAt"`
-	FinishedAt    *time.Time `json:"finishedAt,omitempty"`
-	Id            string     `json:"id"`
-	In            []Field    `json:"in"`
-	Input         []Value    `json:"input"`
-	Out           []Field    `json:"out"`
-	Output        []Value    `json:"output"`
-	OwnerID       string     `json:"ownerID"`
-	ResultCode    *int       `json:"resultCode,omitempty"`
-	ResultMsg     *string    `json:"resultMsg,omitempty"`
-	StartedAt     *time.Time `json:"startedAt,omitempty"`
-	State         JobState   `json:"state"`
+	// Attempts Завершённые попытки выполнения задачи по порядку, включая последнюю.
+	Attempts []JobAttempt `json:"attempts"`
+
+	// BatchID ID пакета, в составе которого запущена задача.
+	BatchID *string `json:"batchID,omitempty"`
+
+	BlueprintID   string            `json:"blueprintID"`
+	BlueprintName string            `json:"blueprintName"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	FailureReason *JobFailureReason `json:"failureReason,omitempty"`
+	FinishedAt    *time.Time        `json:"finishedAt,omitempty"`
+	Id            string            `json:"id"`
+	In            []Field           `json:"in"`
+	Input         []Value           `json:"input"`
+
+	// NextAttemptAt Время, не раньше которого будет выполнена повторная попытка ожидающей задачи.
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
+	Out     []Field `json:"out"`
+	Output  []Value `json:"output"`
+	OwnerID string  `json:"ownerID"`
+
+	// ParentJobID ID задачи, повторным запуском которой является эта задача.
+	ParentJobID *string `json:"parentJobID,omitempty"`
+
+	ResultCode *int    `json:"resultCode,omitempty"`
+	ResultMsg  *string `json:"resultMsg,omitempty"`
+
+	// ScheduleID ID расписания, по которому запущена задача.
+	ScheduleID *string `json:"scheduleID,omitempty"`
+
+	// StartedAt Время запуска текущей или последней попытки.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	State JobState `json:"state"`
+}
+
+// JobAttempt Завершённая попытка выполнения задачи.
+type JobAttempt struct {
+	FailureReason *JobFailureReason `json:"failureReason,omitempty"`
+	FinishedAt    time.Time         `json:"finishedAt"`
+
+	// Number Номер попытки, начиная с 1.
+	Number int `json:"number"`
+
+	ResultCode int       `json:"resultCode"`
+	ResultMsg  *string   `json:"resultMsg,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+}
+
+// JobFailureReason Причина неуспешного завершения задачи. Отсутствует у успешно завершённых задач.
+type JobFailureReason string
+
+// JobOutputEvent Данные события `output` потока событий задачи -- одна строка вывода контейнера.
+type JobOutputEvent struct {
+	Line   string          `json:"line"`
+	Stream JobOutputStream `json:"stream"`
 }
 
+// JobOutputStream defines model for JobOutputStream.
+type JobOutputStream string
+
 // JobState defines model for JobState.
 type JobState string
 
+// JobStateEvent Данные события `state` потока событий задачи.
+type JobStateEvent struct {
+	State JobState `json:"state"`
+}
+
 // LoginRequest defines model for LoginRequest.
 type LoginRequest struct {
 	Email    string `json:"email"`
@@ -148,6 +423,21 @@ type LoginResponse struct {
 	AccessToken string `json:"accessToken"`
 }
 
+// OverlapPolicy Что делает расписание, если задача прошлого срабатывания ещё не завершена: allow -- запускает новую задачу параллельно, forbid -- пропускает срабатывание, replace -- отменяет предыдущую задачу.
+type OverlapPolicy string
+
+// PatchScheduleRequest defines model for PatchScheduleRequest.
+type PatchScheduleRequest struct {
+	Cron          *string        `json:"cron,omitempty"`
+	Enabled       *bool          `json:"enabled,omitempty"`
+	OverlapPolicy *OverlapPolicy `json:"overlapPolicy,omitempty"`
+	Timezone      *string        `json:"timezone,omitempty"`
+	Values        *[]Value       `json:"values,omitempty"`
+}
+
+// PatchScheduleResponse defines model for PatchScheduleResponse.
+type PatchScheduleResponse = Schedule
+
 // PatchUserRequest defines model for PatchUserRequest.
 type PatchUserRequest struct {
 	Email    *string `json:"email,omitempty"`
@@ -159,20 +449,125 @@ type PatchUserRequest struct {
 // PatchUserResponse defines model for PatchUserResponse.
 type PatchUserResponse = User
 
+// Pipeline defines model for Pipeline.
+type Pipeline struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Desc      *string   `json:"desc,omitempty"`
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"ownerID"`
+
+	// Steps Шаги в порядке зависимостей.
+	Steps []PipelineStep `json:"steps"`
+}
+
+// PipelineRun defines model for PipelineRun.
+type PipelineRun struct {
+	CreatedAt  time.Time        `json:"createdAt"`
+	FinishedAt *time.Time       `json:"finishedAt,omitempty"`
+	Id         string           `json:"id"`
+	OwnerID    string           `json:"ownerID"`
+	PipelineID string           `json:"pipelineID"`
+	State      PipelineRunState `json:"state"`
+	Steps      []StepRun        `json:"steps"`
+}
+
+// PipelineRunState defines model for PipelineRunState.
+type PipelineRunState string
+
+// PipelineStep defines model for PipelineStep.
+type PipelineStep struct {
+	BlueprintID string `json:"blueprintID"`
+
+	// Input Источники входных полей шаблона, по одному на каждое поле.
+	Input []StepInput `json:"input"`
+
+	// Name Имя шага, уникальное в пределах конвейера.
+	Name string `json:"name"`
+}
+
 // PlainError defines model for PlainError.
 type PlainError struct {
 	// Message Сообщение об ошибке.
 	Message string `json:"message"`
 }
 
+// RerunJobRequest defines model for RerunJobRequest.
+type RerunJobRequest struct {
+	// Overrides Замены входных значений исходной задачи.
+	Overrides *[]ValueOverride `json:"overrides,omitempty"`
+}
+
+// ResourceLimits Ограничения ресурсов контейнера задачи. Отсутствующее поле означает, что действует глобальное ограничение, заданное администратором; превышать глобальные ограничения нельзя.
+type ResourceLimits struct {
+	// Cpus Доля процессорного времени в числе ядер.
+	Cpus *float64 `json:"cpus,omitempty"`
+
+	// MemoryMB Ограничение памяти в мегабайтах.
+	MemoryMB *int64 `json:"memoryMB,omitempty"`
+
+	// Pids Максимальное число процессов.
+	Pids *int64 `json:"pids,omitempty"`
+
+	// TmpfsMB Размер tmpfs, смонтированной в /tmp, в мегабайтах.
+	TmpfsMB *int64 `json:"tmpfsMB,omitempty"`
+}
+
+// RetryOn Какие неуспешные завершения задачи повторяются: infrastructure -- только сбои инфраструктуры и прерывание исполнителя, any_failure -- также ненулевой код возврата, превышение времени выполнения и нехватка памяти. Ошибки сборки образа и разбора вывода не повторяются никогда. Сбой инфраструктуры при запуске (например, недоступность Docker) не завершает попытку: доставку задачи повторяет очередь, а после последнего повтора задача попадает в недоставленные (см. GET /dead-letters).
+type RetryOn string
+
+// RetryPolicy Политика повторного запуска неуспешно завершившихся задач шаблона. Задержка перед первым повтором равна backoffSeconds и удваивается перед каждым следующим.
+type RetryPolicy struct {
+	// BackoffSeconds Задержка перед первым повтором в секундах.
+	BackoffSeconds *int64 `json:"backoffSeconds,omitempty"`
+
+	// MaxAttempts Наибольшее число попыток, включая первую, от 0 до 10; значения 0 и 1 отключают повторы.
+	MaxAttempts int `json:"maxAttempts"`
+
+	RetryOn *RetryOn `json:"retryOn,omitempty"`
+}
+
 // Role defines model for Role.
 type Role string
 
+// Schedule defines model for Schedule.
+type Schedule struct {
+	BlueprintID string    `json:"blueprintID"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Cron        string    `json:"cron"`
+	Enabled     bool      `json:"enabled"`
+	Id          string    `json:"id"`
+
+	// LastJobID ID задачи последнего срабатывания.
+	LastJobID *string `json:"lastJobID,omitempty"`
+
+	// NextRunAt Время следующего срабатывания; отсутствует у выключенного расписания.
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+
+	OverlapPolicy OverlapPolicy `json:"overlapPolicy"`
+	OwnerID       string        `json:"ownerID"`
+	Timezone      string        `json:"timezone"`
+	Values        []Value       `json:"values"`
+}
+
 // SearchBlueprintsResponse defines model for SearchBlueprintsResponse.
 type SearchBlueprintsResponse = []Blueprint
 
+// StartBatchRequest Ровно одно из полей -- valueSets или sweep (по одной оси на каждое входное поле).
+type StartBatchRequest struct {
+	Sweep     *[]SweepAxis `json:"sweep,omitempty"`
+	ValueSets *[][]Value   `json:"valueSets,omitempty"`
+}
+
+// StartBatchResponse defines model for StartBatchResponse.
+type StartBatchResponse struct {
+	BatchID string   `json:"batchID"`
+	JobIDs  []string `json:"jobIDs"`
+}
+
 // StartJobRequest defines model for StartJobRequest.
 type StartJobRequest struct {
+	// Values Значения входных полей по порядку. Значения последних необязательных полей можно опустить.
 	Values []Value `json:"values"`
 }
 
@@ -181,6 +576,42 @@ type StartJobResponse struct {
 	JobID string `json:"jobID"`
 }
 
+// StartPipelineResponse defines model for StartPipelineResponse.
+type StartPipelineResponse struct {
+	// JobIDs Задачи шагов, запущенных сразу.
+	JobIDs []string `json:"jobIDs"`
+
+	RunID string `json:"runID"`
+}
+
+// StepInput Источник значения входного поля шага: постоянное значение value либо выходное поле с индексом sourceField шага sourceStep.
+type StepInput struct {
+	SourceField *int    `json:"sourceField,omitempty"`
+	SourceStep  *string `json:"sourceStep,omitempty"`
+	Value       *Value  `json:"value,omitempty"`
+}
+
+// StepRun defines model for StepRun.
+type StepRun struct {
+	// JobID ID задачи шага; отсутствует, если задача не запускалась.
+	JobID *string `json:"jobID,omitempty"`
+
+	// Message Почему шаг не выполнен.
+	Message *string `json:"message,omitempty"`
+
+	Name  string    `json:"name"`
+	State StepState `json:"state"`
+}
+
+// StepState Состояние шага запуска конвейера: waiting -- ожидает завершения зависимостей, running -- задача шага запущена, succeeded -- задача завершилась успешно, failed -- задачу не удалось запустить, либо она завершилась неуспешно или была отменена, skipped -- не выполнен один из шагов, от которых зависит шаг.
+type StepState string
+
+// SweepAxis Значения одного входного поля при переборе -- явный список values либо диапазон range.
+type SweepAxis struct {
+	Range  *ValueRange `json:"range,omitempty"`
+	Values *[]string   `json:"values,omitempty"`
+}
+
 // UploadFileResponse defines model for UploadFileResponse.
 type UploadFileResponse struct {
 	FileID string  `json:"fileID"`
@@ -202,12 +633,39 @@ type Value struct {
 	Value *string   `json:"value,omitempty"`
 }
 
-// ValueType defines model for ValueType.
+// ValueOverride defines model for ValueOverride.
+type ValueOverride struct {
+	// Index Индекс заменяемого входного значения, начиная с 0.
+	Index int `json:"index"`
+
+	Value Value `json:"value"`
+}
+
+// ValueRange Числовой диапазон от start до stop включительно с шагом step.
+type ValueRange struct {
+	Start string `json:"start"`
+	Step  string `json:"step"`
+	Stop  string `json:"stop"`
+}
+
+// ValueType Тип значения. Значение boolean передаётся как "true" или "false"; значение enum -- как один из вариантов (options) поля. Значение file -- ID файла, загруженного этим же пользователем через POST /files; задача получает файл только для чтения и вместо ID -- путь к нему в контейнере (/input/<ID файла>). Выходное поле file скрипт заполняет не строкой вывода, а файлом /out/<имя поля>; в результате задачи значение -- ID сохранённого файла (см. GET /jobs/{id}/artifacts/{name}).
 type ValueType string
 
 // Visibility defines model for Visibility.
 type Visibility string
 
+// WorkerStats defines model for WorkerStats.
+type WorkerStats struct {
+	// BusyWorkers Число задач, выполняемых в данный момент всеми исполнителями.
+	BusyWorkers int `json:"busyWorkers"`
+
+	// MaxConcurrentJobs Максимальное число задач, одновременно выполняемых одним исполнителем.
+	MaxConcurrentJobs int `json:"maxConcurrentJobs"`
+
+	// QueueDepth Число задач, ожидающих свободного обработчика.
+	QueueDepth int `json:"queueDepth"`
+}
+
 // SearchBlueprintsParams defines parameters for SearchBlueprints.
 type SearchBlueprintsParams struct {
 	// Name Название или часть названия шаблона (blueprint).
@@ -231,12 +689,27 @@ type LoginJSONRequestBody = LoginRequest
 // CreateBlueprintJSONRequestBody defines body for CreateBlueprint for application/json ContentType.
 type CreateBlueprintJSONRequestBody = CreateBlueprintRequest
 
+// StartBatchJSONRequestBody defines body for StartBatch for application/json ContentType.
+type StartBatchJSONRequestBody = StartBatchRequest
+
 // StartJobJSONRequestBody defines body for StartJob for application/json ContentType.
 type StartJobJSONRequestBody = StartJobRequest
 
 // UploadFileMultipartRequestBody defines body for UploadFile for multipart/form-data ContentType.
 type UploadFileMultipartRequestBody UploadFileMultipartBody
 
+// RerunJobJSONRequestBody defines body for RerunJob for application/json ContentType.
+type RerunJobJSONRequestBody = RerunJobRequest
+
+// CreatePipelineJSONRequestBody defines body for CreatePipeline for application/json ContentType.
+type CreatePipelineJSONRequestBody = CreatePipelineRequest
+
+// CreateScheduleJSONRequestBody defines body for CreateSchedule for application/json ContentType.
+type CreateScheduleJSONRequestBody = CreateScheduleRequest
+
+// PatchScheduleJSONRequestBody defines body for PatchSchedule for application/json ContentType.
+type PatchScheduleJSONRequestBody = PatchScheduleRequest
+
 // CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
 type CreateUserJSONRequestBody = CreateUserRequest
 
@@ -2,11 +2,14 @@ package apiv2
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/go-chi/render"
 
 	"github.com/bmstu-itstech/scriptum-back/internal/app"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
 	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
 	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
 	"github.com/bmstu-itstech/scriptum-back/internal/domain"
@@ -60,115 +63,649 @@ func (s *Server) StartJob(w http.ResponseWriter, r *http.Request, id string) {
 }
 
 func (s *Server) UploadFile(w http.ResponseWriter, r *http.Request) {
-	_, ok := jwtauth.FromContext(r.Context())
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	err := r.ParseMultipartForm(maxMultipartFormDataSize)
+	if err != nil {
+		renderPlainError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	f, header, err := r.FormFile("attachment")
+	if err != nil {
+		renderPlainError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	fileID, err := s.app.Commands.UploadFile.Handle(r.Context(), request.UploadFileRequest{
+		ActorID: uid,
+		Name:    header.Filename,
+		Reader:  f,
+	})
+	if err != nil {
+		renderPlainError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	// TODO: return file size
+	res := UploadFileResponse{FileID: fileID, Size: 0}
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) GetBlueprints(w http.ResponseWriter, r *http.Request) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	bs, err := s.app.Queries.GetBlueprints.Handle(r.Context(), request.GetBlueprints{ActorID: uid})
+	if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := blueprintsToAPI(bs)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) CreateBlueprint(w http.ResponseWriter, r *http.Request) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	req := CreateBlueprintRequest{}
+	if err := render.Decode(r, &req); err != nil {
+		renderPlainError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	id, err := s.app.Commands.CreateBlueprint.Handle(r.Context(), createBlueprintToDTO(req, uid))
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		renderInvalidInputError(w, r, iiErr, http.StatusBadRequest)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := CreateBlueprintResponse{BlueprintID: id}
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) SearchBlueprints(w http.ResponseWriter, r *http.Request, params SearchBlueprintsParams) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	bs, err := s.app.Queries.SearchBlueprints.Handle(r.Context(), request.SearchBlueprints{ActorID: uid, Name: params.Name})
+	if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := blueprintsToAPI(bs)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) DeleteBlueprint(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	err := s.app.Commands.DeleteBlueprint.Handle(r.Context(), request.DeleteBlueprint{BlueprintID: id, ActorID: uid})
+	if errors.Is(err, ports.ErrBlueprintNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+func (s *Server) GetBlueprint(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	b, err := s.app.Queries.GetBlueprint.Handle(r.Context(), request.GetBlueprint{BlueprintID: id, ActorID: uid})
+	if errors.Is(err, ports.ErrBlueprintNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := blueprintToAPI(b)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) GetBuildLog(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	bl, err := s.app.Queries.GetBuildLog.Handle(r.Context(), request.GetBuildLog{BlueprintID: id, ActorID: uid})
+	if errors.Is(err, ports.ErrBlueprintNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := GetBuildLogResponse{
+		BuildStatus: BuildStatus(bl.BuildStatus),
+		Log:         bl.Log,
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) GetJobLog(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	jl, err := s.app.Queries.GetJobLog.Handle(r.Context(), request.GetJobLog{JobID: id, ActorID: uid})
+	if errors.Is(err, ports.ErrJobNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := GetJobLogResponse{
+		State: JobState(jl.State),
+		Log:   jl.Log,
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) GetJobArtifact(w http.ResponseWriter, r *http.Request, id string, name string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	a, err := s.app.Queries.GetJobArtifact.Handle(
+		r.Context(), request.GetJobArtifact{ActorID: uid, JobID: id, Name: name},
+	)
+	if errors.Is(err, ports.ErrJobNotFound) || errors.Is(err, ports.ErrArtifactNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+	defer func() { _ = a.Content.Close() }()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", a.Name))
+	w.WriteHeader(http.StatusOK)
+	// Заголовки уже отправлены, поэтому ошибку записи сообщить клиенту нельзя.
+	_, _ = io.Copy(w, a.Content)
+}
+
+func (s *Server) GetJobEvents(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	wj, err := s.app.Queries.WatchJob.Handle(r.Context(), request.WatchJob{JobID: id, ActorID: uid})
+	if errors.Is(err, ports.ErrJobNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	err = writeSSE(w, dto.JobEventState, JobStateEvent{State: JobState(wj.Job.State)})
+	if err != nil {
+		return
+	}
+	// Канал закрывается после перехода задачи в конечное состояние или отключения клиента.
+	for e := range wj.Events {
+		event, data := jobEventToSSE(e)
+		if err = writeSSE(w, event, data); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) GetJobs(w http.ResponseWriter, r *http.Request, params GetJobsParams) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	js, err := s.app.Queries.GetJobs.Handle(r.Context(), request.GetJobs{ActorID: uid, State: (*string)(params.State)})
+	if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := jobsToAPI(js)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) GetJob(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	j, err := s.app.Queries.GetJob.Handle(r.Context(), request.GetJob{ActorID: uid, JobID: id})
+	if errors.Is(err, ports.ErrJobNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := jobToAPI(j)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) CancelJob(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	err := s.app.Commands.CancelJob.Handle(r.Context(), request.CancelJob{ActorID: uid, JobID: id})
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		renderInvalidInputError(w, r, iiErr, http.StatusBadRequest)
+		return
+	} else if errors.Is(err, ports.ErrJobNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+func (s *Server) RerunJob(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	// Тело запроса необязательно: без него задача перезапускается с теми же входными данными.
+	req := RerunJobRequest{}
+	if err := render.Decode(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		renderPlainError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	jobID, err := s.app.Commands.RerunJob.Handle(r.Context(), rerunJobRequestToDTO(req, uid, id))
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		renderInvalidInputError(w, r, iiErr, http.StatusBadRequest)
+		return
+	} else if errors.Is(err, ports.ErrJobNotFound) || errors.Is(err, ports.ErrBlueprintNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := StartJobResponse{JobID: jobID}
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) StartBatch(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	req := StartBatchRequest{}
+	if err := render.Decode(r, &req); err != nil {
+		renderPlainError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	b, err := s.app.Commands.StartBatch.Handle(r.Context(), startBatchRequestToDTO(req, uid, id))
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		renderInvalidInputError(w, r, iiErr, http.StatusBadRequest)
+		return
+	} else if errors.Is(err, ports.ErrBlueprintNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := StartBatchResponse{BatchID: b.BatchID, JobIDs: b.JobIDs}
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) GetBatch(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	b, err := s.app.Queries.GetBatch.Handle(r.Context(), request.GetBatch{ActorID: uid, BatchID: id})
+	if errors.Is(err, ports.ErrBatchNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := batchToAPI(b)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) GetBatchResults(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
 	if !ok {
 		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
 		return
 	}
 
-	err := r.ParseMultipartForm(maxMultipartFormDataSize)
-	if err != nil {
-		renderPlainError(w, r, err, http.StatusBadRequest)
+	js, err := s.app.Queries.GetBatchResults.Handle(r.Context(), request.GetBatchResults{ActorID: uid, BatchID: id})
+	if errors.Is(err, ports.ErrBatchNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
 		return
 	}
 
-	f, header, err := r.FormFile("attachment")
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "batch-"+id+".csv"))
+	w.WriteHeader(http.StatusOK)
+	// Заголовки уже отправлены, поэтому ошибку записи сообщить клиенту нельзя.
+	_ = writeBatchResultsCSV(w, js)
+}
+
+func (s *Server) GetSchedules(w http.ResponseWriter, r *http.Request) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	ss, err := s.app.Queries.GetSchedules.Handle(r.Context(), request.GetSchedules{ActorID: uid})
 	if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := schedulesToAPI(ss)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	req := CreateScheduleRequest{}
+	if err := render.Decode(r, &req); err != nil {
 		renderPlainError(w, r, err, http.StatusBadRequest)
 		return
 	}
-	defer func() {
-		_ = f.Close()
-	}()
 
-	fileID, err := s.app.Commands.UploadFile.Handle(r.Context(), request.UploadFileRequest{
-		Name:   header.Filename,
-		Reader: f,
-	})
-	if err != nil {
-		renderPlainError(w, r, err, http.StatusInternalServerError)
+	id, err := s.app.Commands.CreateSchedule.Handle(r.Context(), createScheduleRequestToDTO(req, uid))
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		renderInvalidInputError(w, r, iiErr, http.StatusBadRequest)
+		return
+	} else if errors.Is(err, ports.ErrBlueprintNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
 		return
 	}
 
-	// TODO: return file size
-	res := UploadFileResponse{FileID: fileID, Size: 0}
+	res := CreateScheduleResponse{ScheduleID: id}
 	render.Status(r, http.StatusCreated)
 	render.JSON(w, r, res)
 }
 
-func (s *Server) GetBlueprints(w http.ResponseWriter, r *http.Request) {
+func (s *Server) GetSchedule(w http.ResponseWriter, r *http.Request, id string) {
 	uid, ok := jwtauth.FromContext(r.Context())
 	if !ok {
 		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
 		return
 	}
 
-	bs, err := s.app.Queries.GetBlueprints.Handle(r.Context(), request.GetBlueprints{ActorID: uid})
-	if err != nil {
+	sc, err := s.app.Queries.GetSchedule.Handle(r.Context(), request.GetSchedule{ActorID: uid, ScheduleID: id})
+	if errors.Is(err, ports.ErrScheduleNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
 		renderInternalServerError(w, r)
 		return
 	}
 
-	res := blueprintsToAPI(bs)
+	res := scheduleToAPI(sc)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, res)
 }
 
-func (s *Server) CreateBlueprint(w http.ResponseWriter, r *http.Request) {
+func (s *Server) PatchSchedule(w http.ResponseWriter, r *http.Request, id string) {
 	uid, ok := jwtauth.FromContext(r.Context())
 	if !ok {
 		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
 		return
 	}
 
-	req := CreateBlueprintRequest{}
+	req := PatchScheduleRequest{}
 	if err := render.Decode(r, &req); err != nil {
 		renderPlainError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
-	id, err := s.app.Commands.CreateBlueprint.Handle(r.Context(), createBlueprintToDTO(req, uid))
+	sc, err := s.app.Commands.UpdateSchedule.Handle(r.Context(), patchScheduleRequestToDTO(req, uid, id))
 	var iiErr domain.InvalidInputError
 	if errors.As(err, &iiErr) {
 		renderInvalidInputError(w, r, iiErr, http.StatusBadRequest)
 		return
+	} else if errors.Is(err, ports.ErrScheduleNotFound) || errors.Is(err, ports.ErrBlueprintNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
 	} else if err != nil {
 		renderInternalServerError(w, r)
 		return
 	}
 
-	res := CreateBlueprintResponse{BlueprintID: id}
-	render.Status(r, http.StatusCreated)
+	res := scheduleToAPI(sc)
+	render.Status(r, http.StatusOK)
 	render.JSON(w, r, res)
 }
 
-func (s *Server) SearchBlueprints(w http.ResponseWriter, r *http.Request, params SearchBlueprintsParams) {
+func (s *Server) DeleteSchedule(w http.ResponseWriter, r *http.Request, id string) {
 	uid, ok := jwtauth.FromContext(r.Context())
 	if !ok {
 		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
 		return
 	}
 
-	bs, err := s.app.Queries.SearchBlueprints.Handle(r.Context(), request.SearchBlueprints{ActorID: uid, Name: params.Name})
+	err := s.app.Commands.DeleteSchedule.Handle(r.Context(), request.DeleteSchedule{ActorID: uid, ScheduleID: id})
+	if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+func (s *Server) GetScheduleJobs(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	js, err := s.app.Queries.GetScheduleJobs.Handle(r.Context(), request.GetScheduleJobs{ActorID: uid, ScheduleID: id})
+	if errors.Is(err, ports.ErrScheduleNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := jobsToAPI(js)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) GetPipelines(w http.ResponseWriter, r *http.Request) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	ps, err := s.app.Queries.GetPipelines.Handle(r.Context(), request.GetPipelines{ActorID: uid})
 	if err != nil {
 		renderInternalServerError(w, r)
 		return
 	}
 
-	res := blueprintsToAPI(bs)
+	res := pipelinesToAPI(ps)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, res)
 }
 
-func (s *Server) DeleteBlueprint(w http.ResponseWriter, r *http.Request, id string) {
+func (s *Server) CreatePipeline(w http.ResponseWriter, r *http.Request) {
 	uid, ok := jwtauth.FromContext(r.Context())
 	if !ok {
 		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
 		return
 	}
 
-	err := s.app.Commands.DeleteBlueprint.Handle(r.Context(), request.DeleteBlueprint{BlueprintID: id, ActorID: uid})
-	if errors.Is(err, ports.ErrBlueprintNotFound) {
+	req := CreatePipelineRequest{}
+	if err := render.Decode(r, &req); err != nil {
+		renderPlainError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	id, err := s.app.Commands.CreatePipeline.Handle(r.Context(), createPipelineRequestToDTO(req, uid))
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		renderInvalidInputError(w, r, iiErr, http.StatusBadRequest)
+		return
+	} else if errors.Is(err, ports.ErrBlueprintNotFound) {
 		renderPlainError(w, r, err, http.StatusNotFound)
 		return
 	} else if errors.Is(err, domain.ErrPermissionDenied) {
@@ -179,18 +716,20 @@ func (s *Server) DeleteBlueprint(w http.ResponseWriter, r *http.Request, id stri
 		return
 	}
 
-	render.NoContent(w, r)
+	res := CreatePipelineResponse{PipelineID: id}
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, res)
 }
 
-func (s *Server) GetBlueprint(w http.ResponseWriter, r *http.Request, id string) {
+func (s *Server) GetPipeline(w http.ResponseWriter, r *http.Request, id string) {
 	uid, ok := jwtauth.FromContext(r.Context())
 	if !ok {
 		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
 		return
 	}
 
-	b, err := s.app.Queries.GetBlueprint.Handle(r.Context(), request.GetBlueprint{BlueprintID: id, ActorID: uid})
-	if errors.Is(err, ports.ErrBlueprintNotFound) {
+	p, err := s.app.Queries.GetPipeline.Handle(r.Context(), request.GetPipeline{ActorID: uid, PipelineID: id})
+	if errors.Is(err, ports.ErrPipelineNotFound) {
 		renderPlainError(w, r, err, http.StatusNotFound)
 		return
 	} else if errors.Is(err, domain.ErrPermissionDenied) {
@@ -201,38 +740,67 @@ func (s *Server) GetBlueprint(w http.ResponseWriter, r *http.Request, id string)
 		return
 	}
 
-	res := blueprintToAPI(b)
+	res := pipelineToAPI(p)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, res)
 }
 
-func (s *Server) GetJobs(w http.ResponseWriter, r *http.Request, params GetJobsParams) {
+func (s *Server) DeletePipeline(w http.ResponseWriter, r *http.Request, id string) {
 	uid, ok := jwtauth.FromContext(r.Context())
 	if !ok {
 		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
 		return
 	}
 
-	js, err := s.app.Queries.GetJobs.Handle(r.Context(), request.GetJobs{ActorID: uid, State: (*string)(params.State)})
-	if err != nil {
+	err := s.app.Commands.DeletePipeline.Handle(r.Context(), request.DeletePipeline{ActorID: uid, PipelineID: id})
+	if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
 		renderInternalServerError(w, r)
 		return
 	}
 
-	res := jobsToAPI(js)
+	render.NoContent(w, r)
+}
+
+func (s *Server) GetPipelineRuns(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	rs, err := s.app.Queries.GetPipelineRuns.Handle(r.Context(), request.GetPipelineRuns{ActorID: uid, PipelineID: id})
+	if errors.Is(err, ports.ErrPipelineNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := pipelineRunsToAPI(rs)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, res)
 }
 
-func (s *Server) GetJob(w http.ResponseWriter, r *http.Request, id string) {
+func (s *Server) StartPipeline(w http.ResponseWriter, r *http.Request, id string) {
 	uid, ok := jwtauth.FromContext(r.Context())
 	if !ok {
 		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
 		return
 	}
 
-	j, err := s.app.Queries.GetJob.Handle(r.Context(), request.GetJob{ActorID: uid, JobID: id})
-	if errors.Is(err, ports.ErrJobNotFound) {
+	resp, err := s.app.Commands.StartPipeline.Handle(r.Context(), request.StartPipeline{ActorID: uid, PipelineID: id})
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		renderInvalidInputError(w, r, iiErr, http.StatusBadRequest)
+		return
+	} else if errors.Is(err, ports.ErrPipelineNotFound) || errors.Is(err, ports.ErrBlueprintNotFound) {
 		renderPlainError(w, r, err, http.StatusNotFound)
 		return
 	} else if errors.Is(err, domain.ErrPermissionDenied) {
@@ -243,7 +811,31 @@ func (s *Server) GetJob(w http.ResponseWriter, r *http.Request, id string) {
 		return
 	}
 
-	res := jobToAPI(j)
+	res := StartPipelineResponse{RunID: resp.RunID, JobIDs: resp.JobIDs}
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) GetPipelineRun(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	run, err := s.app.Queries.GetPipelineRun.Handle(r.Context(), request.GetPipelineRun{ActorID: uid, RunID: id})
+	if errors.Is(err, ports.ErrPipelineRunNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := pipelineRunToAPI(run)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, res)
 }
@@ -430,3 +1022,75 @@ func (s *Server) PatchUser(w http.ResponseWriter, r *http.Request, id string) {
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, res)
 }
+
+func (s *Server) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	dls, err := s.app.Queries.GetDeadLetters.Handle(r.Context(), request.GetDeadLetters{ActorID: uid})
+	if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := deadLettersToAPI(dls)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
+
+func (s *Server) RequeueDeadLetter(w http.ResponseWriter, r *http.Request, id string) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	err := s.app.Commands.RequeueDeadLetter.Handle(r.Context(), request.RequeueDeadLetter{ActorID: uid, JobID: id})
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		renderInvalidInputError(w, r, iiErr, http.StatusBadRequest)
+		return
+	} else if errors.Is(err, ports.ErrDeadLetterNotFound) {
+		renderPlainError(w, r, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+func (s *Server) GetWorkerStats(w http.ResponseWriter, r *http.Request) {
+	uid, ok := jwtauth.FromContext(r.Context())
+	if !ok {
+		renderPlainError(w, r, ErrAuthorizationRequired, http.StatusUnauthorized)
+		return
+	}
+
+	stats, err := s.app.Queries.GetWorkerStats.Handle(r.Context(), request.GetWorkerStats{ActorID: uid})
+	if errors.Is(err, domain.ErrPermissionDenied) {
+		renderPlainError(w, r, err, http.StatusForbidden)
+		return
+	} else if err != nil {
+		renderInternalServerError(w, r)
+		return
+	}
+
+	res := WorkerStats{
+		MaxConcurrentJobs: stats.MaxConcurrentJobs,
+		BusyWorkers:       stats.BusyWorkers,
+		QueueDepth:        stats.QueueDepth,
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, res)
+}
@@ -22,3 +22,19 @@ func emptyOnNil(s *string) string {
 	}
 	return *s
 }
+
+func nilOnZero[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+func zeroOnNil[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
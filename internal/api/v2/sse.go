@@ -0,0 +1,32 @@
+package apiv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+)
+
+// writeSSE записывает одно событие Server-Sent Events и сразу отправляет его клиенту.
+func writeSSE(w http.ResponseWriter, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+	if err != nil {
+		return err
+	}
+	return http.NewResponseController(w).Flush()
+}
+
+func jobEventToSSE(e dto.JobEvent) (string, any) {
+	if e.Kind == dto.JobEventOutput {
+		return dto.JobEventOutput, JobOutputEvent{
+			Stream: JobOutputStream(e.Stream),
+			Line:   e.Line,
+		}
+	}
+	return dto.JobEventState, JobStateEvent{State: JobState(e.State)}
+}
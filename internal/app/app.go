@@ -2,32 +2,66 @@ package app
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/bmstu-itstech/scriptum-back/internal/app/command"
 	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
 	"github.com/bmstu-itstech/scriptum-back/internal/app/query"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
 )
 
 type Commands struct {
-	CreateBlueprint command.CreateBlueprintHandler
-	CreateUser      command.CreateUserHandler
-	DeleteBlueprint command.DeleteBlueprintHandler
-	DeleteUser      command.DeleteUserHandler
-	Login           command.LoginHandler
-	RunJob          command.RunJobHandler
-	StartJob        command.StartJobHandler
-	UpdateUser      command.UpdateUserHandler
-	UploadFile      command.UploadFileHandler
+	AdvancePipelines  command.AdvancePipelinesHandler
+	BuildBlueprint    command.BuildBlueprintHandler
+	CancelJob         command.CancelJobHandler
+	CreateBlueprint   command.CreateBlueprintHandler
+	CreatePipeline    command.CreatePipelineHandler
+	CreateSchedule    command.CreateScheduleHandler
+	CreateUser        command.CreateUserHandler
+	DeadLetterJob     command.DeadLetterJobHandler
+	DeleteBlueprint   command.DeleteBlueprintHandler
+	DeletePipeline    command.DeletePipelineHandler
+	DeleteSchedule    command.DeleteScheduleHandler
+	DeleteUser        command.DeleteUserHandler
+	Login             command.LoginHandler
+	ReconcileJobs     command.ReconcileJobsHandler
+	RequeueBuilds     command.RequeueBuildsHandler
+	RequeueDeadLetter command.RequeueDeadLetterHandler
+	RerunJob          command.RerunJobHandler
+	RunJob            command.RunJobHandler
+	RunSchedules      command.RunSchedulesHandler
+	StartBatch        command.StartBatchHandler
+	StartJob          command.StartJobHandler
+	StartPipeline     command.StartPipelineHandler
+	StopCancelledJobs command.StopCancelledJobsHandler
+	UpdateSchedule    command.UpdateScheduleHandler
+	UpdateUser        command.UpdateUserHandler
+	UploadFile        command.UploadFileHandler
 }
 
 type Queries struct {
+	GetBatch         query.GetBatchHandler
+	GetBatchResults  query.GetBatchResultsHandler
 	GetBlueprint     query.GetBlueprintHandler
 	GetBlueprints    query.GetBlueprintsHandler
+	GetBuildLog      query.GetBuildLogHandler
+	GetDeadLetters   query.GetDeadLettersHandler
 	GetJob           query.GetJobHandler
+	GetJobArtifact   query.GetJobArtifactHandler
+	GetJobLog        query.GetJobLogHandler
 	GetJobs          query.GetJobsHandler
+	GetPipeline      query.GetPipelineHandler
+	GetPipelineRun   query.GetPipelineRunHandler
+	GetPipelineRuns  query.GetPipelineRunsHandler
+	GetPipelines     query.GetPipelinesHandler
+	GetSchedule      query.GetScheduleHandler
+	GetScheduleJobs  query.GetScheduleJobsHandler
+	GetSchedules     query.GetSchedulesHandler
 	GetUser          query.GetUserHandler
 	GetUsers         query.GetUsersHandler
+	GetWorkerStats   query.GetWorkerStatsHandler
 	SearchBlueprints query.SearchBlueprintsHandler
+	WatchJob         query.WatchJobHandler
 }
 
 type App struct {
@@ -36,41 +70,125 @@ type App struct {
 }
 
 type Infra struct {
-	BlueprintProvider   ports.BlueprintProvider
-	BlueprintRepository ports.BlueprintRepository
-	FileReader          ports.FileReader
-	FileUploader        ports.FileUploader
-	JobProvider         ports.JobProvider
-	JobPublisher        ports.JobPublisher
-	JobRepository       ports.JobRepository
-	PasswordHasher      ports.PasswordHasher
-	Runner              ports.Runner
-	TokenService        ports.TokenService
-	UserProvider        ports.UserProvider
-	UserRepository      ports.UserRepository
+	BatchProvider        ports.BatchProvider
+	BatchRepository      ports.BatchRepository
+	BlueprintPublisher   ports.BlueprintPublisher
+	BlueprintProvider    ports.BlueprintProvider
+	BlueprintRepository  ports.BlueprintRepository
+	BuildLogProvider     ports.BuildLogProvider
+	BuildLogRepository   ports.BuildLogRepository
+	DeadLetterProvider   ports.DeadLetterProvider
+	DeadLetterRepository ports.DeadLetterRepository
+	FileReader           ports.FileReader
+	FileRepository       ports.FileRepository
+	FileUploader         ports.FileUploader
+	JobEventPublisher    ports.JobEventPublisher
+	JobEventSubscriber   ports.JobEventSubscriber
+	JobLogProvider       ports.JobLogProvider
+	JobLogRepository     ports.JobLogRepository
+	JobProvider          ports.JobProvider
+	JobReconcileProvider ports.JobReconcileProvider
+	JobRepository        ports.JobRepository
+	PasswordHasher       ports.PasswordHasher
+	PipelineRepository   ports.PipelineRepository
+	Runner               ports.Runner
+	ScheduleProvider     ports.ScheduleProvider
+	ScheduleRepository   ports.ScheduleRepository
+	TokenService         ports.TokenService
+	UserProvider         ports.UserProvider
+	UserRepository       ports.UserRepository
+	WorkerStatsProvider  ports.WorkerStatsProvider
 }
 
-func NewApp(infra Infra, l *slog.Logger) *App {
+// Policy -- ограничения, задаваемые администратором сервиса.
+type Policy struct {
+	MaxLimits    value.ResourceLimits
+	AllowNetwork bool
+	MaxTimeout   time.Duration
+}
+
+func NewApp(infra Infra, policy Policy, l *slog.Logger) *App {
 	return &App{
 		Commands: Commands{
-			CreateBlueprint: command.NewCreateBlueprintHandler(infra.BlueprintRepository, infra.UserProvider, l),
+			AdvancePipelines: command.NewAdvancePipelinesHandler(
+				infra.PipelineRepository, infra.BlueprintRepository, l,
+			),
+			BuildBlueprint: command.NewBuildBlueprintHandler(
+				infra.BlueprintRepository, infra.BuildLogRepository, infra.FileReader, infra.Runner, l,
+			),
+			CancelJob: command.NewCancelJobHandler(infra.JobRepository, infra.Runner, infra.JobEventPublisher, l),
+			CreateBlueprint: command.NewCreateBlueprintHandler(
+				infra.BlueprintRepository, infra.BlueprintPublisher, infra.UserProvider,
+				policy.MaxLimits, policy.AllowNetwork, policy.MaxTimeout, l,
+			),
+			CreatePipeline: command.NewCreatePipelineHandler(
+				infra.BlueprintRepository, infra.PipelineRepository, infra.FileRepository, l,
+			),
+			CreateSchedule: command.NewCreateScheduleHandler(
+				infra.BlueprintRepository, infra.ScheduleRepository, infra.FileRepository, l,
+			),
 			CreateUser:      command.NewCreateUserHandler(infra.UserRepository, infra.PasswordHasher, l),
+			DeadLetterJob:   command.NewDeadLetterJobHandler(infra.DeadLetterRepository, l),
 			DeleteBlueprint: command.NewDeleteBlueprintHandler(infra.BlueprintRepository, l),
+			DeletePipeline:  command.NewDeletePipelineHandler(infra.PipelineRepository, l),
+			DeleteSchedule:  command.NewDeleteScheduleHandler(infra.ScheduleProvider, infra.ScheduleRepository, l),
 			DeleteUser:      command.NewDeleteUserHandler(infra.UserRepository, l),
 			Login:           command.NewLoginHandler(infra.UserProvider, infra.PasswordHasher, infra.TokenService, l),
-			RunJob:          command.NewRunJobHandler(infra.Runner, infra.JobRepository, infra.FileReader, l),
-			StartJob:        command.NewStartJobHandler(infra.BlueprintRepository, infra.JobRepository, infra.JobPublisher, l),
-			UpdateUser:      command.NewUpdateUserHandler(infra.UserRepository, infra.PasswordHasher, l),
-			UploadFile:      command.NewUploadFileHandler(infra.FileUploader, l),
+			ReconcileJobs: command.NewReconcileJobsHandler(
+				infra.Runner, infra.JobProvider, infra.JobRepository, infra.JobReconcileProvider,
+				infra.JobEventPublisher, l,
+			),
+			RequeueBuilds: command.NewRequeueBuildsHandler(infra.BlueprintRepository, infra.BlueprintPublisher, l),
+			RequeueDeadLetter: command.NewRequeueDeadLetterHandler(
+				infra.UserProvider, infra.DeadLetterProvider, infra.DeadLetterRepository, l,
+			),
+			RerunJob: command.NewRerunJobHandler(
+				infra.JobProvider, infra.BlueprintRepository, infra.JobRepository, infra.FileRepository, l,
+			),
+			RunJob: command.NewRunJobHandler(
+				infra.Runner, infra.JobProvider, infra.JobRepository, infra.JobLogRepository, infra.JobEventPublisher,
+				infra.FileReader, infra.FileUploader, infra.FileRepository, l,
+			),
+			RunSchedules: command.NewRunSchedulesHandler(
+				infra.ScheduleProvider, infra.ScheduleRepository, infra.BlueprintRepository, infra.JobEventPublisher, l,
+			),
+			StartBatch: command.NewStartBatchHandler(
+				infra.BlueprintRepository, infra.BatchRepository, infra.FileRepository, l,
+			),
+			StartJob: command.NewStartJobHandler(
+				infra.BlueprintRepository, infra.JobRepository, infra.FileRepository, l,
+			),
+			StartPipeline:     command.NewStartPipelineHandler(infra.BlueprintRepository, infra.PipelineRepository, l),
+			StopCancelledJobs: command.NewStopCancelledJobsHandler(infra.Runner, infra.JobProvider, l),
+			UpdateSchedule: command.NewUpdateScheduleHandler(
+				infra.BlueprintRepository, infra.ScheduleRepository, infra.FileRepository, l,
+			),
+			UpdateUser: command.NewUpdateUserHandler(infra.UserRepository, infra.PasswordHasher, l),
+			UploadFile: command.NewUploadFileHandler(infra.FileUploader, infra.FileRepository, l),
 		},
 		Queries: Queries{
+			GetBatch:         query.NewGetBatchHandler(infra.BatchProvider, l),
+			GetBatchResults:  query.NewGetBatchResultsHandler(infra.BatchProvider, l),
 			GetBlueprint:     query.NewGetBlueprintHandler(infra.BlueprintProvider, l),
 			GetBlueprints:    query.NewGetBlueprintsHandler(infra.BlueprintProvider, l),
+			GetBuildLog:      query.NewGetBuildLogHandler(infra.BlueprintProvider, infra.BuildLogProvider, l),
+			GetDeadLetters:   query.NewGetDeadLettersHandler(infra.UserProvider, infra.DeadLetterProvider, l),
 			GetJob:           query.NewGetJobHandler(infra.JobProvider, l),
+			GetJobArtifact:   query.NewGetJobArtifactHandler(infra.JobProvider, infra.FileReader, l),
+			GetJobLog:        query.NewGetJobLogHandler(infra.JobProvider, infra.JobLogProvider, l),
 			GetJobs:          query.NewGetJobsHandler(infra.JobProvider, l),
+			GetPipeline:      query.NewGetPipelineHandler(infra.PipelineRepository, l),
+			GetPipelineRun:   query.NewGetPipelineRunHandler(infra.PipelineRepository, l),
+			GetPipelineRuns:  query.NewGetPipelineRunsHandler(infra.PipelineRepository, l),
+			GetPipelines:     query.NewGetPipelinesHandler(infra.PipelineRepository, l),
+			GetSchedule:      query.NewGetScheduleHandler(infra.ScheduleProvider, l),
+			GetScheduleJobs:  query.NewGetScheduleJobsHandler(infra.ScheduleProvider, l),
+			GetSchedules:     query.NewGetSchedulesHandler(infra.ScheduleProvider, l),
 			GetUser:          query.NewGetUserHandler(infra.UserProvider, l),
 			GetUsers:         query.NewGetUsersHandler(infra.UserProvider, l),
+			GetWorkerStats:   query.NewGetWorkerStatsHandler(infra.UserProvider, infra.WorkerStatsProvider, l),
 			SearchBlueprints: query.NewSearchBlueprintsHandler(infra.BlueprintProvider, l),
+			WatchJob:         query.NewWatchJobHandler(infra.JobProvider, infra.JobEventSubscriber, l),
 		},
 	}
 }
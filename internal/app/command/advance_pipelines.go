@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// AdvancePipelinesHandler продвигает незавершённые запуски конвейеров: отмечает завершившиеся шаги и запускает
+// задачи шагов, зависимости которых выполнены. Исполнитель вызывает обработчик периодически; один запуск
+// продвигается только одним исполнителем (см. PipelineRepository.AdvancePipelineRun).
+type AdvancePipelinesHandler struct {
+	pr ports.PipelineRepository
+	br ports.BlueprintRepository
+	l  *slog.Logger
+}
+
+func NewAdvancePipelinesHandler(
+	pr ports.PipelineRepository, br ports.BlueprintRepository, l *slog.Logger,
+) AdvancePipelinesHandler {
+	return AdvancePipelinesHandler{pr, br, l}
+}
+
+func (h AdvancePipelinesHandler) Handle(ctx context.Context) error {
+	l := h.l.With(slog.String("op", "app.AdvancePipelines"))
+
+	ids, err := h.pr.RunningPipelineRuns(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to get running pipeline runs", slog.String("error", err.Error()))
+		return err
+	}
+
+	var errs []error
+	for _, id := range ids {
+		rl := l.With(slog.String("run_id", string(id)))
+		err = h.pr.AdvancePipelineRun(ctx, id, func(
+			ctx2 context.Context, run *entity.PipelineRun, jobs map[value.JobID]*entity.Job,
+		) ([]*entity.Job, error) {
+			return h.advance(ctx2, rl, run, jobs)
+		})
+		if err != nil {
+			// Запуск будет продвинут при следующем вызове.
+			rl.ErrorContext(ctx, "failed to advance pipeline run", slog.String("error", err.Error()))
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h AdvancePipelinesHandler) advance(
+	ctx context.Context, l *slog.Logger, run *entity.PipelineRun, jobs map[value.JobID]*entity.Job,
+) ([]*entity.Job, error) {
+	// Запуск мог завершиться в другом исполнителе между выборкой и блокировкой.
+	if run.State() != value.PipelineRunning {
+		return nil, nil
+	}
+
+	pipeline, err := h.pr.Pipeline(ctx, run.PipelineID())
+	if err != nil {
+		return nil, err
+	}
+
+	// Blueprint, удалённый после запуска конвейера, не найдётся, и его шаг завершится неуспешно.
+	blueprints, err := stepBlueprints(ctx, h.br, pipeline.Steps())
+	if err != nil {
+		return nil, err
+	}
+
+	started, err := run.Advance(pipeline, jobs, blueprints)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range started {
+		l.InfoContext(ctx, "pipeline step started", slog.String("job_id", string(job.ID())))
+	}
+	if run.State() != value.PipelineRunning {
+		l.InfoContext(ctx, "pipeline run finished", slog.String("state", run.State().String()))
+	}
+	return started, nil
+}
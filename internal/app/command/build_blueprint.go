@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type BuildBlueprintHandler struct {
+	br ports.BlueprintRepository
+	lr ports.BuildLogRepository
+	fr ports.FileReader
+	r  ports.Runner
+	l  *slog.Logger
+}
+
+func NewBuildBlueprintHandler(
+	br ports.BlueprintRepository,
+	lr ports.BuildLogRepository,
+	fr ports.FileReader,
+	r ports.Runner,
+	l *slog.Logger,
+) BuildBlueprintHandler {
+	return BuildBlueprintHandler{br, lr, fr, r, l}
+}
+
+func (h BuildBlueprintHandler) Handle(ctx context.Context, req request.BuildBlueprint) error {
+	l := h.l.With(
+		slog.String("op", "app.BuildBlueprint"),
+		slog.String("blueprint_id", req.BlueprintID),
+	)
+	l.DebugContext(ctx, "building blueprint")
+
+	// Как и в RunJobHandler, сборка образа выполняется вне транзакции между двумя обновлениями Blueprint.
+
+	var blueprint *entity.Blueprint
+	err := h.br.UpdateBlueprint(ctx, value.BlueprintID(req.BlueprintID), func(_ context.Context, b *entity.Blueprint) error {
+		blueprint = b
+		return b.StartBuild()
+	})
+	if errors.Is(err, entity.ErrInvalidBuildStatusChange) || errors.Is(err, ports.ErrBlueprintNotFound) {
+		l.InfoContext(ctx, "blueprint build is not required, skipping", slog.String("error", err.Error()))
+		return nil
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to update blueprint", slog.String("error", err.Error()))
+		return err
+	}
+
+	var log strings.Builder
+	image, buildErr := h.build(ctx, blueprint, &log)
+	if buildErr != nil && !errors.Is(buildErr, ports.ErrImageBuildFailed) {
+		// Ошибки, не попавшие в вывод сборки (например, недоступность Docker), дописываются в лог,
+		// чтобы автор видел причину неудачи.
+		log.WriteString(buildErr.Error())
+		log.WriteRune('\n')
+	}
+
+	err = h.lr.SaveBuildLog(ctx, blueprint.ID(), log.String())
+	if err != nil {
+		l.ErrorContext(ctx, "failed to save build log", slog.String("error", err.Error()))
+		return err
+	}
+
+	err = h.br.UpdateBlueprint(ctx, value.BlueprintID(req.BlueprintID), func(_ context.Context, b *entity.Blueprint) error {
+		if buildErr != nil {
+			return b.FailBuild()
+		}
+		return b.CompleteBuild(image)
+	})
+	if err != nil {
+		l.ErrorContext(ctx, "failed to update blueprint", slog.String("error", err.Error()))
+		return err
+	}
+	if buildErr != nil {
+		l.InfoContext(ctx, "blueprint build failed", slog.String("error", buildErr.Error()))
+		return nil
+	}
+	l.InfoContext(ctx, "blueprint built", slog.String("image", string(image)))
+
+	return nil
+}
+
+func (h BuildBlueprintHandler) build(
+	ctx context.Context, blueprint *entity.Blueprint, log io.Writer,
+) (value.ImageTag, error) {
+	buildCtx, err := h.fr.Read(ctx, blueprint.ArchiveID())
+	if err != nil {
+		return "", fmt.Errorf("failed to read build context: %w", err)
+	}
+	defer func() { _ = buildCtx.Close() }()
+
+	return h.r.Build(ctx, buildCtx, blueprint.ID(), log)
+}
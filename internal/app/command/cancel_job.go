@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type CancelJobHandler struct {
+	jr ports.JobRepository
+	r  ports.Runner
+	ep ports.JobEventPublisher
+	l  *slog.Logger
+}
+
+func NewCancelJobHandler(
+	jr ports.JobRepository, r ports.Runner, ep ports.JobEventPublisher, l *slog.Logger,
+) CancelJobHandler {
+	return CancelJobHandler{jr, r, ep, l}
+}
+
+func (h CancelJobHandler) Handle(ctx context.Context, req request.CancelJob) error {
+	l := h.l.With(
+		slog.String("op", "app.CancelJob"),
+		slog.String("job_id", req.JobID),
+		slog.String("uid", req.ActorID),
+	)
+	l.DebugContext(ctx, "cancelling job")
+
+	var wasRunning bool
+	err := h.jr.UpdateJob(ctx, value.JobID(req.JobID), func(_ context.Context, job *entity.Job) error {
+		if job.OwnerID() != value.UserID(req.ActorID) {
+			l.InfoContext(ctx, "user does not own job", slog.String("owner_id", string(job.OwnerID())))
+			return domain.ErrPermissionDenied
+		}
+		wasRunning = job.State() == value.JobRunning
+		return job.Cancel()
+	})
+	var iiErr domain.InvalidInputError
+	if errors.Is(err, ports.ErrJobNotFound) || errors.Is(err, domain.ErrPermissionDenied) || errors.As(err, &iiErr) {
+		l.InfoContext(ctx, "failed to cancel job", slog.String("error", err.Error()))
+		return err
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to update job", slog.String("error", err.Error()))
+		return err
+	}
+	publishJobState(ctx, h.ep, l, value.JobID(req.JobID), value.JobCancelled)
+
+	// Ожидающая задача будет пропущена RunJobHandler при получении из очереди, выполняющуюся -- останавливаем
+	// здесь, уже после фиксации нового состояния. Процесс без доступа к Docker (Runner не задан) оставляет
+	// остановку исполнителю, см. StopCancelledJobsHandler. Если контейнер ещё не создан, остановка ничего
+	// не делает, а RunJobHandler не запустит контейнер, проверив состояние задачи (см. RunSpec.BeforeStart).
+	if wasRunning && h.r != nil {
+		err = h.r.Stop(ctx, value.JobID(req.JobID))
+		if err != nil {
+			l.ErrorContext(ctx, "failed to stop job container", slog.String("error", err.Error()))
+			return err
+		}
+	}
+	l.InfoContext(ctx, "job cancelled", slog.Bool("was_running", wasRunning))
+
+	return nil
+}
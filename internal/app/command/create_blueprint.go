@@ -2,7 +2,9 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/bmstu-itstech/scriptum-back/internal/domain"
 
@@ -16,14 +18,25 @@ import (
 
 type CreateBlueprintHandler struct {
 	br ports.BlueprintRepository
+	bp ports.BlueprintPublisher
 	up ports.UserProvider
 	l  *slog.Logger
+
+	maxLimits    value.ResourceLimits
+	allowNetwork bool
+	maxTimeout   time.Duration
 }
 
 func NewCreateBlueprintHandler(
-	br ports.BlueprintRepository, up ports.UserProvider, l *slog.Logger,
+	br ports.BlueprintRepository,
+	bp ports.BlueprintPublisher,
+	up ports.UserProvider,
+	maxLimits value.ResourceLimits,
+	allowNetwork bool,
+	maxTimeout time.Duration,
+	l *slog.Logger,
 ) CreateBlueprintHandler {
-	return CreateBlueprintHandler{br, up, l}
+	return CreateBlueprintHandler{br, bp, up, l, maxLimits, allowNetwork, maxTimeout}
 }
 
 func (h CreateBlueprintHandler) Handle(
@@ -54,6 +67,37 @@ func (h CreateBlueprintHandler) Handle(
 		return "", err
 	}
 
+	limits, err := dto.ResourceLimitsFromDTO(req.Limits)
+	if err != nil {
+		l.InfoContext(ctx, "invalid resource limits", slog.String("error", err.Error()))
+		return "", err
+	}
+	if err = limits.CheckWithin(h.maxLimits); err != nil {
+		l.InfoContext(ctx, "resource limits exceed maximum", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	if req.Network && !h.allowNetwork {
+		l.InfoContext(ctx, "network access is not allowed")
+		return "", domain.NewInvalidInputError(
+			"blueprint-network-not-allowed", "network access for blueprints is disabled by administrator",
+		)
+	}
+
+	if h.maxTimeout > 0 && req.Timeout > h.maxTimeout {
+		l.InfoContext(ctx, "timeout exceeds maximum", slog.Duration("timeout", req.Timeout))
+		return "", domain.NewInvalidInputError(
+			"blueprint-timeout-exceeded",
+			fmt.Sprintf("timeout %s exceeds maximum of %s", req.Timeout, h.maxTimeout),
+		)
+	}
+
+	retryPolicy, err := dto.RetryPolicyFromDTO(req.RetryPolicy)
+	if err != nil {
+		l.InfoContext(ctx, "invalid retry policy", slog.String("error", err.Error()))
+		return "", err
+	}
+
 	vis, err := value.VisibilityFromString(req.Visibility)
 	if err != nil {
 		l.InfoContext(ctx, "failed to convert visibility from string", slog.String("error", err.Error()))
@@ -72,6 +116,10 @@ func (h CreateBlueprintHandler) Handle(
 		vis,
 		input,
 		output,
+		limits,
+		req.Network,
+		req.Timeout,
+		retryPolicy,
 	)
 	if err != nil {
 		l.InfoContext(ctx, "failed to create blueprint", slog.String("error", err.Error()))
@@ -85,5 +133,11 @@ func (h CreateBlueprintHandler) Handle(
 	}
 	l.InfoContext(ctx, "successfully created blueprint", slog.String("id", string(blueprint.ID())))
 
+	err = h.bp.PublishBlueprintBuild(ctx, blueprint)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to publish blueprint build", slog.String("error", err.Error()))
+		return "", err
+	}
+
 	return string(blueprint.ID()), nil
 }
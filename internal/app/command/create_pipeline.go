@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type CreatePipelineHandler struct {
+	br ports.BlueprintRepository
+	pr ports.PipelineRepository
+	fp ports.FileProvider
+	l  *slog.Logger
+}
+
+func NewCreatePipelineHandler(
+	br ports.BlueprintRepository, pr ports.PipelineRepository, fp ports.FileProvider, l *slog.Logger,
+) CreatePipelineHandler {
+	return CreatePipelineHandler{br, pr, fp, l}
+}
+
+func (h CreatePipelineHandler) Handle(
+	ctx context.Context, req request.CreatePipeline,
+) (response.CreatePipeline, error) {
+	l := h.l.With(
+		slog.String("op", "app.CreatePipeline"),
+		slog.String("uid", req.ActorID),
+	)
+	l.DebugContext(ctx, "creating pipeline", slog.String("name", req.Name), slog.Int("steps", len(req.Steps)))
+
+	steps, err := dto.PipelineStepsFromDTOs(req.Steps)
+	if err != nil {
+		l.InfoContext(ctx, "invalid pipeline steps", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	var consts []value.Value
+	for _, step := range steps {
+		for _, in := range step.Input() {
+			if v, ok := in.Const(); ok {
+				consts = append(consts, v)
+			}
+		}
+	}
+	err = checkFilesAccess(ctx, h.fp, value.UserID(req.ActorID), consts)
+	if err != nil {
+		l.InfoContext(ctx, "step input files are not available", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	blueprints, err := availableBlueprints(ctx, h.br, steps, value.UserID(req.ActorID))
+	if err != nil {
+		l.InfoContext(ctx, "failed to get step blueprints", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	pipeline, err := entity.NewPipeline(value.UserID(req.ActorID), req.Name, req.Desc, steps, blueprints)
+	if err != nil {
+		l.InfoContext(ctx, "failed to create pipeline", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	err = h.pr.SavePipeline(ctx, pipeline)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to save pipeline", slog.String("error", err.Error()))
+		return "", err
+	}
+	l.InfoContext(ctx, "pipeline saved successfully", slog.String("id", string(pipeline.ID())))
+
+	return string(pipeline.ID()), nil
+}
+
+// availableBlueprints возвращает Blueprint шагов steps, проверяя, что все они существуют и доступны
+// пользователю uid.
+func availableBlueprints(
+	ctx context.Context, br ports.BlueprintRepository, steps []value.PipelineStep, uid value.UserID,
+) (map[value.BlueprintID]*entity.Blueprint, error) {
+	blueprints, err := stepBlueprints(ctx, br, steps)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		b, ok := blueprints[step.BlueprintID()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ports.ErrBlueprintNotFound, step.BlueprintID())
+		}
+		if !b.IsAvailableFor(uid) {
+			return nil, domain.ErrPermissionDenied
+		}
+	}
+	return blueprints, nil
+}
+
+// stepBlueprints возвращает Blueprint шагов steps по их ID; не найденные Blueprint пропускаются.
+func stepBlueprints(
+	ctx context.Context, br ports.BlueprintRepository, steps []value.PipelineStep,
+) (map[value.BlueprintID]*entity.Blueprint, error) {
+	res := make(map[value.BlueprintID]*entity.Blueprint, len(steps))
+	for _, step := range steps {
+		if _, ok := res[step.BlueprintID()]; ok {
+			continue
+		}
+		b, err := br.Blueprint(ctx, step.BlueprintID())
+		if errors.Is(err, ports.ErrBlueprintNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res[step.BlueprintID()] = b
+	}
+	return res, nil
+}
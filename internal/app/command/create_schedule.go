@@ -0,0 +1,98 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type CreateScheduleHandler struct {
+	br ports.BlueprintRepository
+	sr ports.ScheduleRepository
+	fp ports.FileProvider
+	l  *slog.Logger
+}
+
+func NewCreateScheduleHandler(
+	br ports.BlueprintRepository, sr ports.ScheduleRepository, fp ports.FileProvider, l *slog.Logger,
+) CreateScheduleHandler {
+	return CreateScheduleHandler{br, sr, fp, l}
+}
+
+func (h CreateScheduleHandler) Handle(
+	ctx context.Context, req request.CreateSchedule,
+) (response.CreateSchedule, error) {
+	l := h.l.With(
+		slog.String("op", "app.CreateSchedule"),
+		slog.String("blueprint_id", req.BlueprintID),
+		slog.String("uid", req.ActorID),
+	)
+	l.DebugContext(ctx, "creating schedule", slog.String("cron", req.Cron), slog.String("timezone", req.Timezone))
+
+	blueprint, err := h.br.Blueprint(ctx, value.BlueprintID(req.BlueprintID))
+	if err != nil {
+		l.InfoContext(ctx, "blueprint not found")
+		return "", err
+	}
+
+	if !blueprint.IsAvailableFor(value.UserID(req.ActorID)) {
+		l.InfoContext(ctx, "blueprint is not available")
+		return "", domain.ErrPermissionDenied
+	}
+
+	in, err := dto.ValuesFromDTOs(req.Values)
+	if err != nil {
+		l.InfoContext(ctx, "invalid input values", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	err = checkFilesAccess(ctx, h.fp, value.UserID(req.ActorID), in)
+	if err != nil {
+		l.InfoContext(ctx, "input files are not available", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	cron, err := value.CronExprFromString(req.Cron)
+	if err != nil {
+		l.InfoContext(ctx, "invalid cron expression", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	location, err := value.LocationFromString(req.Timezone)
+	if err != nil {
+		l.InfoContext(ctx, "invalid timezone", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	overlap := value.OverlapForbid
+	if req.OverlapPolicy != nil {
+		overlap, err = value.OverlapPolicyFromString(*req.OverlapPolicy)
+		if err != nil {
+			l.InfoContext(ctx, "invalid overlap policy", slog.String("error", err.Error()))
+			return "", err
+		}
+	}
+
+	enabled := req.Enabled == nil || *req.Enabled
+
+	schedule, err := blueprint.AssembleSchedule(value.UserID(req.ActorID), in, cron, location, overlap, enabled)
+	if err != nil {
+		l.InfoContext(ctx, "failed to assemble schedule", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	err = h.sr.SaveSchedule(ctx, schedule)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to save schedule", slog.String("error", err.Error()))
+		return "", err
+	}
+	l.InfoContext(ctx, "schedule saved successfully", slog.String("id", string(schedule.ID())))
+
+	return string(schedule.ID()), nil
+}
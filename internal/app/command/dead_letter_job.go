@@ -0,0 +1,37 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// DeadLetterJobHandler сохраняет задачу, обработка которой не удалась после всех повторных попыток, чтобы
+// администратор мог разобраться в причине и повторно поставить её в очередь.
+type DeadLetterJobHandler struct {
+	dr ports.DeadLetterRepository
+	l  *slog.Logger
+}
+
+func NewDeadLetterJobHandler(dr ports.DeadLetterRepository, l *slog.Logger) DeadLetterJobHandler {
+	return DeadLetterJobHandler{dr, l}
+}
+
+func (h DeadLetterJobHandler) Handle(ctx context.Context, req request.DeadLetterJob) error {
+	l := h.l.With(
+		slog.String("op", "app.DeadLetterJob"),
+		slog.String("job_id", req.JobID),
+	)
+
+	err := h.dr.SaveDeadLetter(ctx, value.JobID(req.JobID), req.Error, req.Attempts)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to save dead letter", slog.String("error", err.Error()))
+		return err
+	}
+	l.InfoContext(ctx, "job moved to dead letters", slog.Int("attempts", req.Attempts))
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type DeletePipelineHandler struct {
+	pr ports.PipelineRepository
+	l  *slog.Logger
+}
+
+func NewDeletePipelineHandler(pr ports.PipelineRepository, l *slog.Logger) DeletePipelineHandler {
+	return DeletePipelineHandler{pr, l}
+}
+
+func (h DeletePipelineHandler) Handle(ctx context.Context, req request.DeletePipeline) error {
+	l := h.l.With(
+		slog.String("op", "app.DeletePipeline"),
+		slog.String("actor_id", req.ActorID),
+		slog.String("pipeline_id", req.PipelineID),
+	)
+
+	p, err := h.pr.Pipeline(ctx, value.PipelineID(req.PipelineID))
+	if errors.Is(err, ports.ErrPipelineNotFound) {
+		l.InfoContext(ctx, "pipeline not found")
+		return nil
+	} else if err != nil {
+		l.ErrorContext(ctx, "could not find pipeline", slog.String("error", err.Error()))
+		return err
+	}
+
+	if p.OwnerID() != value.UserID(req.ActorID) {
+		l.InfoContext(ctx, "not authorized to delete this pipeline", slog.String("owner_id", string(p.OwnerID())))
+		return domain.ErrPermissionDenied
+	}
+
+	err = h.pr.DeletePipeline(ctx, p.ID())
+	if err != nil {
+		l.ErrorContext(ctx, "could not delete pipeline", slog.String("error", err.Error()))
+		return err
+	}
+	l.InfoContext(ctx, "successfully deleted pipeline")
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type DeleteScheduleHandler struct {
+	sp ports.ScheduleProvider
+	sr ports.ScheduleRepository
+	l  *slog.Logger
+}
+
+func NewDeleteScheduleHandler(
+	sp ports.ScheduleProvider, sr ports.ScheduleRepository, l *slog.Logger,
+) DeleteScheduleHandler {
+	return DeleteScheduleHandler{sp, sr, l}
+}
+
+func (h DeleteScheduleHandler) Handle(ctx context.Context, req request.DeleteSchedule) error {
+	l := h.l.With(
+		slog.String("op", "app.DeleteSchedule"),
+		slog.String("actor_id", req.ActorID),
+		slog.String("schedule_id", req.ScheduleID),
+	)
+
+	s, err := h.sp.Schedule(ctx, value.ScheduleID(req.ScheduleID))
+	if errors.Is(err, ports.ErrScheduleNotFound) {
+		l.InfoContext(ctx, "schedule not found")
+		return nil
+	} else if err != nil {
+		l.ErrorContext(ctx, "could not find schedule", slog.String("error", err.Error()))
+		return err
+	}
+
+	if s.OwnerID != req.ActorID {
+		l.InfoContext(ctx, "not authorized to delete this schedule", slog.String("owner_id", s.OwnerID))
+		return domain.ErrPermissionDenied
+	}
+
+	err = h.sr.DeleteSchedule(ctx, value.ScheduleID(req.ScheduleID))
+	if err != nil {
+		l.ErrorContext(ctx, "could not delete schedule", slog.String("error", err.Error()))
+		return err
+	}
+	l.InfoContext(ctx, "successfully deleted schedule")
+
+	return nil
+}
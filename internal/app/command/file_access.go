@@ -0,0 +1,32 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// checkFilesAccess проверяет, что пользователь uid может передать задаче файлы значений values типа file:
+// передать можно только загруженный им самим файл.
+func checkFilesAccess(ctx context.Context, fp ports.FileProvider, uid value.UserID, values []value.Value) error {
+	for _, v := range values {
+		id, ok := v.FileID()
+		if !ok {
+			continue
+		}
+		owner, err := fp.FileOwner(ctx, id)
+		if errors.Is(err, ports.ErrFileNotFound) {
+			return domain.NewInvalidInputError("value-file-not-found", fmt.Sprintf("file %s not found", id))
+		} else if err != nil {
+			return err
+		}
+		if owner != uid {
+			return domain.ErrPermissionDenied
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// publishJobState оповещает наблюдающих за задачей о смене её состояния. События вспомогательные, поэтому
+// ошибка публикации только журналируется.
+func publishJobState(
+	ctx context.Context, ep ports.JobEventPublisher, l *slog.Logger, id value.JobID, state value.JobState,
+) {
+	err := ep.PublishJobEvent(ctx, dto.JobEvent{
+		JobID: string(id),
+		Kind:  dto.JobEventState,
+		State: state.String(),
+	})
+	if err != nil {
+		l.WarnContext(ctx, "failed to publish job state", slog.String("error", err.Error()))
+	}
+}
+
+// outputWriter публикует вывод контейнера построчно; незавершённая строка накапливается до следующей записи
+// или вызова Flush.
+type outputWriter struct {
+	ctx    context.Context
+	ep     ports.JobEventPublisher
+	l      *slog.Logger
+	id     value.JobID
+	stream string
+	buf    []byte
+}
+
+func newOutputWriter(
+	ctx context.Context, ep ports.JobEventPublisher, l *slog.Logger, id value.JobID, stream string,
+) *outputWriter {
+	return &outputWriter{ctx: ctx, ep: ep, l: l, id: id, stream: stream}
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.publish(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *outputWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.publish(string(w.buf))
+		w.buf = nil
+	}
+}
+
+func (w *outputWriter) publish(line string) {
+	err := w.ep.PublishJobEvent(w.ctx, dto.JobEvent{
+		JobID:  string(w.id),
+		Kind:   dto.JobEventOutput,
+		Stream: w.stream,
+		Line:   line,
+	})
+	if err != nil {
+		w.l.WarnContext(w.ctx, "failed to publish job output", slog.String("error", err.Error()))
+	}
+}
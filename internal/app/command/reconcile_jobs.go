@@ -0,0 +1,170 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// ReconcileJobsHandler восстанавливает задачи после аварийного завершения исполнителя: завершает с причиной
+// FailureInterrupted выполняющиеся задачи без heartbeat, повторно публикует потерянные ожидающие задачи и
+// удаляет контейнеры, задачи которых уже не выполняются.
+type ReconcileJobsHandler struct {
+	r  ports.Runner
+	jp ports.JobProvider
+	jr ports.JobRepository
+	rp ports.JobReconcileProvider
+	ep ports.JobEventPublisher
+	l  *slog.Logger
+}
+
+func NewReconcileJobsHandler(
+	r ports.Runner,
+	jp ports.JobProvider,
+	jr ports.JobRepository,
+	rp ports.JobReconcileProvider,
+	ep ports.JobEventPublisher,
+	l *slog.Logger,
+) ReconcileJobsHandler {
+	return ReconcileJobsHandler{r, jp, jr, rp, ep, l}
+}
+
+func (h ReconcileJobsHandler) Handle(ctx context.Context, req request.ReconcileJobs) error {
+	l := h.l.With(slog.String("op", "app.ReconcileJobs"))
+	l.DebugContext(ctx, "reconciling jobs")
+
+	interrupted, err := h.interruptOrphaned(ctx, req.HeartbeatTimeout, l)
+	if err != nil {
+		return err
+	}
+
+	var republished int
+	if req.RepublishPending {
+		republished, err = h.republishUndelivered(ctx, l)
+		if err != nil {
+			return err
+		}
+	}
+
+	removed, err := h.removeLeftoverContainers(ctx, l)
+	if err != nil {
+		return err
+	}
+
+	if interrupted > 0 || republished > 0 || removed > 0 {
+		l.InfoContext(
+			ctx, "jobs reconciled",
+			slog.Int("interrupted", interrupted),
+			slog.Int("republished", republished),
+			slog.Int("containers_removed", removed),
+		)
+	}
+	return nil
+}
+
+func (h ReconcileJobsHandler) interruptOrphaned(
+	ctx context.Context, timeout time.Duration, l *slog.Logger,
+) (int, error) {
+	ids, err := h.rp.OrphanedJobs(ctx, timeout)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to get orphaned jobs", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	var n int
+	msg := fmt.Sprintf("job interrupted: no worker heartbeat for %s", timeout)
+	for _, id := range ids {
+		var state value.JobState
+		err = h.jr.UpdateJob(ctx, id, func(_ context.Context, job *entity.Job) error {
+			err2 := job.Fail(value.FailureInterrupted, msg)
+			state = job.State()
+			return err2
+		})
+		if errors.Is(err, entity.ErrJobCancelled) || errors.Is(err, entity.ErrInvalidJobStateChange) ||
+			errors.Is(err, ports.ErrJobNotFound) {
+			// Задача завершилась после выборки
+			continue
+		} else if err != nil {
+			l.ErrorContext(
+				ctx, "failed to update job",
+				slog.String("job_id", string(id)),
+				slog.String("error", err.Error()),
+			)
+			return n, err
+		}
+		// Прерванная задача может быть повторена по политике повторов Blueprint.
+		publishJobState(ctx, h.ep, l, id, state)
+		l.WarnContext(
+			ctx, "orphaned job interrupted",
+			slog.String("job_id", string(id)),
+			slog.String("state", state.String()),
+		)
+		n++
+	}
+	return n, nil
+}
+
+func (h ReconcileJobsHandler) republishUndelivered(ctx context.Context, l *slog.Logger) (int, error) {
+	ids, err := h.rp.UndeliveredJobs(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to get undelivered jobs", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	for i, id := range ids {
+		err = h.jr.RepublishJob(ctx, id)
+		if err != nil {
+			l.ErrorContext(
+				ctx, "failed to republish job",
+				slog.String("job_id", string(id)),
+				slog.String("error", err.Error()),
+			)
+			return i, err
+		}
+	}
+	return len(ids), nil
+}
+
+// removeLeftoverContainers удаляет контейнеры, задачи которых не выполняются: завершены, отменены, прерваны
+// или удалены. Контейнер выполняющейся задачи удаляет сам Runner.Run по её завершении.
+func (h ReconcileJobsHandler) removeLeftoverContainers(ctx context.Context, l *slog.Logger) (int, error) {
+	ids, err := h.r.Running(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to get job containers", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	var n int
+	for _, id := range ids {
+		job, err2 := h.jp.Job(ctx, id)
+		if err2 != nil && !errors.Is(err2, ports.ErrJobNotFound) {
+			l.ErrorContext(
+				ctx, "failed to get job",
+				slog.String("job_id", string(id)),
+				slog.String("error", err2.Error()),
+			)
+			return n, err2
+		} else if err2 == nil && job.State == value.JobRunning.String() {
+			continue
+		}
+
+		err2 = h.r.Stop(ctx, id)
+		if err2 != nil {
+			l.ErrorContext(
+				ctx, "failed to remove job container",
+				slog.String("job_id", string(id)),
+				slog.String("error", err2.Error()),
+			)
+			return n, err2
+		}
+		n++
+	}
+	return n, nil
+}
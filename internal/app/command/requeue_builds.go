@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+)
+
+// RequeueBuildsHandler повторно ставит в очередь сборки образов, не завершённые к моменту запуска сервиса.
+type RequeueBuildsHandler struct {
+	br ports.BlueprintRepository
+	bp ports.BlueprintPublisher
+	l  *slog.Logger
+}
+
+func NewRequeueBuildsHandler(
+	br ports.BlueprintRepository, bp ports.BlueprintPublisher, l *slog.Logger,
+) RequeueBuildsHandler {
+	return RequeueBuildsHandler{br, bp, l}
+}
+
+func (h RequeueBuildsHandler) Handle(ctx context.Context) error {
+	l := h.l.With(slog.String("op", "app.RequeueBuilds"))
+
+	blueprints, err := h.br.UnbuiltBlueprints(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to get unbuilt blueprints", slog.String("error", err.Error()))
+		return err
+	}
+
+	for _, b := range blueprints {
+		err = h.bp.PublishBlueprintBuild(ctx, b)
+		if err != nil {
+			l.ErrorContext(
+				ctx, "failed to publish blueprint build",
+				slog.String("blueprint_id", string(b.ID())),
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+	}
+	l.InfoContext(ctx, "requeued blueprint builds", slog.Int("count", len(blueprints)))
+
+	return nil
+}
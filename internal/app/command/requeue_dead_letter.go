@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type RequeueDeadLetterHandler struct {
+	up ports.UserProvider
+	dp ports.DeadLetterProvider
+	dr ports.DeadLetterRepository
+	l  *slog.Logger
+}
+
+func NewRequeueDeadLetterHandler(
+	up ports.UserProvider, dp ports.DeadLetterProvider, dr ports.DeadLetterRepository, l *slog.Logger,
+) RequeueDeadLetterHandler {
+	return RequeueDeadLetterHandler{up, dp, dr, l}
+}
+
+func (h RequeueDeadLetterHandler) Handle(ctx context.Context, req request.RequeueDeadLetter) error {
+	l := h.l.With(
+		slog.String("op", "app.RequeueDeadLetter"),
+		slog.String("actor_id", req.ActorID),
+		slog.String("job_id", req.JobID),
+	)
+
+	actor, err := h.up.User(ctx, value.UserID(req.ActorID))
+	if errors.Is(err, ports.ErrUserNotFound) {
+		l.InfoContext(ctx, "user not found")
+		return domain.ErrPermissionDenied
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to fetch user", slog.String("error", err.Error()))
+		return err
+	}
+	if actor.Role() != value.RoleAdmin {
+		l.InfoContext(ctx, "actor is not admin")
+		return domain.ErrPermissionDenied
+	}
+
+	dl, err := h.dp.DeadLetter(ctx, value.JobID(req.JobID))
+	if errors.Is(err, ports.ErrDeadLetterNotFound) {
+		l.InfoContext(ctx, "dead letter not found")
+		return err
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to fetch dead letter", slog.String("error", err.Error()))
+		return err
+	}
+
+	// Задачу, успевшую перейти в другое состояние, RunJobHandler всё равно пропустит.
+	if dl.State != value.JobPending.String() {
+		l.InfoContext(ctx, "job is not pending", slog.String("state", dl.State))
+		return domain.NewInvalidInputError(
+			"dead-letter-job-not-pending",
+			fmt.Sprintf("only pending jobs can be requeued, job is %s", dl.State),
+		)
+	}
+
+	err = h.dr.RequeueDeadLetter(ctx, value.JobID(req.JobID))
+	if errors.Is(err, ports.ErrDeadLetterNotFound) {
+		l.InfoContext(ctx, "dead letter was already requeued")
+		return err
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to requeue dead letter", slog.String("error", err.Error()))
+		return err
+	}
+	l.InfoContext(ctx, "dead letter requeued")
+
+	return nil
+}
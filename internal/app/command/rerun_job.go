@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// RerunJobHandler создаёт новую задачу с входными данными существующей, возможно изменёнными, и связывает её
+// с исходной задачей.
+type RerunJobHandler struct {
+	jp ports.JobProvider
+	br ports.BlueprintRepository
+	jr ports.JobRepository
+	fp ports.FileProvider
+	l  *slog.Logger
+}
+
+func NewRerunJobHandler(
+	jp ports.JobProvider,
+	br ports.BlueprintRepository,
+	jr ports.JobRepository,
+	fp ports.FileProvider,
+	l *slog.Logger,
+) RerunJobHandler {
+	return RerunJobHandler{jp, br, jr, fp, l}
+}
+
+func (h RerunJobHandler) Handle(ctx context.Context, req request.RerunJob) (string, error) {
+	l := h.l.With(
+		slog.String("op", "app.RerunJob"),
+		slog.String("job_id", req.JobID),
+		slog.String("uid", req.ActorID),
+	)
+	l.DebugContext(ctx, "rerunning job", "overrides", fmt.Sprintf("%+v", req.Overrides))
+
+	parent, err := h.jp.Job(ctx, value.JobID(req.JobID))
+	if errors.Is(err, ports.ErrJobNotFound) {
+		l.InfoContext(ctx, "job not found", slog.String("error", err.Error()))
+		return "", err
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to query job", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	if parent.OwnerID != req.ActorID {
+		l.InfoContext(ctx, "user does not own job", slog.String("owner_id", parent.OwnerID))
+		return "", domain.ErrPermissionDenied
+	}
+
+	blueprint, err := h.br.Blueprint(ctx, value.BlueprintID(parent.BlueprintID))
+	if err != nil {
+		l.InfoContext(ctx, "blueprint not found")
+		return "", err
+	}
+
+	if !blueprint.IsAvailableFor(value.UserID(req.ActorID)) {
+		l.InfoContext(ctx, "blueprint is not available")
+		return "", domain.ErrPermissionDenied
+	}
+
+	values, err := overrideValues(parent.Input, req.Overrides)
+	if err != nil {
+		l.InfoContext(ctx, "invalid value overrides", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	in, err := dto.ValuesFromDTOs(values)
+	if err != nil {
+		l.InfoContext(ctx, "invalid input values", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	err = checkFilesAccess(ctx, h.fp, value.UserID(req.ActorID), in)
+	if err != nil {
+		l.InfoContext(ctx, "input files are not available", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	job, err := blueprint.AssembleRerun(value.UserID(req.ActorID), value.JobID(parent.ID), in)
+	if err != nil {
+		l.InfoContext(ctx, "failed to assemble job", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	// Задача публикуется асинхронно вместе с сохранением (см. JobRepository.SaveJob).
+	err = h.jr.SaveJob(ctx, job)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to save job", slog.String("error", err.Error()))
+		return "", err
+	}
+	l.InfoContext(ctx, "job saved successfully", slog.String("id", string(job.ID())))
+
+	return string(job.ID()), nil
+}
+
+// overrideValues возвращает копию values, в которой значения с индексами из overrides заменены.
+func overrideValues(values []dto.Value, overrides map[int]dto.Value) ([]dto.Value, error) {
+	res := make([]dto.Value, len(values))
+	copy(res, values)
+	for i, v := range overrides {
+		if i < 0 || i >= len(res) {
+			return nil, domain.NewInvalidInputError(
+				"rerun-override-index-out-of-range",
+				fmt.Sprintf("failed to override value %d: job has %d input values", i, len(res)),
+			)
+		}
+		res[i] = v
+	}
+	return res, nil
+}
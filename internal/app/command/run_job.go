@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
 	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
 	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
 	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
@@ -14,68 +16,220 @@ import (
 
 type RunJobHandler struct {
 	r  ports.Runner
+	jp ports.JobProvider
 	jr ports.JobRepository
+	lr ports.JobLogRepository
+	ep ports.JobEventPublisher
 	fr ports.FileReader
+	fu ports.FileUploader
+	fs ports.FileRepository
 	l  *slog.Logger
 }
 
-func NewRunJobHandler(r ports.Runner, jr ports.JobRepository, fr ports.FileReader, l *slog.Logger) RunJobHandler {
-	return RunJobHandler{r, jr, fr, l}
+func NewRunJobHandler(
+	r ports.Runner,
+	jp ports.JobProvider,
+	jr ports.JobRepository,
+	lr ports.JobLogRepository,
+	ep ports.JobEventPublisher,
+	fr ports.FileReader,
+	fu ports.FileUploader,
+	fs ports.FileRepository,
+	l *slog.Logger,
+) RunJobHandler {
+	return RunJobHandler{r, jp, jr, lr, ep, fr, fu, fs, l}
 }
 
-func (h RunJobHandler) Handle(ctx context.Context, job request.RunJob) error {
+func (h RunJobHandler) Handle(ctx context.Context, req request.RunJob) error {
 	l := h.l.With(
 		slog.String("op", "app.RunJob"),
-		slog.String("job_id", job.JobID),
+		slog.String("job_id", req.JobID),
 	)
 	l.DebugContext(ctx, "running job")
 
-	// Две раздельные операции обновления Job так как необходимо достичь состояния Running.
+	// Запуск и завершение Job -- две раздельные операции обновления: выполнение скрипта происходит вне
+	// транзакции, чтобы задачу можно было отменить (см. CancelJobHandler) во время её выполнения.
 
-	err := h.jr.UpdateJob(ctx, value.JobID(job.JobID), func(_ context.Context, job *entity.Job) error {
-		return job.Run()
+	var job *entity.Job
+	err := h.jr.UpdateJob(ctx, value.JobID(req.JobID), func(_ context.Context, j *entity.Job) error {
+		job = j
+		return j.Run()
 	})
-	if err != nil {
+	if errors.Is(err, entity.ErrJobCancelled) {
+		l.InfoContext(ctx, "job was cancelled before start, skipping")
+		return nil
+	} else if errors.Is(err, entity.ErrInvalidJobStateChange) {
+		// Очередь доставляет задачу не менее одного раза: повторная доставка уже запущенной задачи пропускается.
+		l.InfoContext(ctx, "job was already started, skipping", slog.String("error", err.Error()))
+		return nil
+	} else if err != nil {
 		l.ErrorContext(ctx, "failed to update job", slog.String("error", err.Error()))
 		return err
 	}
+	publishJobState(ctx, h.ep, l, job.ID(), value.JobRunning)
 
-	var res value.Result
-	err = h.jr.UpdateJob(ctx, value.JobID(job.JobID), func(ctx2 context.Context, job *entity.Job) error {
-		buildCtx, err2 := h.fr.Read(ctx2, job.ArchiveID())
-		if err2 != nil {
-			return fmt.Errorf("failed to read build context: %w", err2)
+	res, runErr := h.execute(ctx, job, l)
+	if errors.Is(runErr, entity.ErrJobCancelled) {
+		l.InfoContext(ctx, "job was cancelled before container start, skipping")
+		return nil
+	}
+	if runErr != nil && failureReason(runErr) == value.FailureInfrastructureError {
+		return h.release(ctx, l, job.ID(), runErr)
+	}
+	if runErr == nil {
+		// Лог вспомогательный: ошибка его сохранения не должна оставить задачу в состоянии выполнения.
+		err = h.lr.SaveJobLog(ctx, job.ID(), res.Log())
+		if err != nil {
+			l.ErrorContext(ctx, "failed to save job log", slog.String("error", err.Error()))
 		}
+	}
 
-		var image value.ImageTag
-		image, err = h.r.Build(ctx2, buildCtx, job.BlueprintID())
-		if err != nil {
-			res = value.NewResult(-1).WithOutput(err.Error())
-			return job.Finish(res)
+	err = h.jr.UpdateJob(ctx, value.JobID(req.JobID), func(ctx2 context.Context, j *entity.Job) error {
+		job = j
+		if runErr != nil {
+			return j.Fail(failureReason(runErr), runErr.Error())
+		}
+		err2 := j.Finish(res)
+		if errors.Is(err2, entity.ErrJobResultParseFailed) {
+			l.InfoContext(ctx2, "failed to parse job result", slog.String("error", err2.Error()))
+			return j.Fail(value.FailureOutputParseFailed, err2.Error())
 		}
+		return err2
+	})
+	if errors.Is(err, entity.ErrJobCancelled) {
+		l.InfoContext(ctx, "job was cancelled while running, result discarded")
+		return nil
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to update job", slog.String("error", err.Error()))
+		return err
+	}
+	publishJobState(ctx, h.ep, l, job.ID(), job.State())
+	if next := job.NextAttemptAt(); next != nil {
+		l.InfoContext(
+			ctx, "job attempt failed, retry scheduled",
+			slog.Int("attempt", len(job.Attempts())),
+			slog.Time("next_attempt_at", *next),
+		)
+		return nil
+	}
+	if runErr != nil {
+		l.InfoContext(ctx, "job failed", slog.String("error", runErr.Error()))
+		return nil
+	}
+	l.InfoContext(ctx, "job completed", slog.Int("code", int(res.Code())), slog.String("output", res.Output()))
+	return nil
+}
+
+// release возвращает задачу, выполнение которой прервал сбой инфраструктуры (например, недоступность Docker),
+// в ожидание и возвращает ошибку: очередь повторит доставку с задержкой, а после последней попытки переложит
+// задачу в недоставленные (dead letters), откуда её может вернуть администратор.
+func (h RunJobHandler) release(ctx context.Context, l *slog.Logger, id value.JobID, runErr error) error {
+	err := h.jr.UpdateJob(ctx, id, func(_ context.Context, j *entity.Job) error {
+		return j.Release()
+	})
+	if errors.Is(err, entity.ErrJobCancelled) {
+		l.InfoContext(ctx, "job was cancelled while running, failure discarded", slog.String("error", runErr.Error()))
+		return nil
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to update job", slog.String("error", err.Error()))
+		return err
+	}
+	publishJobState(ctx, h.ep, l, id, value.JobPending)
+	l.WarnContext(ctx, "job failed due to infrastructure error", slog.String("error", runErr.Error()))
+	return fmt.Errorf("failed to run job: %w", runErr)
+}
 
-		res, err = h.r.Run(ctx2, image, job.Input())
+func (h RunJobHandler) execute(ctx context.Context, job *entity.Job, l *slog.Logger) (value.Result, error) {
+	// Задачи, созданные до появления асинхронной сборки Blueprint, не содержат тега образа.
+	if job.Image() == "" {
+		return value.Result{}, fmt.Errorf("%w: job has no blueprint image", ports.ErrImageBuildFailed)
+	}
+
+	files := make(map[value.FileID]io.Reader)
+	for _, v := range job.Input() {
+		id, ok := v.FileID()
+		if !ok {
+			continue
+		}
+		if _, ok = files[id]; ok {
+			continue
+		}
+		rc, err := h.fr.Read(ctx, id)
 		if err != nil {
-			res = value.NewResult(-1).WithOutput(err.Error())
-			return job.Finish(res)
+			return value.Result{}, fmt.Errorf("failed to read input file %s: %w", id, err)
 		}
+		defer func() { _ = rc.Close() }()
+		files[id] = rc
+	}
 
-		err = job.Finish(res)
-		if errors.Is(err, entity.ErrJobResultParseFailed) {
-			l.InfoContext(ctx2, "failed to parse job result", slog.String("error", err.Error()))
-			res = value.NewResult(-1).WithOutput(err.Error())
-			return job.Finish(res)
-		} else if err != nil {
-			l.ErrorContext(ctx2, "failed to finish job", slog.String("error", err.Error()))
-			return err
-		}
+	stdout := newOutputWriter(ctx, h.ep, l, job.ID(), dto.StreamStdout)
+	stderr := newOutputWriter(ctx, h.ep, l, job.ID(), dto.StreamStderr)
+	defer stdout.Flush()
+	defer stderr.Flush()
 
-		return nil
+	return h.r.Run(ctx, ports.RunSpec{
+		JobID:     job.ID(),
+		Image:     job.Image(),
+		Input:     job.Input(),
+		Files:     files,
+		Artifacts: job.ArtifactNames(),
+		BeforeStart: func(ctx2 context.Context) error {
+			return h.checkNotCancelled(ctx2, job.ID())
+		},
+		SaveArtifact: func(ctx2 context.Context, name string, content io.Reader) (value.FileID, error) {
+			return h.saveArtifact(ctx2, job, name, content)
+		},
+		Limits:  job.Limits(),
+		Network: job.Network(),
+		Timeout: job.Timeout(),
+		Stdout:  stdout,
+		Stderr:  stderr,
 	})
+}
+
+// checkNotCancelled возвращает entity.ErrJobCancelled, если задачу отменили после её запуска: CancelJobHandler
+// останавливает контейнер задачи, но до создания контейнера остановить нечего.
+func (h RunJobHandler) checkNotCancelled(ctx context.Context, id value.JobID) error {
+	job, err := h.jp.Job(ctx, id)
 	if err != nil {
-		l.ErrorContext(ctx, "failed to update job", slog.String("error", err.Error()))
 		return err
 	}
-	l.InfoContext(ctx, "job completed", slog.Int("code", int(res.Code())), slog.String("output", res.Output()))
+	if job.State == value.JobCancelled.String() {
+		return entity.ErrJobCancelled
+	}
 	return nil
 }
+
+// saveArtifact сохраняет файл выходного поля name задачи job. Владелец файла -- владелец задачи, поэтому файл
+// можно передать на вход другим задачам так же, как загруженный им файл.
+func (h RunJobHandler) saveArtifact(
+	ctx context.Context, job *entity.Job, name string, content io.Reader,
+) (value.FileID, error) {
+	id, err := h.fu.Upload(ctx, name, content)
+	if err != nil {
+		return "", err
+	}
+	err = h.fs.SaveFile(ctx, id, job.OwnerID(), name)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// failureReason сопоставляет ошибку запуска задачи с причиной её неуспешного завершения. Задачи со сбоем
+// инфраструктуры не завершаются, а повторно доставляются очередью (см. release).
+func failureReason(err error) value.FailureReason {
+	switch {
+	case errors.Is(err, ports.ErrImageBuildFailed):
+		return value.FailureBuildFailed
+	case errors.Is(err, ports.ErrRunTimeout):
+		return value.FailureTimeout
+	case errors.Is(err, ports.ErrOutOfMemory):
+		return value.FailureOutOfMemory
+	case errors.Is(err, ports.ErrFileNotFound):
+		// Удалённый входной файл не появится при повторной доставке.
+		return value.FailureInputFileMissing
+	default:
+		return value.FailureInfrastructureError
+	}
+}
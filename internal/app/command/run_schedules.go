@@ -0,0 +1,135 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// RunSchedulesHandler запускает задачи расписаний, которым пора сработать. Исполнитель вызывает обработчик
+// периодически; одно срабатывание выполняется только одним исполнителем (см. ScheduleRepository.FireSchedule).
+type RunSchedulesHandler struct {
+	sp ports.ScheduleProvider
+	sr ports.ScheduleRepository
+	br ports.BlueprintRepository
+	ep ports.JobEventPublisher
+	l  *slog.Logger
+}
+
+func NewRunSchedulesHandler(
+	sp ports.ScheduleProvider,
+	sr ports.ScheduleRepository,
+	br ports.BlueprintRepository,
+	ep ports.JobEventPublisher,
+	l *slog.Logger,
+) RunSchedulesHandler {
+	return RunSchedulesHandler{sp, sr, br, ep, l}
+}
+
+func (h RunSchedulesHandler) Handle(ctx context.Context) error {
+	l := h.l.With(slog.String("op", "app.RunSchedules"))
+
+	now := time.Now()
+	ids, err := h.sp.DueSchedules(ctx, now)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to get due schedules", slog.String("error", err.Error()))
+		return err
+	}
+
+	var errs []error
+	for _, id := range ids {
+		sl := l.With(slog.String("schedule_id", string(id)))
+		var cancelled *value.JobID
+		err = h.sr.FireSchedule(
+			ctx, id, func(ctx2 context.Context, s *entity.Schedule, prev *entity.Job) (*entity.Job, error) {
+				job, cancelPrev, err2 := h.fire(ctx2, sl, s, prev, now)
+				if cancelPrev {
+					prevID := prev.ID()
+					cancelled = &prevID
+				}
+				return job, err2
+			},
+		)
+		if err != nil {
+			// Срабатывание не перенесено и будет повторено при следующем вызове; отмена предыдущей задачи
+			// не сохранена вместе с ним.
+			sl.ErrorContext(ctx, "failed to fire schedule", slog.String("error", err.Error()))
+			errs = append(errs, err)
+			continue
+		}
+		if cancelled != nil {
+			publishJobState(ctx, h.ep, sl, *cancelled, value.JobCancelled)
+			sl.InfoContext(ctx, "previous scheduled job cancelled", slog.String("job_id", string(*cancelled)))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// fire собирает задачу срабатывания расписания s либо пропускает срабатывание, если задача не может быть
+// запущена или этого требует политика перекрытия. Если политика требует отменить задачу прошлого срабатывания
+// prev, prev отменяется и fire сообщает об этом: отмена сохраняется в одной транзакции со срабатыванием.
+// Контейнер отменённой выполняющейся задачи останавливает StopCancelledJobsHandler.
+func (h RunSchedulesHandler) fire(
+	ctx context.Context, l *slog.Logger, s *entity.Schedule, prev *entity.Job, now time.Time,
+) (*entity.Job, bool, error) {
+	// Расписание могло сработать в другом исполнителе между выборкой и блокировкой.
+	if !s.IsDue(now) {
+		return nil, false, nil
+	}
+
+	var cancelPrev bool
+	if prev != nil {
+		var skip bool
+		skip, cancelPrev = s.ResolveOverlap(prev.State())
+		if skip {
+			s.Skip(now)
+			l.InfoContext(
+				ctx, "schedule run skipped: previous job is not finished",
+				slog.String("job_id", string(prev.ID())),
+			)
+			return nil, false, nil
+		}
+	}
+
+	blueprint, err := h.br.Blueprint(ctx, s.BlueprintID())
+	if errors.Is(err, ports.ErrBlueprintNotFound) {
+		s.Skip(now)
+		l.WarnContext(ctx, "schedule run skipped: blueprint not found")
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	if !blueprint.IsAvailableFor(s.OwnerID()) {
+		s.Skip(now)
+		l.WarnContext(ctx, "schedule run skipped: blueprint is not available")
+		return nil, false, nil
+	}
+
+	job, err := blueprint.AssembleScheduledJob(s, now)
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		s.Skip(now)
+		l.WarnContext(ctx, "schedule run skipped: failed to assemble job", slog.String("error", err.Error()))
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	// Предыдущая задача отменяется, только если новая задача будет запущена.
+	if cancelPrev {
+		if err = prev.Cancel(); err != nil {
+			return nil, false, err
+		}
+	}
+	l.InfoContext(ctx, "schedule fired", slog.String("job_id", string(job.ID())))
+
+	return job, cancelPrev, nil
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type StartBatchHandler struct {
+	br ports.BlueprintRepository
+	sr ports.BatchRepository
+	fp ports.FileProvider
+	l  *slog.Logger
+}
+
+func NewStartBatchHandler(
+	br ports.BlueprintRepository, sr ports.BatchRepository, fp ports.FileProvider, l *slog.Logger,
+) StartBatchHandler {
+	return StartBatchHandler{br, sr, fp, l}
+}
+
+func (h StartBatchHandler) Handle(ctx context.Context, req request.StartBatch) (response.StartBatch, error) {
+	l := h.l.With(
+		slog.String("op", "app.StartBatch"),
+		slog.String("blueprint_id", req.BlueprintID),
+		slog.String("uid", req.ActorID),
+	)
+	l.DebugContext(
+		ctx, "starting batch",
+		slog.Int("value_sets", len(req.ValueSets)),
+		slog.Int("sweep_axes", len(req.Sweep)),
+	)
+
+	blueprint, err := h.br.Blueprint(ctx, value.BlueprintID(req.BlueprintID))
+	if err != nil {
+		l.InfoContext(ctx, "blueprint not found")
+		return response.StartBatch{}, err
+	}
+
+	if !blueprint.IsAvailableFor(value.UserID(req.ActorID)) {
+		l.InfoContext(ctx, "blueprint is not available")
+		return response.StartBatch{}, domain.ErrPermissionDenied
+	}
+
+	inputs, err := batchInputs(req, blueprint)
+	if err != nil {
+		l.InfoContext(ctx, "invalid batch input values", slog.String("error", err.Error()))
+		return response.StartBatch{}, err
+	}
+
+	for _, in := range inputs {
+		err = checkFilesAccess(ctx, h.fp, value.UserID(req.ActorID), in)
+		if err != nil {
+			l.InfoContext(ctx, "input files are not available", slog.String("error", err.Error()))
+			return response.StartBatch{}, err
+		}
+	}
+
+	batch, jobs, err := blueprint.AssembleBatch(value.UserID(req.ActorID), inputs)
+	if err != nil {
+		l.InfoContext(ctx, "failed to assemble batch", slog.String("error", err.Error()))
+		return response.StartBatch{}, err
+	}
+
+	// Задачи публикуются асинхронно вместе с сохранением (см. BatchRepository.SaveBatch).
+	err = h.sr.SaveBatch(ctx, batch, jobs)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to save batch", slog.String("error", err.Error()))
+		return response.StartBatch{}, err
+	}
+	l.InfoContext(
+		ctx, "batch saved successfully",
+		slog.String("id", string(batch.ID())),
+		slog.Int("jobs", len(jobs)),
+	)
+
+	jobIDs := make([]string, len(jobs))
+	for i, job := range jobs {
+		jobIDs[i] = string(job.ID())
+	}
+	return response.StartBatch{
+		BatchID: string(batch.ID()),
+		JobIDs:  jobIDs,
+	}, nil
+}
+
+// batchInputs возвращает наборы входных данных пакета из явного списка либо из перебора значений.
+func batchInputs(req request.StartBatch, blueprint *entity.Blueprint) ([][]value.Value, error) {
+	if (len(req.ValueSets) == 0) == (len(req.Sweep) == 0) {
+		return nil, domain.NewInvalidInputError(
+			"batch-input-invalid", "expected either value sets or sweep to start batch",
+		)
+	}
+
+	if len(req.Sweep) > 0 {
+		return dto.SweepFromDTOs(req.Sweep, blueprint.In())
+	}
+
+	res := make([][]value.Value, len(req.ValueSets))
+	for i, set := range req.ValueSets {
+		in, err := dto.ValuesFromDTOs(set)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = in
+	}
+	return res, nil
+}
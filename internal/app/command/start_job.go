@@ -15,17 +15,14 @@ import (
 type StartJobHandler struct {
 	br ports.BlueprintRepository
 	jr ports.JobRepository
-	jp ports.JobPublisher
+	fp ports.FileProvider
 	l  *slog.Logger
 }
 
 func NewStartJobHandler(
-	br ports.BlueprintRepository,
-	jr ports.JobRepository,
-	jp ports.JobPublisher,
-	l *slog.Logger,
+	br ports.BlueprintRepository, jr ports.JobRepository, fp ports.FileProvider, l *slog.Logger,
 ) StartJobHandler {
-	return StartJobHandler{br, jr, jp, l}
+	return StartJobHandler{br, jr, fp, l}
 }
 
 func (h StartJobHandler) Handle(ctx context.Context, req request.StartJob) (string, error) {
@@ -53,24 +50,25 @@ func (h StartJobHandler) Handle(ctx context.Context, req request.StartJob) (stri
 		return "", err
 	}
 
-	job, err := blueprint.AssembleJob(value.UserID(req.ActorID), in)
+	err = checkFilesAccess(ctx, h.fp, value.UserID(req.ActorID), in)
 	if err != nil {
-		l.InfoContext(ctx, "failed to assemble job", slog.String("error", err.Error()))
+		l.InfoContext(ctx, "input files are not available", slog.String("error", err.Error()))
 		return "", err
 	}
 
-	err = h.jr.SaveJob(ctx, job)
+	job, err := blueprint.AssembleJob(value.UserID(req.ActorID), in)
 	if err != nil {
-		l.ErrorContext(ctx, "failed to save job", slog.String("error", err.Error()))
+		l.InfoContext(ctx, "failed to assemble job", slog.String("error", err.Error()))
 		return "", err
 	}
 
-	err = h.jp.PublishJob(ctx, job)
+	// Задача публикуется асинхронно вместе с сохранением (см. JobRepository.SaveJob).
+	err = h.jr.SaveJob(ctx, job)
 	if err != nil {
-		l.ErrorContext(ctx, "failed to publish job", slog.String("error", err.Error()))
+		l.ErrorContext(ctx, "failed to save job", slog.String("error", err.Error()))
 		return "", err
 	}
-	l.InfoContext(ctx, "job published successfully", slog.String("id", string(job.ID())))
+	l.InfoContext(ctx, "job saved successfully", slog.String("id", string(job.ID())))
 
 	return string(job.ID()), nil
 }
@@ -0,0 +1,82 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type StartPipelineHandler struct {
+	br ports.BlueprintRepository
+	pr ports.PipelineRepository
+	l  *slog.Logger
+}
+
+func NewStartPipelineHandler(
+	br ports.BlueprintRepository, pr ports.PipelineRepository, l *slog.Logger,
+) StartPipelineHandler {
+	return StartPipelineHandler{br, pr, l}
+}
+
+func (h StartPipelineHandler) Handle(ctx context.Context, req request.StartPipeline) (response.StartPipeline, error) {
+	l := h.l.With(
+		slog.String("op", "app.StartPipeline"),
+		slog.String("pipeline_id", req.PipelineID),
+		slog.String("uid", req.ActorID),
+	)
+	l.DebugContext(ctx, "starting pipeline")
+
+	pipeline, err := h.pr.Pipeline(ctx, value.PipelineID(req.PipelineID))
+	if errors.Is(err, ports.ErrPipelineNotFound) {
+		l.InfoContext(ctx, "pipeline not found")
+		return response.StartPipeline{}, err
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to get pipeline", slog.String("error", err.Error()))
+		return response.StartPipeline{}, err
+	}
+
+	if pipeline.OwnerID() != value.UserID(req.ActorID) {
+		l.InfoContext(ctx, "user does not own pipeline", slog.String("owner_id", string(pipeline.OwnerID())))
+		return response.StartPipeline{}, domain.ErrPermissionDenied
+	}
+
+	blueprints, err := availableBlueprints(ctx, h.br, pipeline.Steps(), value.UserID(req.ActorID))
+	if err != nil {
+		l.InfoContext(ctx, "failed to get step blueprints", slog.String("error", err.Error()))
+		return response.StartPipeline{}, err
+	}
+
+	run, jobs, err := pipeline.AssembleRun(value.UserID(req.ActorID), blueprints)
+	if err != nil {
+		l.InfoContext(ctx, "failed to assemble pipeline run", slog.String("error", err.Error()))
+		return response.StartPipeline{}, err
+	}
+
+	// Задачи публикуются асинхронно вместе с сохранением (см. PipelineRepository.SavePipelineRun), следующие
+	// шаги запускает AdvancePipelinesHandler.
+	err = h.pr.SavePipelineRun(ctx, run, jobs)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to save pipeline run", slog.String("error", err.Error()))
+		return response.StartPipeline{}, err
+	}
+	l.InfoContext(
+		ctx, "pipeline run saved successfully",
+		slog.String("run_id", string(run.ID())),
+		slog.Int("jobs", len(jobs)),
+	)
+
+	jobIDs := make([]string, len(jobs))
+	for i, job := range jobs {
+		jobIDs[i] = string(job.ID())
+	}
+	return response.StartPipeline{
+		RunID:  string(run.ID()),
+		JobIDs: jobIDs,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// StopCancelledJobsHandler останавливает контейнеры отменённых задач. CancelJobHandler останавливает контейнер
+// сам, только если процесс имеет доступ к Docker; иначе это делает исполнитель, периодически вызывая обработчик.
+type StopCancelledJobsHandler struct {
+	r  ports.Runner
+	jp ports.JobProvider
+	l  *slog.Logger
+}
+
+func NewStopCancelledJobsHandler(r ports.Runner, jp ports.JobProvider, l *slog.Logger) StopCancelledJobsHandler {
+	return StopCancelledJobsHandler{r, jp, l}
+}
+
+func (h StopCancelledJobsHandler) Handle(ctx context.Context) error {
+	l := h.l.With(slog.String("op", "app.StopCancelledJobs"))
+
+	ids, err := h.r.Running(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to get running jobs", slog.String("error", err.Error()))
+		return err
+	}
+
+	for _, id := range ids {
+		job, err2 := h.jp.Job(ctx, id)
+		if errors.Is(err2, ports.ErrJobNotFound) {
+			continue
+		} else if err2 != nil {
+			l.ErrorContext(
+				ctx, "failed to get job",
+				slog.String("job_id", string(id)),
+				slog.String("error", err2.Error()),
+			)
+			return err2
+		}
+		if job.State != value.JobCancelled.String() {
+			continue
+		}
+
+		err2 = h.r.Stop(ctx, id)
+		if err2 != nil {
+			l.ErrorContext(
+				ctx, "failed to stop job container",
+				slog.String("job_id", string(id)),
+				slog.String("error", err2.Error()),
+			)
+			return err2
+		}
+		l.InfoContext(ctx, "cancelled job container stopped", slog.String("job_id", string(id)))
+	}
+
+	return nil
+}
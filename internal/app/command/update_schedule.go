@@ -0,0 +1,127 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type UpdateScheduleHandler struct {
+	br ports.BlueprintRepository
+	sr ports.ScheduleRepository
+	fp ports.FileProvider
+	l  *slog.Logger
+}
+
+func NewUpdateScheduleHandler(
+	br ports.BlueprintRepository, sr ports.ScheduleRepository, fp ports.FileProvider, l *slog.Logger,
+) UpdateScheduleHandler {
+	return UpdateScheduleHandler{br, sr, fp, l}
+}
+
+func (h UpdateScheduleHandler) Handle(
+	ctx context.Context, req request.UpdateSchedule,
+) (response.UpdateSchedule, error) {
+	l := h.l.With(
+		slog.String("op", "app.UpdateSchedule"),
+		slog.String("schedule_id", req.ScheduleID),
+		slog.String("uid", req.ActorID),
+	)
+
+	var ret *entity.Schedule
+	id := value.ScheduleID(req.ScheduleID)
+	err := h.sr.UpdateSchedule(ctx, id, func(inner context.Context, s *entity.Schedule) error {
+		if s.OwnerID() != value.UserID(req.ActorID) {
+			l.InfoContext(ctx, "user does not own schedule", slog.String("owner_id", string(s.OwnerID())))
+			return domain.ErrPermissionDenied
+		}
+
+		if pValues := req.Values; pValues != nil {
+			if errTx := h.updateInput(inner, s, *pValues); errTx != nil {
+				return errTx
+			}
+		}
+
+		if req.Cron != nil || req.Timezone != nil {
+			if errTx := h.reschedule(s, req.Cron, req.Timezone); errTx != nil {
+				return errTx
+			}
+		}
+
+		if pOverlap := req.OverlapPolicy; pOverlap != nil {
+			overlap, errTx := value.OverlapPolicyFromString(*pOverlap)
+			if errTx != nil {
+				return errTx
+			}
+			if errTx = s.SetOverlapPolicy(overlap); errTx != nil {
+				return errTx
+			}
+		}
+
+		if pEnabled := req.Enabled; pEnabled != nil {
+			if *pEnabled {
+				s.Enable()
+			} else {
+				s.Disable()
+			}
+		}
+
+		ret = s
+		return nil
+	})
+	var iiErr domain.InvalidInputError
+	if errors.Is(err, ports.ErrScheduleNotFound) || errors.Is(err, domain.ErrPermissionDenied) ||
+		errors.Is(err, ports.ErrBlueprintNotFound) || errors.As(err, &iiErr) {
+		l.InfoContext(ctx, "failed to update schedule", slog.String("error", err.Error()))
+		return response.UpdateSchedule{}, err
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to update schedule", slog.String("error", err.Error()))
+		return response.UpdateSchedule{}, err
+	}
+	l.InfoContext(ctx, "schedule updated successfully")
+
+	return dto.ScheduleToDTO(ret), nil
+}
+
+func (h UpdateScheduleHandler) updateInput(ctx context.Context, s *entity.Schedule, values []dto.Value) error {
+	in, err := dto.ValuesFromDTOs(values)
+	if err != nil {
+		return err
+	}
+	if err = checkFilesAccess(ctx, h.fp, s.OwnerID(), in); err != nil {
+		return err
+	}
+	blueprint, err := h.br.Blueprint(ctx, s.BlueprintID())
+	if err != nil {
+		return err
+	}
+	return s.SetInput(blueprint, in)
+}
+
+func (h UpdateScheduleHandler) reschedule(s *entity.Schedule, cronStr *string, timezone *string) error {
+	cron := s.Cron()
+	if cronStr != nil {
+		var err error
+		cron, err = value.CronExprFromString(*cronStr)
+		if err != nil {
+			return err
+		}
+	}
+	location := s.Location()
+	if timezone != nil {
+		var err error
+		location, err = value.LocationFromString(*timezone)
+		if err != nil {
+			return err
+		}
+	}
+	return s.Reschedule(cron, location)
+}
@@ -6,21 +6,24 @@ import (
 
 	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
 	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
 )
 
 type UploadFileHandler struct {
-	u ports.FileUploader
-	l *slog.Logger
+	u  ports.FileUploader
+	fr ports.FileRepository
+	l  *slog.Logger
 }
 
-func NewUploadFileHandler(u ports.FileUploader, l *slog.Logger) UploadFileHandler {
-	return UploadFileHandler{u, l}
+func NewUploadFileHandler(u ports.FileUploader, fr ports.FileRepository, l *slog.Logger) UploadFileHandler {
+	return UploadFileHandler{u, fr, l}
 }
 
 func (h UploadFileHandler) Handle(ctx context.Context, req request.UploadFileRequest) (string, error) {
 	l := h.l.With(
 		slog.String("op", "app.UploadFile"),
 		slog.String("name", req.Name),
+		slog.String("uid", req.ActorID),
 	)
 	l.DebugContext(ctx, "uploading file")
 	id, err := h.u.Upload(ctx, req.Name, req.Reader)
@@ -28,6 +31,13 @@ func (h UploadFileHandler) Handle(ctx context.Context, req request.UploadFileReq
 		l.ErrorContext(ctx, "failed to upload file", slog.String("error", err.Error()))
 		return "", err
 	}
+
+	// Владелец нужен, чтобы файл можно было передать задаче (см. checkFilesAccess).
+	err = h.fr.SaveFile(ctx, id, value.UserID(req.ActorID), req.Name)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to save file owner", slog.String("error", err.Error()))
+		return "", err
+	}
 	l.InfoContext(ctx, "successfully uploaded file", slog.String("id", string(id)))
 	return string(id), nil
 }
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+type Batch struct {
+	ID            string
+	BlueprintID   string
+	BlueprintName string
+	OwnerID       string
+	Size          int
+	Progress      BatchProgress
+	JobIDs        []string
+	CreatedAt     time.Time
+}
+
+// BatchProgress -- число задач пакета в каждом состоянии. Завершённые задачи делятся на успешные (Finished)
+// и неуспешные (Failed).
+type BatchProgress struct {
+	Pending   int
+	Running   int
+	Finished  int
+	Failed    int
+	Cancelled int
+}
@@ -7,28 +7,38 @@ import (
 )
 
 type Blueprint struct {
-	ID         string
-	OwnerID    string
-	ArchiveID  string
-	Name       string
-	Desc       *string
-	Visibility string
-	In         []Field
-	Out        []Field
-	CreatedAt  time.Time
+	ID          string
+	OwnerID     string
+	ArchiveID   string
+	Name        string
+	Desc        *string
+	Visibility  string
+	In          []Field
+	Out         []Field
+	Limits      ResourceLimits
+	Network     bool
+	Timeout     time.Duration
+	RetryPolicy RetryPolicy
+	CreatedAt   time.Time
+	BuildStatus string
 }
 
 func BlueprintToDTO(b *entity.Blueprint) Blueprint {
 	return Blueprint{
-		ID:         string(b.ID()),
-		OwnerID:    string(b.OwnerID()),
-		ArchiveID:  string(b.ArchiveID()),
-		Name:       b.Name(),
-		Desc:       b.Desc(),
-		Visibility: b.Vis().String(),
-		In:         fieldsToDTOs(b.In()),
-		Out:        fieldsToDTOs(b.Out()),
-		CreatedAt:  b.CreatedAt(),
+		ID:          string(b.ID()),
+		OwnerID:     string(b.OwnerID()),
+		ArchiveID:   string(b.ArchiveID()),
+		Name:        b.Name(),
+		Desc:        b.Desc(),
+		Visibility:  b.Vis().String(),
+		In:          fieldsToDTOs(b.In()),
+		Out:         fieldsToDTOs(b.Out()),
+		Limits:      resourceLimitsToDTO(b.Limits()),
+		Network:     b.Network(),
+		Timeout:     b.Timeout(),
+		RetryPolicy: retryPolicyToDTO(b.RetryPolicy()),
+		CreatedAt:   b.CreatedAt(),
+		BuildStatus: b.BuildStatus().String(),
 	}
 }
 
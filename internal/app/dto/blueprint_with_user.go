@@ -3,14 +3,19 @@ package dto
 import "time"
 
 type BlueprintWithUser struct {
-	ID         string
-	ArchiveID  string
-	Name       string
-	Desc       *string
-	Visibility string
-	In         []Field
-	Out        []Field
-	OwnerID    string
-	OwnerName  string
-	CreatedAt  time.Time
+	ID          string
+	ArchiveID   string
+	Name        string
+	Desc        *string
+	Visibility  string
+	In          []Field
+	Out         []Field
+	Limits      ResourceLimits
+	Network     bool
+	Timeout     time.Duration
+	RetryPolicy RetryPolicy
+	OwnerID     string
+	OwnerName   string
+	CreatedAt   time.Time
+	BuildStatus string
 }
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// DeadLetter -- задача, обработка которой не удалась после всех повторных попыток.
+type DeadLetter struct {
+	JobID     string
+	OwnerID   string
+	State     string
+	Error     string
+	Attempts  int
+	CreatedAt time.Time
+}
@@ -9,6 +9,20 @@ type Field struct {
 	Name string
 	Desc *string
 	Unit *string
+	// Options -- допустимые значения поля типа enum.
+	Options []string
+	// Constraints -- ограничения значений входного поля; nil, если ограничений нет.
+	Constraints *FieldConstraints
+	// Default -- значение необязательного входного поля по умолчанию; nil у обязательных полей.
+	Default *string
+}
+
+type FieldConstraints struct {
+	Min       *string
+	Max       *string
+	MinLength *int
+	MaxLength *int
+	Pattern   *string
 }
 
 func fieldFromDTO(dto Field) (value.Field, error) {
@@ -16,11 +30,21 @@ func fieldFromDTO(dto Field) (value.Field, error) {
 	if err != nil {
 		return value.Field{}, err
 	}
+	var constraints value.FieldConstraints
+	if c := dto.Constraints; c != nil {
+		constraints, err = value.NewFieldConstraints(t, dto.Name, c.Min, c.Max, c.MinLength, c.MaxLength, c.Pattern)
+		if err != nil {
+			return value.Field{}, err
+		}
+	}
 	return value.NewField(
 		t,
 		dto.Name,
 		dto.Desc,
 		dto.Unit,
+		dto.Options,
+		constraints,
+		dto.Default,
 	)
 }
 
@@ -38,10 +62,34 @@ func FieldsFromDTOs(dtos []Field) ([]value.Field, error) {
 
 func fieldToDTO(f value.Field) Field {
 	return Field{
-		Type: f.Type().String(),
-		Name: f.Name(),
-		Desc: f.Desc(),
-		Unit: f.Unit(),
+		Type:        f.Type().String(),
+		Name:        f.Name(),
+		Desc:        f.Desc(),
+		Unit:        f.Unit(),
+		Options:     f.Options(),
+		Constraints: fieldConstraintsToDTO(f.Constraints()),
+		Default:     defaultToDTO(f.Default()),
+	}
+}
+
+func defaultToDTO(v *value.Value) *string {
+	if v == nil {
+		return nil
+	}
+	s := v.String()
+	return &s
+}
+
+func fieldConstraintsToDTO(c value.FieldConstraints) *FieldConstraints {
+	if c.IsZero() {
+		return nil
+	}
+	return &FieldConstraints{
+		Min:       c.Min(),
+		Max:       c.Max(),
+		MinLength: c.MinLength(),
+		MaxLength: c.MaxLength(),
+		Pattern:   c.Pattern(),
 	}
 }
 
@@ -1,8 +0,0 @@
-package dto
-
-import "io"
-
-type File struct {
-	Name   string
-	Reader io.Reader
-}
@@ -4,6 +4,9 @@ import "time"
 
 type Job struct {
 	ID            string
+	ParentJobID   *string
+	BatchID       *string
+	ScheduleID    *string
 	OwnerID       string
 	BlueprintID   string
 	BlueprintName string
@@ -14,7 +17,20 @@ type Job struct {
 	Output        []Value
 	ResultCode    *int
 	ResultMsg     *string
+	ResultReason  *string
+	Attempts      []JobAttempt
 	CreatedAt     time.Time
+	NextAttemptAt *time.Time
 	StartedAt     *time.Time
 	FinishedAt    *time.Time
 }
+
+// JobAttempt -- завершённая попытка выполнения задачи.
+type JobAttempt struct {
+	Number       int
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	ResultCode   int
+	ResultMsg    *string
+	ResultReason *string
+}
@@ -0,0 +1,21 @@
+package dto
+
+const (
+	JobEventState  = "state"
+	JobEventOutput = "output"
+)
+
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// JobEvent -- событие выполнения задачи: смена состояния (Kind == JobEventState) или строка вывода
+// контейнера (Kind == JobEventOutput).
+type JobEvent struct {
+	JobID  string
+	Kind   string
+	State  string
+	Stream string
+	Line   string
+}
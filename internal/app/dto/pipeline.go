@@ -0,0 +1,160 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type Pipeline struct {
+	ID        string
+	OwnerID   string
+	Name      string
+	Desc      *string
+	Steps     []PipelineStep
+	CreatedAt time.Time
+}
+
+type PipelineStep struct {
+	Name        string
+	BlueprintID string
+	Input       []StepInput
+}
+
+// StepInput -- источник входного поля шага: постоянное значение Value либо выходное поле с индексом
+// SourceField шага SourceStep.
+type StepInput struct {
+	Value       *Value
+	SourceStep  *string
+	SourceField *int
+}
+
+type PipelineRun struct {
+	ID         string
+	PipelineID string
+	OwnerID    string
+	State      string
+	Steps      []StepRun
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+type StepRun struct {
+	Name    string
+	State   string
+	JobID   *string
+	Message *string
+}
+
+func PipelineStepsFromDTOs(dtos []PipelineStep) ([]value.PipelineStep, error) {
+	res := make([]value.PipelineStep, len(dtos))
+	for i, d := range dtos {
+		input := make([]value.StepInput, len(d.Input))
+		for k, in := range d.Input {
+			var err error
+			input[k], err = stepInputFromDTO(in)
+			if err != nil {
+				return nil, err
+			}
+		}
+		step, err := value.NewPipelineStep(d.Name, value.BlueprintID(d.BlueprintID), input)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = step
+	}
+	return res, nil
+}
+
+func stepInputFromDTO(d StepInput) (value.StepInput, error) {
+	if (d.Value == nil) == (d.SourceStep == nil || d.SourceField == nil) {
+		return value.StepInput{}, domain.NewInvalidInputError(
+			"step-input-invalid", "expected either value or source step and field of step input",
+		)
+	}
+	if d.Value != nil {
+		v, err := valueFromDTO(*d.Value)
+		if err != nil {
+			return value.StepInput{}, err
+		}
+		return value.NewConstStepInput(v), nil
+	}
+	return value.NewOutputStepInput(*d.SourceStep, *d.SourceField)
+}
+
+func PipelineToDTO(p *entity.Pipeline) Pipeline {
+	steps := make([]PipelineStep, len(p.Steps()))
+	for i, step := range p.Steps() {
+		input := make([]StepInput, len(step.Input()))
+		for k, in := range step.Input() {
+			input[k] = stepInputToDTO(in)
+		}
+		steps[i] = PipelineStep{
+			Name:        step.Name(),
+			BlueprintID: string(step.BlueprintID()),
+			Input:       input,
+		}
+	}
+	return Pipeline{
+		ID:        string(p.ID()),
+		OwnerID:   string(p.OwnerID()),
+		Name:      p.Name(),
+		Desc:      p.Desc(),
+		Steps:     steps,
+		CreatedAt: p.CreatedAt(),
+	}
+}
+
+func stepInputToDTO(in value.StepInput) StepInput {
+	if v, ok := in.Const(); ok {
+		d := valueToDTO(v)
+		return StepInput{Value: &d}
+	}
+	step := in.Step()
+	field := in.Field()
+	return StepInput{SourceStep: &step, SourceField: &field}
+}
+
+func PipelinesToDTOs(ps []*entity.Pipeline) []Pipeline {
+	res := make([]Pipeline, len(ps))
+	for i, p := range ps {
+		res[i] = PipelineToDTO(p)
+	}
+	return res
+}
+
+func PipelineRunToDTO(r *entity.PipelineRun) PipelineRun {
+	steps := make([]StepRun, len(r.Steps()))
+	for i, s := range r.Steps() {
+		var jobID *string
+		if id := s.JobID(); id != nil {
+			sID := string(*id)
+			jobID = &sID
+		}
+		steps[i] = StepRun{
+			Name:    s.Name(),
+			State:   s.State().String(),
+			JobID:   jobID,
+			Message: s.Message(),
+		}
+	}
+	return PipelineRun{
+		ID:         string(r.ID()),
+		PipelineID: string(r.PipelineID()),
+		OwnerID:    string(r.OwnerID()),
+		State:      r.State().String(),
+		Steps:      steps,
+		CreatedAt:  r.CreatedAt(),
+		FinishedAt: r.FinishedAt(),
+	}
+}
+
+func PipelineRunsToDTOs(rs []*entity.PipelineRun) []PipelineRun {
+	res := make([]PipelineRun, len(rs))
+	for i, r := range rs {
+		res[i] = PipelineRunToDTO(r)
+	}
+	return res
+}
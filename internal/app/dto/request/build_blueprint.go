@@ -0,0 +1,5 @@
+package request
+
+type BuildBlueprint struct {
+	BlueprintID string
+}
@@ -0,0 +1,6 @@
+package request
+
+type CancelJob struct {
+	ActorID string
+	JobID   string
+}
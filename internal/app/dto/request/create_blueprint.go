@@ -1,13 +1,21 @@
 package request
 
-import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+import (
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+)
 
 type CreateBlueprint struct {
-	ActorID    string
-	ArchiveID  string
-	Name       string
-	Desc       *string
-	In         []dto.Field
-	Out        []dto.Field
-	Visibility string
+	ActorID     string
+	ArchiveID   string
+	Name        string
+	Desc        *string
+	In          []dto.Field
+	Out         []dto.Field
+	Limits      dto.ResourceLimits
+	Network     bool
+	Timeout     time.Duration
+	RetryPolicy dto.RetryPolicy
+	Visibility  string
 }
@@ -0,0 +1,10 @@
+package request
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+type CreatePipeline struct {
+	ActorID string
+	Name    string
+	Desc    *string
+	Steps   []dto.PipelineStep
+}
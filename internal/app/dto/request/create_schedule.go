@@ -0,0 +1,15 @@
+package request
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+type CreateSchedule struct {
+	ActorID     string
+	BlueprintID string
+	Values      []dto.Value
+	Cron        string
+	Timezone    string
+	// OverlapPolicy по умолчанию -- forbid.
+	OverlapPolicy *string
+	// Enabled по умолчанию -- true.
+	Enabled *bool
+}
@@ -0,0 +1,7 @@
+package request
+
+type DeadLetterJob struct {
+	JobID    string
+	Error    string
+	Attempts int
+}
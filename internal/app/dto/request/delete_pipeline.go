@@ -0,0 +1,6 @@
+package request
+
+type DeletePipeline struct {
+	ActorID    string
+	PipelineID string
+}
@@ -0,0 +1,6 @@
+package request
+
+type DeleteSchedule struct {
+	ActorID    string
+	ScheduleID string
+}
@@ -0,0 +1,6 @@
+package request
+
+type GetBatch struct {
+	ActorID string
+	BatchID string
+}
@@ -0,0 +1,6 @@
+package request
+
+type GetBatchResults struct {
+	ActorID string
+	BatchID string
+}
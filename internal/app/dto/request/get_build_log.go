@@ -0,0 +1,6 @@
+package request
+
+type GetBuildLog struct {
+	ActorID     string
+	BlueprintID string
+}
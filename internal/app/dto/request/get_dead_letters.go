@@ -0,0 +1,5 @@
+package request
+
+type GetDeadLetters struct {
+	ActorID string
+}
@@ -0,0 +1,8 @@
+package request
+
+type GetJobArtifact struct {
+	ActorID string
+	JobID   string
+	// Name -- имя выходного поля типа file.
+	Name string
+}
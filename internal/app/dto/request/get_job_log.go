@@ -0,0 +1,6 @@
+package request
+
+type GetJobLog struct {
+	ActorID string
+	JobID   string
+}
@@ -0,0 +1,6 @@
+package request
+
+type GetPipeline struct {
+	ActorID    string
+	PipelineID string
+}
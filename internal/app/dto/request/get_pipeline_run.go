@@ -0,0 +1,6 @@
+package request
+
+type GetPipelineRun struct {
+	ActorID string
+	RunID   string
+}
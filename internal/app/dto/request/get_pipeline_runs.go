@@ -0,0 +1,6 @@
+package request
+
+type GetPipelineRuns struct {
+	ActorID    string
+	PipelineID string
+}
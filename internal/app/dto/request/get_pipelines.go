@@ -0,0 +1,5 @@
+package request
+
+type GetPipelines struct {
+	ActorID string
+}
@@ -0,0 +1,6 @@
+package request
+
+type GetSchedule struct {
+	ActorID    string
+	ScheduleID string
+}
@@ -0,0 +1,6 @@
+package request
+
+type GetScheduleJobs struct {
+	ActorID    string
+	ScheduleID string
+}
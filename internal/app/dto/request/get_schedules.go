@@ -0,0 +1,5 @@
+package request
+
+type GetSchedules struct {
+	ActorID string
+}
@@ -0,0 +1,5 @@
+package request
+
+type GetWorkerStats struct {
+	ActorID string
+}
@@ -0,0 +1,10 @@
+package request
+
+import "time"
+
+type ReconcileJobs struct {
+	// HeartbeatTimeout -- время без heartbeat, после которого выполняющаяся задача считается прерванной.
+	HeartbeatTimeout time.Duration
+	// RepublishPending -- повторно публиковать ожидающие задачи, не найденные ни в outbox, ни в очереди.
+	RepublishPending bool
+}
@@ -0,0 +1,6 @@
+package request
+
+type RequeueDeadLetter struct {
+	ActorID string
+	JobID   string
+}
@@ -0,0 +1,10 @@
+package request
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+type RerunJob struct {
+	ActorID string
+	JobID   string
+	// Overrides заменяет входные значения исходной задачи по их индексу.
+	Overrides map[int]dto.Value
+}
@@ -0,0 +1,12 @@
+package request
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+// StartBatch запускает пакет задач: либо по одной на каждый набор ValueSets, либо по одной на каждое сочетание
+// значений перебора Sweep.
+type StartBatch struct {
+	ActorID     string
+	BlueprintID string
+	ValueSets   [][]dto.Value
+	Sweep       []dto.SweepAxis
+}
@@ -0,0 +1,6 @@
+package request
+
+type StartPipeline struct {
+	ActorID    string
+	PipelineID string
+}
@@ -0,0 +1,14 @@
+package request
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+// UpdateSchedule изменяет только заданные поля расписания.
+type UpdateSchedule struct {
+	ActorID       string
+	ScheduleID    string
+	Values        *[]dto.Value
+	Cron          *string
+	Timezone      *string
+	OverlapPolicy *string
+	Enabled       *bool
+}
@@ -1,5 +1,9 @@
 package request
 
-import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+import "io"
 
-type UploadFileRequest dto.File
+type UploadFileRequest struct {
+	ActorID string
+	Name    string
+	Reader  io.Reader
+}
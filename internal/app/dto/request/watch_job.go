@@ -0,0 +1,6 @@
+package request
+
+type WatchJob struct {
+	ActorID string
+	JobID   string
+}
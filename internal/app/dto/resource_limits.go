@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// ResourceLimits -- ограничения ресурсов контейнера; нулевое значение поля означает отсутствие ограничения.
+type ResourceLimits struct {
+	MemoryMB int64
+	CPUs     float64
+	Pids     int64
+	TmpfsMB  int64
+}
+
+func ResourceLimitsFromDTO(dto ResourceLimits) (value.ResourceLimits, error) {
+	return value.NewResourceLimits(dto.MemoryMB, dto.CPUs, dto.Pids, dto.TmpfsMB)
+}
+
+func resourceLimitsToDTO(l value.ResourceLimits) ResourceLimits {
+	return ResourceLimits{
+		MemoryMB: l.MemoryMB(),
+		CPUs:     l.CPUs(),
+		Pids:     l.Pids(),
+		TmpfsMB:  l.TmpfsMB(),
+	}
+}
@@ -0,0 +1,3 @@
+package response
+
+type CreatePipeline = string
@@ -0,0 +1,3 @@
+package response
+
+type CreateSchedule = string
@@ -0,0 +1,6 @@
+package response
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+// GetBatchResults -- задачи пакета с их входными данными и результатами в порядке создания.
+type GetBatchResults = []dto.Job
@@ -0,0 +1,6 @@
+package response
+
+type GetBuildLog struct {
+	BuildStatus string
+	Log         string
+}
@@ -0,0 +1,5 @@
+package response
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+type GetDeadLetters = []dto.DeadLetter
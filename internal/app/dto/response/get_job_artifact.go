@@ -0,0 +1,9 @@
+package response
+
+import "io"
+
+// GetJobArtifact -- содержимое файла выходного поля задачи. Получатель обязан закрыть Content.
+type GetJobArtifact struct {
+	Name    string
+	Content io.ReadCloser
+}
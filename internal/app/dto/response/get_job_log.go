@@ -0,0 +1,6 @@
+package response
+
+type GetJobLog struct {
+	State string
+	Log   string
+}
@@ -0,0 +1,6 @@
+package response
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+// GetPipelineRuns -- запуски конвейера начиная с последнего.
+type GetPipelineRuns = []dto.PipelineRun
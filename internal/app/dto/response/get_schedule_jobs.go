@@ -0,0 +1,6 @@
+package response
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+// GetScheduleJobs -- история расписания: запущенные по нему задачи начиная с последней.
+type GetScheduleJobs = []dto.Job
@@ -0,0 +1,6 @@
+package response
+
+type StartBatch struct {
+	BatchID string
+	JobIDs  []string
+}
@@ -0,0 +1,7 @@
+package response
+
+type StartPipeline struct {
+	RunID string
+	// JobIDs -- задачи шагов, запущенных сразу: шагов, не зависящих от других.
+	JobIDs []string
+}
@@ -0,0 +1,10 @@
+package response
+
+import "github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+
+type WatchJob struct {
+	// Job -- состояние задачи на момент подписки.
+	Job dto.Job
+	// Events закрывается, когда задача переходит в конечное состояние или отменяется контекст запроса.
+	Events <-chan dto.JobEvent
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// RetryPolicy -- политика повторного запуска неуспешно завершившихся задач; нулевое значение -- без повторов.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	On          string
+}
+
+// RetryPolicyFromDTO создаёт политику повторов; пустое On означает повтор только при сбоях инфраструктуры.
+func RetryPolicyFromDTO(dto RetryPolicy) (value.RetryPolicy, error) {
+	var on value.RetryOn
+	if dto.On != "" {
+		var err error
+		on, err = value.RetryOnFromString(dto.On)
+		if err != nil {
+			return value.RetryPolicy{}, err
+		}
+	}
+	return value.NewRetryPolicy(dto.MaxAttempts, dto.Backoff, on)
+}
+
+func retryPolicyToDTO(p value.RetryPolicy) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: p.MaxAttempts(),
+		Backoff:     p.Backoff(),
+		On:          p.On().String(),
+	}
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+)
+
+type Schedule struct {
+	ID            string
+	BlueprintID   string
+	OwnerID       string
+	Input         []Value
+	Cron          string
+	Timezone      string
+	OverlapPolicy string
+	Enabled       bool
+	LastJobID     *string
+	NextRunAt     *time.Time
+	CreatedAt     time.Time
+}
+
+func ScheduleToDTO(s *entity.Schedule) Schedule {
+	var lastJobID *string
+	if id := s.LastJobID(); id != nil {
+		jobID := string(*id)
+		lastJobID = &jobID
+	}
+	return Schedule{
+		ID:            string(s.ID()),
+		BlueprintID:   string(s.BlueprintID()),
+		OwnerID:       string(s.OwnerID()),
+		Input:         valuesToDTOs(s.Input()),
+		Cron:          s.Cron().String(),
+		Timezone:      s.Location().String(),
+		OverlapPolicy: s.OverlapPolicy().String(),
+		Enabled:       s.Enabled(),
+		LastJobID:     lastJobID,
+		NextRunAt:     s.NextRunAt(),
+		CreatedAt:     s.CreatedAt(),
+	}
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// SweepAxis -- значения одного входного поля при переборе: явный список Values либо диапазон Range.
+type SweepAxis struct {
+	Values []string
+	Range  *ValueRange
+}
+
+// ValueRange -- числовой диапазон от Start до Stop включительно с шагом Step.
+type ValueRange struct {
+	Start string
+	Stop  string
+	Step  string
+}
+
+// SweepFromDTOs раскрывает перебор в наборы входных данных -- декартово произведение значений осей. Оси
+// соответствуют входным полям fields по порядку, значения приводятся к типам полей. Оси последних необязательных
+// полей можно опустить -- задачи получат значения по умолчанию.
+func SweepFromDTOs(axes []SweepAxis, fields []value.Field) ([][]value.Value, error) {
+	if len(axes) > len(fields) {
+		return nil, domain.NewInvalidInputError(
+			"sweep-axes-mismatch", fmt.Sprintf("expected at most %d sweep axes, got %d", len(fields), len(axes)),
+		)
+	}
+
+	values := make([][]value.Value, len(axes))
+	for i, axis := range axes {
+		vs, err := sweepAxisFromDTO(axis, fields[i].Type())
+		if err != nil {
+			return nil, err
+		}
+		values[i] = vs
+	}
+	return value.CartesianProduct(values)
+}
+
+func sweepAxisFromDTO(axis SweepAxis, t value.Type) ([]value.Value, error) {
+	if (axis.Range == nil) == (len(axis.Values) == 0) {
+		return nil, domain.NewInvalidInputError(
+			"sweep-axis-invalid", "expected either values or range for each sweep axis",
+		)
+	}
+
+	if axis.Range != nil {
+		return value.RangeValues(t, axis.Range.Start, axis.Range.Stop, axis.Range.Step)
+	}
+
+	res := make([]value.Value, len(axis.Values))
+	for i, s := range axis.Values {
+		v, err := value.NewValue(t, s)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+	return res, nil
+}
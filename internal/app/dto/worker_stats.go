@@ -0,0 +1,8 @@
+package dto
+
+type WorkerStats struct {
+	MaxConcurrentJobs int
+	BusyWorkers       int
+	// QueueDepth -- число задач, ожидающих свободного обработчика.
+	QueueDepth int
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+var ErrBatchNotFound = errors.New("batch not found")
+
+type BatchProvider interface {
+	Batch(ctx context.Context, id value.BatchID) (dto.Batch, error)
+	// BatchJobs возвращает задачи пакета в порядке их создания.
+	BatchJobs(ctx context.Context, id value.BatchID) ([]dto.Job, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+)
+
+type BatchRepository interface {
+	// SaveBatch сохраняет новый пакет вместе с его задачами в одной транзакции; каждая задача ставится на
+	// публикацию в JobPublisher так же, как в JobRepository.SaveJob.
+	SaveBatch(ctx context.Context, batch *entity.Batch, jobs []*entity.Job) error
+}
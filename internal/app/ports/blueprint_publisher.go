@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+)
+
+type BlueprintPublisher interface {
+	// PublishBlueprintBuild ставит сборку образа Blueprint в очередь.
+	PublishBlueprintBuild(ctx context.Context, blueprint *entity.Blueprint) error
+}
@@ -10,5 +10,13 @@ import (
 type BlueprintRepository interface {
 	Blueprint(ctx context.Context, id value.BlueprintID) (*entity.Blueprint, error)
 	SaveBlueprint(ctx context.Context, box *entity.Blueprint) error
+	UpdateBlueprint(
+		ctx context.Context,
+		id value.BlueprintID,
+		updateFn func(ctx2 context.Context, blueprint *entity.Blueprint) error,
+	) error
 	DeleteBlueprint(ctx context.Context, id value.BlueprintID) error
+
+	// UnbuiltBlueprints возвращает все Blueprint, сборка образа которых ещё не завершена.
+	UnbuiltBlueprints(ctx context.Context) ([]*entity.Blueprint, error)
 }
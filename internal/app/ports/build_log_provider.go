@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+var ErrBuildLogNotFound = errors.New("build log not found")
+
+type BuildLogProvider interface {
+	// BuildLog возвращает вывод последней сборки образа Blueprint или ошибку ErrBuildLogNotFound.
+	BuildLog(ctx context.Context, id value.BlueprintID) (string, error)
+}
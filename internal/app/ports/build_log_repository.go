@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type BuildLogRepository interface {
+	// SaveBuildLog сохраняет вывод последней сборки образа Blueprint, заменяя предыдущий.
+	SaveBuildLog(ctx context.Context, id value.BlueprintID, log string) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+type DeadLetterProvider interface {
+	DeadLetter(ctx context.Context, id value.JobID) (dto.DeadLetter, error)
+	DeadLetters(ctx context.Context) ([]dto.DeadLetter, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type DeadLetterRepository interface {
+	// SaveDeadLetter помещает задачу в очередь недоставленных, заменяя предыдущую запись о ней.
+	SaveDeadLetter(ctx context.Context, id value.JobID, reason string, attempts int) error
+	// RequeueDeadLetter удаляет задачу из очереди недоставленных и в той же транзакции повторно ставит её на
+	// публикацию. Если задачи нет среди недоставленных, возвращает ErrDeadLetterNotFound.
+	RequeueDeadLetter(ctx context.Context, id value.JobID) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// FileProvider возвращает сведения о загруженных файлах, которые хранятся отдельно от их содержимого.
+type FileProvider interface {
+	// FileOwner возвращает ID загрузившего файл пользователя. Если файл не учтён, возвращается ErrFileNotFound.
+	FileOwner(ctx context.Context, id value.FileID) (value.UserID, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type FileRepository interface {
+	FileProvider
+
+	// SaveFile учитывает загруженный пользователем ownerID файл id с исходным названием name.
+	SaveFile(ctx context.Context, id value.FileID, ownerID value.UserID, name string) error
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+)
+
+type JobEventPublisher interface {
+	// PublishJobEvent доставляет событие Job текущим подписчикам; событие без подписчиков отбрасывается.
+	PublishJobEvent(ctx context.Context, e dto.JobEvent) error
+}
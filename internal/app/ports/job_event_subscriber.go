@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type JobEventSubscriber interface {
+	// SubscribeJobEvents возвращает канал событий Job, опубликованных после подписки. Канал закрывается
+	// после отмены ctx.
+	SubscribeJobEvents(ctx context.Context, id value.JobID) (<-chan dto.JobEvent, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type JobHeartbeatRepository interface {
+	// BeatJob отмечает, что исполнитель задачи продолжает её обрабатывать.
+	BeatJob(ctx context.Context, id value.JobID) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+var ErrJobLogNotFound = errors.New("job log not found")
+
+type JobLogProvider interface {
+	// JobLog возвращает лог (stderr) выполнения Job или ошибку ErrJobLogNotFound.
+	JobLog(ctx context.Context, id value.JobID) (string, error)
+}
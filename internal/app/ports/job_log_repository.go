@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type JobLogRepository interface {
+	// SaveJobLog сохраняет лог (stderr) выполнения Job, заменяя предыдущий.
+	SaveJobLog(ctx context.Context, id value.JobID, log string) error
+}
@@ -10,6 +10,10 @@ import (
 
 var ErrJobNotFound = errors.New("job not found")
 
+// ErrArtifactNotFound -- у задачи нет файла выходного поля с таким именем: поля нет, оно не типа file или
+// задача не завершилась успешно.
+var ErrArtifactNotFound = errors.New("artifact not found")
+
 type JobProvider interface {
 	Job(ctx context.Context, id value.JobID) (dto.Job, error)
 	UserJobs(ctx context.Context, uid value.UserID) ([]dto.Job, error)
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type JobReconcileProvider interface {
+	// OrphanedJobs возвращает выполняющиеся задачи, heartbeat которых не обновлялся дольше timeout.
+	OrphanedJobs(ctx context.Context, timeout time.Duration) ([]value.JobID, error)
+	// UndeliveredJobs возвращает ожидающие задачи, которых нет ни в outbox, ни в очереди Postgres, ни среди
+	// недоставленных.
+	UndeliveredJobs(ctx context.Context) ([]value.JobID, error)
+}
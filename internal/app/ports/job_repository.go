@@ -11,6 +11,12 @@ import (
 var ErrJobAlreadyExists = errors.New("job already exists")
 
 type JobRepository interface {
+	// SaveJob сохраняет новую задачу. Ожидающая выполнения задача в той же транзакции ставится на публикацию
+	// в JobPublisher, поэтому не может оказаться сохранённой, но не опубликованной.
 	SaveJob(ctx context.Context, job *entity.Job) error
+	// UpdateJob изменяет задачу в транзакции. Задача, вернувшаяся в ожидание повторной попытки, в той же
+	// транзакции ставится на публикацию в JobPublisher не раньше Job.NextAttemptAt.
 	UpdateJob(ctx context.Context, id value.JobID, updateFn func(ctx2 context.Context, job *entity.Job) error) error
+	// RepublishJob повторно ставит ожидающую задачу на публикацию в JobPublisher.
+	RepublishJob(ctx context.Context, id value.JobID) error
 }
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+var ErrPipelineNotFound = errors.New("pipeline not found")
+var ErrPipelineRunNotFound = errors.New("pipeline run not found")
+
+type PipelineProvider interface {
+	Pipeline(ctx context.Context, id value.PipelineID) (*entity.Pipeline, error)
+	UserPipelines(ctx context.Context, uid value.UserID) ([]*entity.Pipeline, error)
+	PipelineRun(ctx context.Context, id value.PipelineRunID) (*entity.PipelineRun, error)
+	// PipelineRuns возвращает запуски конвейера, начиная с последнего.
+	PipelineRuns(ctx context.Context, id value.PipelineID) ([]*entity.PipelineRun, error)
+	// RunningPipelineRuns возвращает незавершённые запуски всех конвейеров.
+	RunningPipelineRuns(ctx context.Context) ([]value.PipelineRunID, error)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type PipelineRepository interface {
+	PipelineProvider
+	SavePipeline(ctx context.Context, pipeline *entity.Pipeline) error
+	// DeletePipeline удаляет конвейер вместе с его запусками; задачи запусков сохраняются.
+	DeletePipeline(ctx context.Context, id value.PipelineID) error
+
+	// SavePipelineRun сохраняет новый запуск конвейера вместе с задачами его шагов в одной транзакции; каждая
+	// задача ставится на публикацию в JobPublisher так же, как в JobRepository.SaveJob.
+	SavePipelineRun(ctx context.Context, run *entity.PipelineRun, jobs []*entity.Job) error
+	// AdvancePipelineRun изменяет запуск конвейера в транзакции, блокируя его от одновременного продвижения
+	// в других исполнителях. advanceFn получает задачи запущенных шагов по их ID; задачи, возвращённые
+	// advanceFn, сохраняются в той же транзакции так же, как в SavePipelineRun.
+	AdvancePipelineRun(
+		ctx context.Context,
+		id value.PipelineRunID,
+		advanceFn func(
+			ctx2 context.Context, run *entity.PipelineRun, jobs map[value.JobID]*entity.Job,
+		) ([]*entity.Job, error),
+	) error
+}
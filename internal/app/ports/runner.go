@@ -2,13 +2,63 @@ package ports
 
 import (
 	"context"
+	"errors"
 	"io"
+	"time"
 
 	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
 )
 
+var ErrImageBuildFailed = errors.New("image build failed")
+var ErrRunTimeout = errors.New("run timeout exceeded")
+var ErrOutOfMemory = errors.New("container out of memory")
+
+// RunSpec -- параметры запуска контейнера задачи.
+type RunSpec struct {
+	JobID  value.JobID
+	Image  value.ImageTag
+	Input  []value.Value
+	Limits value.ResourceLimits
+
+	// Files -- содержимое файлов значений Input типа file. Runner делает каждый файл доступным в контейнере
+	// только для чтения и передаёт на вход задачи вместо ID файла путь к нему (см. реализацию Runner).
+	Files map[value.FileID]io.Reader
+
+	// Artifacts -- имена файлов, которые скрипт записывает в каталог /out (выходные поля типа file). После
+	// успешного завершения Runner передаёт содержимое каждого записанного файла в SaveArtifact до удаления
+	// контейнера и возвращает полученные ID в value.Result; незаписанные файлы пропускаются.
+	Artifacts    []string
+	SaveArtifact func(ctx context.Context, name string, content io.Reader) (value.FileID, error)
+
+	// BeforeStart, если задан, вызывается после создания контейнера и до его запуска. Ошибка BeforeStart
+	// отменяет запуск, и Run возвращает её без изменений. Так вызывающий может проверить, что задачу не
+	// отменили, пока контейнер создавался: остановка задачи до создания контейнера его не найдёт.
+	BeforeStart func(ctx context.Context) error
+
+	// Network запрашивает доступ контейнера к сети; предоставляется, только если разрешён администратором.
+	Network bool
+
+	// Timeout ограничивает время выполнения; нулевое значение -- ограничение по умолчанию. По истечении
+	// контейнер останавливается, а ошибка оборачивает ErrRunTimeout.
+	Timeout time.Duration
+
+	// Stdout и Stderr, если заданы, получают вывод контейнера по мере его появления -- ещё до завершения
+	// контейнера. Итоговый value.Result содержит весь вывод независимо от них.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
 type Runner interface {
-	Build(ctx context.Context, archive io.Reader, id value.BlueprintID) (value.ImageTag, error)
-	Run(ctx context.Context, image value.ImageTag, input []value.Value) (value.Result, error)
+	// Build собирает образ Blueprint, записывая в log вывод сборки. Ошибка сборки, вызванная содержимым
+	// архива (а не недоступностью Docker), оборачивает ErrImageBuildFailed.
+	Build(ctx context.Context, archive io.Reader, id value.BlueprintID, log io.Writer) (value.ImageTag, error)
+	// Run запускает контейнер задачи в изолированном окружении. Если контейнер завершён из-за нехватки памяти,
+	// ошибка оборачивает ErrOutOfMemory.
+	Run(ctx context.Context, spec RunSpec) (value.Result, error)
 	Cleanup(ctx context.Context, image value.ImageTag) error
+
+	// Stop принудительно останавливает и удаляет контейнер задачи. Если контейнер не найден, ошибки не возникает.
+	Stop(ctx context.Context, id value.JobID) error
+	// Running возвращает задачи, контейнеры которых сейчас существуют, независимо от запустившего их экземпляра.
+	Running(ctx context.Context) ([]value.JobID, error)
 }
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+type ScheduleProvider interface {
+	Schedule(ctx context.Context, id value.ScheduleID) (dto.Schedule, error)
+	UserSchedules(ctx context.Context, uid value.UserID) ([]dto.Schedule, error)
+	// ScheduleJobs возвращает задачи, запущенные по расписанию, начиная с последней.
+	ScheduleJobs(ctx context.Context, id value.ScheduleID) ([]dto.Job, error)
+	// DueSchedules возвращает включённые расписания, время срабатывания которых не позже now.
+	DueSchedules(ctx context.Context, now time.Time) ([]value.ScheduleID, error)
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type ScheduleRepository interface {
+	SaveSchedule(ctx context.Context, schedule *entity.Schedule) error
+	// UpdateSchedule изменяет расписание в транзакции.
+	UpdateSchedule(
+		ctx context.Context, id value.ScheduleID, updateFn func(ctx2 context.Context, s *entity.Schedule) error,
+	) error
+	// FireSchedule изменяет расписание в транзакции, блокируя его от одновременного срабатывания в других
+	// исполнителях. Задача, возвращённая fireFn, сохраняется в той же транзакции так же, как JobRepository.SaveJob.
+	// prev -- задача прошлого срабатывания, заблокированная в той же транзакции, либо nil, если её нет; её
+	// изменения сохраняются вместе с расписанием.
+	FireSchedule(
+		ctx context.Context,
+		id value.ScheduleID,
+		fireFn func(ctx2 context.Context, s *entity.Schedule, prev *entity.Job) (*entity.Job, error),
+	) error
+	DeleteSchedule(ctx context.Context, id value.ScheduleID) error
+}
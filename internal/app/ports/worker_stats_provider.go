@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+)
+
+type WorkerStatsProvider interface {
+	// WorkerStats возвращает загрузку обработчиков задач текущего экземпляра сервиса.
+	WorkerStats(ctx context.Context) (dto.WorkerStats, error)
+}
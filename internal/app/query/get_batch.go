@@ -0,0 +1,49 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetBatchHandler struct {
+	bp ports.BatchProvider
+	l  *slog.Logger
+}
+
+func NewGetBatchHandler(bp ports.BatchProvider, l *slog.Logger) GetBatchHandler {
+	return GetBatchHandler{bp, l}
+}
+
+func (h GetBatchHandler) Handle(ctx context.Context, req request.GetBatch) (response.GetBatch, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetBatch"),
+		slog.String("batch_id", req.BatchID),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying batch")
+	batch, err := h.bp.Batch(ctx, value.BatchID(req.BatchID))
+	if errors.Is(err, ports.ErrBatchNotFound) {
+		l.InfoContext(ctx, "batch not found", slog.String("error", err.Error()))
+		return response.GetBatch{}, err
+	}
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query batch", slog.String("error", err.Error()))
+		return response.GetBatch{}, err
+	}
+
+	if batch.OwnerID != req.ActorID {
+		l.InfoContext(ctx, "user does not own batch", slog.String("owner_id", batch.OwnerID))
+		return response.GetBatch{}, domain.ErrPermissionDenied
+	}
+	l.InfoContext(ctx, "got batch", slog.Int("size", batch.Size))
+
+	return batch, nil
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetBatchResultsHandler struct {
+	bp ports.BatchProvider
+	l  *slog.Logger
+}
+
+func NewGetBatchResultsHandler(bp ports.BatchProvider, l *slog.Logger) GetBatchResultsHandler {
+	return GetBatchResultsHandler{bp, l}
+}
+
+func (h GetBatchResultsHandler) Handle(
+	ctx context.Context, req request.GetBatchResults,
+) (response.GetBatchResults, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetBatchResults"),
+		slog.String("batch_id", req.BatchID),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying batch results")
+	batch, err := h.bp.Batch(ctx, value.BatchID(req.BatchID))
+	if errors.Is(err, ports.ErrBatchNotFound) {
+		l.InfoContext(ctx, "batch not found", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query batch", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if batch.OwnerID != req.ActorID {
+		l.InfoContext(ctx, "user does not own batch", slog.String("owner_id", batch.OwnerID))
+		return nil, domain.ErrPermissionDenied
+	}
+
+	jobs, err := h.bp.BatchJobs(ctx, value.BatchID(req.BatchID))
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query batch jobs", slog.String("error", err.Error()))
+		return nil, err
+	}
+	l.InfoContext(ctx, "got batch results", slog.Int("jobs", len(jobs)))
+
+	return jobs, nil
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetBuildLogHandler struct {
+	bp ports.BlueprintProvider
+	lp ports.BuildLogProvider
+	l  *slog.Logger
+}
+
+func NewGetBuildLogHandler(bp ports.BlueprintProvider, lp ports.BuildLogProvider, l *slog.Logger) GetBuildLogHandler {
+	return GetBuildLogHandler{bp, lp, l}
+}
+
+func (h GetBuildLogHandler) Handle(ctx context.Context, req request.GetBuildLog) (response.GetBuildLog, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetBuildLog"),
+		slog.String("blueprint_id", req.BlueprintID),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying build log")
+	blueprint, err := h.bp.BlueprintWithUser(ctx, value.BlueprintID(req.BlueprintID))
+	if err != nil {
+		if errors.Is(err, ports.ErrBlueprintNotFound) {
+			l.InfoContext(ctx, "blueprint not found")
+		} else {
+			l.ErrorContext(ctx, "failed to query blueprint", slog.String("error", err.Error()))
+		}
+		return response.GetBuildLog{}, err
+	}
+
+	// Лог сборки может содержать подробности устройства скрипта, поэтому доступен только автору.
+	if blueprint.OwnerID != req.ActorID {
+		l.InfoContext(ctx, "user does not own blueprint", slog.String("owner_id", blueprint.OwnerID))
+		return response.GetBuildLog{}, domain.ErrPermissionDenied
+	}
+
+	log, err := h.lp.BuildLog(ctx, value.BlueprintID(req.BlueprintID))
+	if errors.Is(err, ports.ErrBuildLogNotFound) {
+		// Сборка ещё не завершилась
+		log = ""
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to query build log", slog.String("error", err.Error()))
+		return response.GetBuildLog{}, err
+	}
+	l.InfoContext(ctx, "got build log", slog.String("build_status", blueprint.BuildStatus))
+
+	return response.GetBuildLog{
+		BuildStatus: blueprint.BuildStatus,
+		Log:         log,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetDeadLettersHandler struct {
+	up ports.UserProvider
+	dp ports.DeadLetterProvider
+	l  *slog.Logger
+}
+
+func NewGetDeadLettersHandler(
+	up ports.UserProvider, dp ports.DeadLetterProvider, l *slog.Logger,
+) GetDeadLettersHandler {
+	return GetDeadLettersHandler{up, dp, l}
+}
+
+func (h GetDeadLettersHandler) Handle(
+	ctx context.Context, req request.GetDeadLetters,
+) (response.GetDeadLetters, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetDeadLetters"),
+		slog.String("uid", req.ActorID),
+	)
+
+	actor, err := h.up.User(ctx, value.UserID(req.ActorID))
+	if errors.Is(err, ports.ErrUserNotFound) {
+		l.InfoContext(ctx, "user not found")
+		return nil, domain.ErrPermissionDenied
+	}
+	if err != nil {
+		l.InfoContext(ctx, "failed to query user", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if actor.Role() != value.RoleAdmin {
+		l.InfoContext(ctx, "user does not have permission to see dead letters")
+		return nil, domain.ErrPermissionDenied
+	}
+
+	dls, err := h.dp.DeadLetters(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query dead letters", slog.String("error", err.Error()))
+		return nil, err
+	}
+	l.InfoContext(ctx, "got dead letters", slog.Int("count", len(dls)))
+
+	return dls, nil
+}
@@ -0,0 +1,87 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetJobArtifactHandler struct {
+	jp ports.JobProvider
+	fr ports.FileReader
+	l  *slog.Logger
+}
+
+func NewGetJobArtifactHandler(jp ports.JobProvider, fr ports.FileReader, l *slog.Logger) GetJobArtifactHandler {
+	return GetJobArtifactHandler{jp, fr, l}
+}
+
+func (h GetJobArtifactHandler) Handle(
+	ctx context.Context, req request.GetJobArtifact,
+) (response.GetJobArtifact, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetJobArtifact"),
+		slog.String("job_id", req.JobID),
+		slog.String("name", req.Name),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying job artifact")
+	job, err := h.jp.Job(ctx, value.JobID(req.JobID))
+	if errors.Is(err, ports.ErrJobNotFound) {
+		l.InfoContext(ctx, "job not found", slog.String("error", err.Error()))
+		return response.GetJobArtifact{}, err
+	}
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query job", slog.String("error", err.Error()))
+		return response.GetJobArtifact{}, err
+	}
+
+	if job.OwnerID != req.ActorID {
+		l.InfoContext(ctx, "user does not own job", slog.String("owner_id", job.OwnerID))
+		return response.GetJobArtifact{}, domain.ErrPermissionDenied
+	}
+
+	id, ok := jobArtifact(job, req.Name)
+	if !ok {
+		l.InfoContext(ctx, "job artifact not found", slog.String("state", job.State))
+		return response.GetJobArtifact{}, fmt.Errorf("%w: %s", ports.ErrArtifactNotFound, req.Name)
+	}
+
+	rc, err := h.fr.Read(ctx, id)
+	if errors.Is(err, ports.ErrFileNotFound) {
+		l.WarnContext(ctx, "job artifact file not found", slog.String("file_id", string(id)))
+		return response.GetJobArtifact{}, fmt.Errorf("%w: %s", ports.ErrArtifactNotFound, req.Name)
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to read job artifact", slog.String("error", err.Error()))
+		return response.GetJobArtifact{}, err
+	}
+	l.InfoContext(ctx, "got job artifact", slog.String("file_id", string(id)))
+
+	return response.GetJobArtifact{
+		Name:    req.Name,
+		Content: rc,
+	}, nil
+}
+
+// jobArtifact возвращает ID файла выходного поля name типа file. Значения выходных полей есть только у
+// успешно завершённой задачи.
+func jobArtifact(job dto.Job, name string) (value.FileID, bool) {
+	if len(job.Output) != len(job.Out) {
+		return "", false
+	}
+	for i, f := range job.Out {
+		if f.Name == name && f.Type == value.FileValueType.String() {
+			return value.FileID(job.Output[i].Value), true
+		}
+	}
+	return "", false
+}
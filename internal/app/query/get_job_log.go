@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetJobLogHandler struct {
+	jp ports.JobProvider
+	lp ports.JobLogProvider
+	l  *slog.Logger
+}
+
+func NewGetJobLogHandler(jp ports.JobProvider, lp ports.JobLogProvider, l *slog.Logger) GetJobLogHandler {
+	return GetJobLogHandler{jp, lp, l}
+}
+
+func (h GetJobLogHandler) Handle(ctx context.Context, req request.GetJobLog) (response.GetJobLog, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetJobLog"),
+		slog.String("job_id", req.JobID),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying job log")
+	job, err := h.jp.Job(ctx, value.JobID(req.JobID))
+	if errors.Is(err, ports.ErrJobNotFound) {
+		l.InfoContext(ctx, "job not found", slog.String("error", err.Error()))
+		return response.GetJobLog{}, err
+	}
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query job", slog.String("error", err.Error()))
+		return response.GetJobLog{}, err
+	}
+
+	if job.OwnerID != req.ActorID {
+		l.InfoContext(ctx, "user does not own job", slog.String("owner_id", job.OwnerID))
+		return response.GetJobLog{}, domain.ErrPermissionDenied
+	}
+
+	log, err := h.lp.JobLog(ctx, value.JobID(req.JobID))
+	if errors.Is(err, ports.ErrJobLogNotFound) {
+		// Задача ещё не завершилась или завершилась до запуска контейнера
+		log = ""
+	} else if err != nil {
+		l.ErrorContext(ctx, "failed to query job log", slog.String("error", err.Error()))
+		return response.GetJobLog{}, err
+	}
+	l.InfoContext(ctx, "got job log", slog.String("state", job.State))
+
+	return response.GetJobLog{
+		State: job.State,
+		Log:   log,
+	}, nil
+}
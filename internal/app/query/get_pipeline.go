@@ -0,0 +1,50 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetPipelineHandler struct {
+	pp ports.PipelineProvider
+	l  *slog.Logger
+}
+
+func NewGetPipelineHandler(pp ports.PipelineProvider, l *slog.Logger) GetPipelineHandler {
+	return GetPipelineHandler{pp, l}
+}
+
+func (h GetPipelineHandler) Handle(ctx context.Context, req request.GetPipeline) (response.GetPipeline, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetPipeline"),
+		slog.String("pipeline_id", req.PipelineID),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying pipeline")
+	pipeline, err := h.pp.Pipeline(ctx, value.PipelineID(req.PipelineID))
+	if errors.Is(err, ports.ErrPipelineNotFound) {
+		l.InfoContext(ctx, "pipeline not found", slog.String("error", err.Error()))
+		return response.GetPipeline{}, err
+	}
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query pipeline", slog.String("error", err.Error()))
+		return response.GetPipeline{}, err
+	}
+
+	if pipeline.OwnerID() != value.UserID(req.ActorID) {
+		l.InfoContext(ctx, "user does not own pipeline", slog.String("owner_id", string(pipeline.OwnerID())))
+		return response.GetPipeline{}, domain.ErrPermissionDenied
+	}
+	l.InfoContext(ctx, "got pipeline", slog.Int("steps", len(pipeline.Steps())))
+
+	return dto.PipelineToDTO(pipeline), nil
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetPipelineRunHandler struct {
+	pp ports.PipelineProvider
+	l  *slog.Logger
+}
+
+func NewGetPipelineRunHandler(pp ports.PipelineProvider, l *slog.Logger) GetPipelineRunHandler {
+	return GetPipelineRunHandler{pp, l}
+}
+
+func (h GetPipelineRunHandler) Handle(
+	ctx context.Context, req request.GetPipelineRun,
+) (response.GetPipelineRun, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetPipelineRun"),
+		slog.String("run_id", req.RunID),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying pipeline run")
+	run, err := h.pp.PipelineRun(ctx, value.PipelineRunID(req.RunID))
+	if errors.Is(err, ports.ErrPipelineRunNotFound) {
+		l.InfoContext(ctx, "pipeline run not found", slog.String("error", err.Error()))
+		return response.GetPipelineRun{}, err
+	}
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query pipeline run", slog.String("error", err.Error()))
+		return response.GetPipelineRun{}, err
+	}
+
+	if run.OwnerID() != value.UserID(req.ActorID) {
+		l.InfoContext(ctx, "user does not own pipeline run", slog.String("owner_id", string(run.OwnerID())))
+		return response.GetPipelineRun{}, domain.ErrPermissionDenied
+	}
+	l.InfoContext(ctx, "got pipeline run", slog.String("state", run.State().String()))
+
+	return dto.PipelineRunToDTO(run), nil
+}
@@ -0,0 +1,58 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetPipelineRunsHandler struct {
+	pp ports.PipelineProvider
+	l  *slog.Logger
+}
+
+func NewGetPipelineRunsHandler(pp ports.PipelineProvider, l *slog.Logger) GetPipelineRunsHandler {
+	return GetPipelineRunsHandler{pp, l}
+}
+
+func (h GetPipelineRunsHandler) Handle(
+	ctx context.Context, req request.GetPipelineRuns,
+) (response.GetPipelineRuns, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetPipelineRuns"),
+		slog.String("pipeline_id", req.PipelineID),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying pipeline runs")
+	pipeline, err := h.pp.Pipeline(ctx, value.PipelineID(req.PipelineID))
+	if errors.Is(err, ports.ErrPipelineNotFound) {
+		l.InfoContext(ctx, "pipeline not found", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query pipeline", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if pipeline.OwnerID() != value.UserID(req.ActorID) {
+		l.InfoContext(ctx, "user does not own pipeline", slog.String("owner_id", string(pipeline.OwnerID())))
+		return nil, domain.ErrPermissionDenied
+	}
+
+	runs, err := h.pp.PipelineRuns(ctx, pipeline.ID())
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query pipeline runs", slog.String("error", err.Error()))
+		return nil, err
+	}
+	l.InfoContext(ctx, "got pipeline runs", slog.Int("runs", len(runs)))
+
+	return dto.PipelineRunsToDTOs(runs), nil
+}
@@ -0,0 +1,38 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetPipelinesHandler struct {
+	pp ports.PipelineProvider
+	l  *slog.Logger
+}
+
+func NewGetPipelinesHandler(pp ports.PipelineProvider, l *slog.Logger) GetPipelinesHandler {
+	return GetPipelinesHandler{pp, l}
+}
+
+func (h GetPipelinesHandler) Handle(ctx context.Context, req request.GetPipelines) (response.GetPipelines, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetPipelines"),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying pipelines")
+	pipelines, err := h.pp.UserPipelines(ctx, value.UserID(req.ActorID))
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query pipelines", slog.String("error", err.Error()))
+		return nil, err
+	}
+	l.InfoContext(ctx, "got pipelines", slog.Int("count", len(pipelines)))
+
+	return dto.PipelinesToDTOs(pipelines), nil
+}
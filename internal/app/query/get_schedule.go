@@ -0,0 +1,49 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetScheduleHandler struct {
+	sp ports.ScheduleProvider
+	l  *slog.Logger
+}
+
+func NewGetScheduleHandler(sp ports.ScheduleProvider, l *slog.Logger) GetScheduleHandler {
+	return GetScheduleHandler{sp, l}
+}
+
+func (h GetScheduleHandler) Handle(ctx context.Context, req request.GetSchedule) (response.GetSchedule, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetSchedule"),
+		slog.String("schedule_id", req.ScheduleID),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying schedule")
+	schedule, err := h.sp.Schedule(ctx, value.ScheduleID(req.ScheduleID))
+	if errors.Is(err, ports.ErrScheduleNotFound) {
+		l.InfoContext(ctx, "schedule not found", slog.String("error", err.Error()))
+		return response.GetSchedule{}, err
+	}
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query schedule", slog.String("error", err.Error()))
+		return response.GetSchedule{}, err
+	}
+
+	if schedule.OwnerID != req.ActorID {
+		l.InfoContext(ctx, "user does not own schedule", slog.String("owner_id", schedule.OwnerID))
+		return response.GetSchedule{}, domain.ErrPermissionDenied
+	}
+	l.InfoContext(ctx, "got schedule", slog.Bool("enabled", schedule.Enabled))
+
+	return schedule, nil
+}
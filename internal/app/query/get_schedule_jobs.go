@@ -0,0 +1,57 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetScheduleJobsHandler struct {
+	sp ports.ScheduleProvider
+	l  *slog.Logger
+}
+
+func NewGetScheduleJobsHandler(sp ports.ScheduleProvider, l *slog.Logger) GetScheduleJobsHandler {
+	return GetScheduleJobsHandler{sp, l}
+}
+
+func (h GetScheduleJobsHandler) Handle(
+	ctx context.Context, req request.GetScheduleJobs,
+) (response.GetScheduleJobs, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetScheduleJobs"),
+		slog.String("schedule_id", req.ScheduleID),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying schedule jobs")
+	schedule, err := h.sp.Schedule(ctx, value.ScheduleID(req.ScheduleID))
+	if errors.Is(err, ports.ErrScheduleNotFound) {
+		l.InfoContext(ctx, "schedule not found", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query schedule", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if schedule.OwnerID != req.ActorID {
+		l.InfoContext(ctx, "user does not own schedule", slog.String("owner_id", schedule.OwnerID))
+		return nil, domain.ErrPermissionDenied
+	}
+
+	jobs, err := h.sp.ScheduleJobs(ctx, value.ScheduleID(req.ScheduleID))
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query schedule jobs", slog.String("error", err.Error()))
+		return nil, err
+	}
+	l.InfoContext(ctx, "got schedule jobs", slog.Int("jobs", len(jobs)))
+
+	return jobs, nil
+}
@@ -0,0 +1,37 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetSchedulesHandler struct {
+	sp ports.ScheduleProvider
+	l  *slog.Logger
+}
+
+func NewGetSchedulesHandler(sp ports.ScheduleProvider, l *slog.Logger) GetSchedulesHandler {
+	return GetSchedulesHandler{sp, l}
+}
+
+func (h GetSchedulesHandler) Handle(ctx context.Context, req request.GetSchedules) (response.GetSchedules, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetSchedules"),
+		slog.String("uid", req.ActorID),
+	)
+
+	l.DebugContext(ctx, "querying schedules")
+	schedules, err := h.sp.UserSchedules(ctx, value.UserID(req.ActorID))
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query schedules", slog.String("error", err.Error()))
+		return nil, err
+	}
+	l.InfoContext(ctx, "got schedules", slog.Int("count", len(schedules)))
+
+	return schedules, nil
+}
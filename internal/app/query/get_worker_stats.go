@@ -0,0 +1,60 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type GetWorkerStatsHandler struct {
+	up ports.UserProvider
+	wp ports.WorkerStatsProvider
+	l  *slog.Logger
+}
+
+func NewGetWorkerStatsHandler(
+	up ports.UserProvider, wp ports.WorkerStatsProvider, l *slog.Logger,
+) GetWorkerStatsHandler {
+	return GetWorkerStatsHandler{up, wp, l}
+}
+
+func (h GetWorkerStatsHandler) Handle(ctx context.Context, req request.GetWorkerStats) (dto.WorkerStats, error) {
+	l := h.l.With(
+		slog.String("op", "app.GetWorkerStats"),
+		slog.String("uid", req.ActorID),
+	)
+
+	actor, err := h.up.User(ctx, value.UserID(req.ActorID))
+	if errors.Is(err, ports.ErrUserNotFound) {
+		l.InfoContext(ctx, "user not found")
+		return dto.WorkerStats{}, domain.ErrPermissionDenied
+	}
+	if err != nil {
+		l.InfoContext(ctx, "failed to query user", slog.String("error", err.Error()))
+		return dto.WorkerStats{}, err
+	}
+
+	if actor.Role() != value.RoleAdmin {
+		l.InfoContext(ctx, "user does not have permission to see worker stats")
+		return dto.WorkerStats{}, domain.ErrPermissionDenied
+	}
+
+	stats, err := h.wp.WorkerStats(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, "failed to query worker stats", slog.String("error", err.Error()))
+		return dto.WorkerStats{}, err
+	}
+	l.InfoContext(
+		ctx, "got worker stats",
+		slog.Int("busy_workers", stats.BusyWorkers),
+		slog.Int("queue_depth", stats.QueueDepth),
+	)
+
+	return stats, nil
+}
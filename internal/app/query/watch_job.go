@@ -0,0 +1,87 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/response"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+type WatchJobHandler struct {
+	jp ports.JobProvider
+	es ports.JobEventSubscriber
+	l  *slog.Logger
+}
+
+func NewWatchJobHandler(jp ports.JobProvider, es ports.JobEventSubscriber, l *slog.Logger) WatchJobHandler {
+	return WatchJobHandler{jp, es, l}
+}
+
+func (h WatchJobHandler) Handle(ctx context.Context, req request.WatchJob) (response.WatchJob, error) {
+	l := h.l.With(
+		slog.String("op", "app.WatchJob"),
+		slog.String("job_id", req.JobID),
+		slog.String("uid", req.ActorID),
+	)
+
+	// Подписка оформляется до чтения задачи, чтобы не пропустить события между чтением и подпиской.
+	subCtx, cancel := context.WithCancel(ctx)
+	events, err := h.es.SubscribeJobEvents(subCtx, value.JobID(req.JobID))
+	if err != nil {
+		cancel()
+		l.ErrorContext(ctx, "failed to subscribe to job events", slog.String("error", err.Error()))
+		return response.WatchJob{}, err
+	}
+
+	l.DebugContext(ctx, "querying job")
+	job, err := h.jp.Job(ctx, value.JobID(req.JobID))
+	if errors.Is(err, ports.ErrJobNotFound) {
+		cancel()
+		l.InfoContext(ctx, "job not found", slog.String("error", err.Error()))
+		return response.WatchJob{}, err
+	}
+	if err != nil {
+		cancel()
+		l.ErrorContext(ctx, "failed to query job", slog.String("error", err.Error()))
+		return response.WatchJob{}, err
+	}
+
+	if job.OwnerID != req.ActorID {
+		cancel()
+		l.InfoContext(ctx, "user does not own job", slog.String("owner_id", job.OwnerID))
+		return response.WatchJob{}, domain.ErrPermissionDenied
+	}
+	l.InfoContext(ctx, "watching job", slog.String("state", job.State))
+
+	out := make(chan dto.JobEvent)
+	go func() {
+		defer close(out)
+		defer cancel()
+		if isTerminalState(job.State) {
+			return
+		}
+		for e := range events {
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+			if e.Kind == dto.JobEventState && isTerminalState(e.State) {
+				return
+			}
+		}
+	}()
+
+	return response.WatchJob{Job: job, Events: out}, nil
+}
+
+func isTerminalState(s string) bool {
+	state, err := value.JobStateFromString(s)
+	return err == nil && state.IsTerminal()
+}
@@ -13,13 +13,37 @@ type Config struct {
 	HTTP     HTTP     `mapstructure:"http"`
 	Logging  Logging  `mapstructure:"logging"`
 	Postgres Postgres `mapstructure:"postgres"`
+	Queue    Queue    `mapstructure:"queue"`
 	Storage  Storage  `mapstructure:"storage"`
 	JWT      JWT      `mapstructure:"jwt"`
+	Worker   Worker   `mapstructure:"worker"`
 }
 
 type Docker struct {
-	ImagePrefix   string        `mapstructure:"image_prefix"`
-	RunnerTimeout time.Duration `mapstructure:"runner_timeout"`
+	ImagePrefix   string         `mapstructure:"image_prefix"`
+	RunnerTimeout time.Duration  `mapstructure:"runner_timeout"` // Если Blueprint не задаёт собственный
+	MaxTimeout    time.Duration  `mapstructure:"max_timeout"`    // Нулевое значение -- без ограничения
+	Limits        DockerLimits   `mapstructure:"limits"`
+	Security      DockerSecurity `mapstructure:"security"`
+}
+
+// DockerLimits -- глобальные ограничения ресурсов контейнера, которые не могут быть превышены ограничениями
+// Blueprint. Также применяются, если Blueprint не задаёт собственное ограничение. Нулевое значение -- без ограничения.
+type DockerLimits struct {
+	MemoryMB int64   `mapstructure:"memory_mb"`
+	CPUs     float64 `mapstructure:"cpus"`
+	Pids     int64   `mapstructure:"pids"`
+	TmpfsMB  int64   `mapstructure:"tmpfs_mb"`
+}
+
+// DockerSecurity -- профиль безопасности контейнеров задач. Контейнер запускается без сети, если только
+// Blueprint не запросил доступ к ней и администратор не разрешил такие запросы через AllowNetwork.
+type DockerSecurity struct {
+	AllowNetwork     bool   `mapstructure:"allow_network"`
+	ReadOnlyRootfs   bool   `mapstructure:"read_only_rootfs"` // Запись возможна только в tmpfs /tmp
+	User             string `mapstructure:"user"`
+	DropCapabilities bool   `mapstructure:"drop_capabilities"`
+	NoNewPrivileges  bool   `mapstructure:"no_new_privileges"`
 }
 
 type HTTP struct {
@@ -35,6 +59,35 @@ type Postgres struct {
 	URI string `mapstructure:"uri"`
 }
 
+const (
+	QueueDriverPostgres  = "postgres"
+	QueueDriverGoChannel = "gochannel"
+)
+
+// Queue -- очередь задач на выполнение. Очередь gochannel хранится в памяти и теряется при перезапуске.
+type Queue struct {
+	Driver       string        `mapstructure:"driver"`        // postgres или gochannel
+	PollInterval time.Duration `mapstructure:"poll_interval"` // Только для postgres
+	LeaseTimeout time.Duration `mapstructure:"lease_timeout"` // Только для postgres; должен превышать max_timeout
+
+	// RelayInterval -- период, с которым сохранённые задачи переносятся из outbox в очередь.
+	RelayInterval time.Duration `mapstructure:"relay_interval"`
+
+	// Сбой обработки задачи (но не сбой самого скрипта) повторяется до MaxRetries раз с удваивающейся
+	// от RetryBackoff задержкой, после чего задача попадает в очередь недоставленных (dead letters).
+	MaxRetries   int           `mapstructure:"max_retries"`
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+
+	// MaxConcurrentJobs ограничивает число задач, одновременно выполняемых экземпляром сервиса. Остальные
+	// задачи ожидают в очереди в состоянии pending.
+	MaxConcurrentJobs int `mapstructure:"max_concurrent_jobs"`
+}
+
+// RetryDelay возвращает задержку перед повторной попыткой attempt (начиная с 1).
+func (q Queue) RetryDelay(attempt int) time.Duration {
+	return q.RetryBackoff << (attempt - 1)
+}
+
 type Storage struct {
 	BasePath string `mapstructure:"base_path"`
 }
@@ -44,6 +97,27 @@ type JWT struct {
 	AccessTTL time.Duration `mapstructure:"access_ttl"`
 }
 
+// Worker -- исполнитель задач и сборок образов.
+type Worker struct {
+	// Embedded -- выполнять задачи и сборки в процессе HTTP-сервера, которому тогда нужен доступ к Docker.
+	// Иначе их выполняет отдельный процесс cmd/worker, что возможно только с очередью postgres.
+	Embedded bool `mapstructure:"embedded"`
+
+	// Исполнитель обновляет heartbeat выполняемых задач каждые HeartbeatInterval. Задача без heartbeat дольше
+	// HeartbeatTimeout считается прерванной. Такие задачи ищутся при запуске исполнителя и далее каждые
+	// ReconcileInterval.
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	HeartbeatTimeout  time.Duration `mapstructure:"heartbeat_timeout"`
+	ReconcileInterval time.Duration `mapstructure:"reconcile_interval"`
+
+	// ScheduleInterval -- как часто исполнитель проверяет, каким расписаниям пора сработать.
+	ScheduleInterval time.Duration `mapstructure:"schedule_interval"`
+
+	// PipelineInterval -- как часто исполнитель продвигает незавершённые запуски конвейеров: следующий шаг
+	// запускается не позже чем через PipelineInterval после завершения шагов, от которых он зависит.
+	PipelineInterval time.Duration `mapstructure:"pipeline_interval"`
+}
+
 func Load(path string) (*Config, error) {
 	// Нетривиальный момент Viper, не описанный в документации, но описанный в
 	// 	https://github.com/spf13/viper/issues/1797
@@ -61,6 +135,26 @@ func Load(path string) (*Config, error) {
 	v.SetEnvPrefix("SC")
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	// Профиль безопасности включён, если не отключён в конфиге явно.
+	v.SetDefault("docker.security.read_only_rootfs", true)
+	v.SetDefault("docker.security.user", "65534:65534")
+	v.SetDefault("docker.security.drop_capabilities", true)
+	v.SetDefault("docker.security.no_new_privileges", true)
+
+	v.SetDefault("queue.driver", QueueDriverPostgres)
+	v.SetDefault("queue.poll_interval", time.Second)
+	v.SetDefault("queue.lease_timeout", 3*time.Hour)
+	v.SetDefault("queue.relay_interval", time.Second)
+	v.SetDefault("queue.max_retries", 3)
+	v.SetDefault("queue.retry_backoff", 5*time.Second)
+	v.SetDefault("queue.max_concurrent_jobs", 4)
+	v.SetDefault("worker.embedded", true)
+	v.SetDefault("worker.heartbeat_interval", 10*time.Second)
+	v.SetDefault("worker.heartbeat_timeout", time.Minute)
+	v.SetDefault("worker.reconcile_interval", time.Minute)
+	v.SetDefault("worker.schedule_interval", 15*time.Second)
+	v.SetDefault("worker.pipeline_interval", 5*time.Second)
+
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config '%s': %w", path, err)
 	}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// Batch -- пакет задач одного Blueprint, запущенных одним запросом с разными входными данными.
+// Состояние пакета складывается из состояний его задач.
+type Batch struct {
+	id          value.BatchID
+	blueprintID value.BlueprintID
+	ownerID     value.UserID
+	size        int
+	createdAt   time.Time
+}
+
+func (b *Batch) ID() value.BatchID {
+	return b.id
+}
+
+func (b *Batch) BlueprintID() value.BlueprintID {
+	return b.blueprintID
+}
+
+func (b *Batch) OwnerID() value.UserID {
+	return b.ownerID
+}
+
+// Size возвращает число задач пакета.
+func (b *Batch) Size() int {
+	return b.size
+}
+
+func (b *Batch) CreatedAt() time.Time {
+	return b.createdAt
+}
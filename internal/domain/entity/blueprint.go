@@ -3,22 +3,31 @@ package entity
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bmstu-itstech/scriptum-back/internal/domain"
 	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
 )
 
+var ErrInvalidBuildStatusChange = errors.New("invalid build status change")
+
 type Blueprint struct {
-	id        value.BlueprintID
-	ownerID   value.UserID
-	archiveID value.FileID
-	name      string
-	desc      *string
-	vis       value.Visibility
-	in        []value.Field
-	out       []value.Field
-	createdAt time.Time
+	id          value.BlueprintID
+	ownerID     value.UserID
+	archiveID   value.FileID
+	name        string
+	desc        *string
+	vis         value.Visibility
+	in          []value.Field
+	out         []value.Field
+	limits      value.ResourceLimits
+	network     bool
+	timeout     time.Duration
+	retryPolicy value.RetryPolicy
+	createdAt   time.Time
+	buildStatus value.BuildStatus
+	image       value.ImageTag
 }
 
 func NewBlueprint(
@@ -29,6 +38,10 @@ func NewBlueprint(
 	vis value.Visibility,
 	in []value.Field,
 	out []value.Field,
+	limits value.ResourceLimits,
+	network bool,
+	timeout time.Duration,
+	retryPolicy value.RetryPolicy,
 ) (*Blueprint, error) {
 	if ownerID == "" {
 		return nil, errors.New("zero ownerID")
@@ -50,6 +63,12 @@ func NewBlueprint(
 		return nil, errors.New("zero visibility")
 	}
 
+	if timeout < 0 {
+		return nil, domain.NewInvalidInputError(
+			"blueprint-negative-timeout", fmt.Sprintf("expected non-negative timeout, got %s", timeout),
+		)
+	}
+
 	if in == nil {
 		in = make([]value.Field, 0)
 	}
@@ -58,42 +77,240 @@ func NewBlueprint(
 		out = make([]value.Field, 0)
 	}
 
+	// Значения входных полей передаются по порядку, поэтому опустить можно только последние поля. Необязательное
+	// поле перед обязательным опустить было бы невозможно.
+	for i := 1; i < len(in); i++ {
+		if in[i-1].IsOptional() && !in[i].IsOptional() {
+			return nil, domain.NewInvalidFieldError(
+				in[i-1].Name(), "blueprint-optional-before-required",
+				fmt.Sprintf("optional input field %d must not precede required field '%s'", i-1, in[i].Name()),
+			)
+		}
+	}
+
+	artifacts := make(map[string]struct{})
+	for _, f := range out {
+		if !f.Constraints().IsZero() {
+			return nil, domain.NewInvalidFieldError(
+				f.Name(), "blueprint-output-constraints", "constraints are supported for input fields only",
+			)
+		}
+		if f.IsOptional() {
+			return nil, domain.NewInvalidFieldError(
+				f.Name(), "blueprint-output-default", "default values are supported for input fields only",
+			)
+		}
+		if f.Type() == value.FileValueType && !isArtifactName(f.Name()) {
+			// Файл выходного поля скрипт записывает в /out/<имя поля>.
+			return nil, domain.NewInvalidFieldError(
+				f.Name(), "blueprint-artifact-name-invalid",
+				fmt.Sprintf("file output field name must be a plain file name, got '%s'", f.Name()),
+			)
+		}
+		if f.Type() == value.FileValueType {
+			if _, ok := artifacts[f.Name()]; ok {
+				return nil, domain.NewInvalidFieldError(
+					f.Name(), "blueprint-artifact-duplicate",
+					fmt.Sprintf("file output field names must be unique, got duplicate '%s'", f.Name()),
+				)
+			}
+			artifacts[f.Name()] = struct{}{}
+		}
+	}
+
 	id := value.NewBlueprintID()
 	return &Blueprint{
-		id:        id,
-		ownerID:   ownerID,
-		archiveID: archiveID,
-		name:      name,
-		desc:      desc,
-		vis:       vis,
-		in:        in,
-		out:       out,
-		createdAt: time.Now(),
+		id:          id,
+		ownerID:     ownerID,
+		archiveID:   archiveID,
+		name:        name,
+		desc:        desc,
+		vis:         vis,
+		in:          in,
+		out:         out,
+		limits:      limits,
+		network:     network,
+		timeout:     timeout,
+		retryPolicy: retryPolicy,
+		createdAt:   time.Now(),
+		buildStatus: value.BuildQueued,
 	}, nil
 }
 
-func (b *Blueprint) AssembleJob(uid value.UserID, input []value.Value) (*Job, error) {
-	if len(input) != len(b.in) {
-		return nil, domain.NewInvalidInputError(
-			"assemble-values-mismatch",
-			fmt.Sprintf("failed to assemble job: expected %d values, got %d", len(b.in), len(input)),
+// StartBuild переводит Blueprint в состояние сборки образа. Повторный запуск сборки в состоянии BuildBuilding
+// допустим: предыдущая сборка могла быть прервана остановкой сервиса.
+func (b *Blueprint) StartBuild() error {
+	if b.buildStatus != value.BuildQueued && b.buildStatus != value.BuildBuilding {
+		return fmt.Errorf(
+			"%w: expected BuildQueued -> BuildBuilding, got %s -> BuildBuilding",
+			ErrInvalidBuildStatusChange, b.buildStatus.String(),
 		)
 	}
+	b.buildStatus = value.BuildBuilding
+	return nil
+}
 
-	for i, field := range b.in {
-		v := input[i]
-		if err := field.Validate(v); err != nil {
-			return nil, domain.NewInvalidInputError(
-				"assemble-value-validation-error",
-				fmt.Sprintf("failed to assemble job: field %d: %s", i, err.Error()),
+func (b *Blueprint) CompleteBuild(image value.ImageTag) error {
+	if b.buildStatus != value.BuildBuilding {
+		return fmt.Errorf(
+			"%w: expected BuildBuilding -> BuildReady, got %s -> BuildReady",
+			ErrInvalidBuildStatusChange, b.buildStatus.String(),
+		)
+	}
+	if image == "" {
+		return errors.New("empty image")
+	}
+	b.buildStatus = value.BuildReady
+	b.image = image
+	return nil
+}
+
+func (b *Blueprint) FailBuild() error {
+	if b.buildStatus != value.BuildBuilding {
+		return fmt.Errorf(
+			"%w: expected BuildBuilding -> BuildFailed, got %s -> BuildFailed",
+			ErrInvalidBuildStatusChange, b.buildStatus.String(),
+		)
+	}
+	b.buildStatus = value.BuildFailed
+	return nil
+}
+
+// AssembleRerun собирает задачу повторного запуска задачи parentID с входными данными input.
+func (b *Blueprint) AssembleRerun(uid value.UserID, parentID value.JobID, input []value.Value) (*Job, error) {
+	job, err := b.AssembleJob(uid, input)
+	if err != nil {
+		return nil, err
+	}
+	job.parentID = &parentID
+	return job, nil
+}
+
+// checkBuilt проверяет, что образ Blueprint собран и по нему можно запускать задачи.
+func (b *Blueprint) checkBuilt() error {
+	switch b.buildStatus {
+	case value.BuildReady:
+		return nil
+	case value.BuildFailed:
+		return domain.NewInvalidInputError(
+			"assemble-blueprint-build-failed",
+			"failed to assemble job: blueprint image build failed",
+		)
+	}
+	return domain.NewInvalidInputError(
+		"assemble-blueprint-not-built",
+		fmt.Sprintf("failed to assemble job: blueprint image is not built yet, build status is %s", b.buildStatus),
+	)
+}
+
+// AssembleBatch собирает пакет задач, по одной на каждый набор входных данных inputs.
+func (b *Blueprint) AssembleBatch(uid value.UserID, inputs [][]value.Value) (*Batch, []*Job, error) {
+	if len(inputs) == 0 {
+		return nil, nil, domain.NewInvalidInputError(
+			"assemble-batch-empty", "failed to assemble batch: expected at least one value set",
+		)
+	}
+	if len(inputs) > value.MaxBatchSize {
+		return nil, nil, domain.NewInvalidInputError(
+			"assemble-batch-too-large",
+			fmt.Sprintf(
+				"failed to assemble batch: expected at most %d value sets, got %d", value.MaxBatchSize, len(inputs),
+			),
+		)
+	}
+
+	if err := b.checkBuilt(); err != nil {
+		return nil, nil, err
+	}
+
+	batch := &Batch{
+		id:          value.NewBatchID(),
+		blueprintID: b.id,
+		ownerID:     uid,
+		size:        len(inputs),
+		createdAt:   time.Now(),
+	}
+	jobs := make([]*Job, len(inputs))
+	for i, input := range inputs {
+		job, err := b.AssembleJob(uid, input)
+		var iiErr domain.InvalidInputError
+		if errors.As(err, &iiErr) {
+			return nil, nil, domain.NewInvalidFieldError(
+				iiErr.Field, iiErr.Code, fmt.Sprintf("value set %d: %s", i, iiErr.Message),
 			)
+		} else if err != nil {
+			return nil, nil, err
 		}
+		job.batchID = &batch.id
+		jobs[i] = job
+	}
+	return batch, jobs, nil
+}
+
+// AssembleSchedule собирает включённое либо выключенное расписание задач с входными данными input.
+func (b *Blueprint) AssembleSchedule(
+	uid value.UserID,
+	input []value.Value,
+	cron value.CronExpr,
+	location *time.Location,
+	overlap value.OverlapPolicy,
+	enabled bool,
+) (*Schedule, error) {
+	s := &Schedule{
+		id:          value.NewScheduleID(),
+		blueprintID: b.id,
+		ownerID:     uid,
+		createdAt:   time.Now(),
+	}
+	if err := s.SetInput(b, input); err != nil {
+		return nil, err
+	}
+	if err := s.SetOverlapPolicy(overlap); err != nil {
+		return nil, err
+	}
+	if err := s.Reschedule(cron, location); err != nil {
+		return nil, err
+	}
+	if enabled {
+		s.Enable()
+	}
+	return s, nil
+}
+
+// AssembleScheduledJob собирает задачу срабатывания расписания s и переносит его следующее срабатывание.
+func (b *Blueprint) AssembleScheduledJob(s *Schedule, now time.Time) (*Job, error) {
+	if s.blueprintID != b.id {
+		return nil, fmt.Errorf("expected blueprint %s, got %s", s.blueprintID, b.id)
+	}
+	job, err := b.AssembleJob(s.ownerID, s.input)
+	if err != nil {
+		return nil, err
+	}
+	job.scheduleID = &s.id
+	s.lastJobID = &job.id
+	s.advance(now)
+	return job, nil
+}
+
+func (b *Blueprint) AssembleJob(uid value.UserID, input []value.Value) (*Job, error) {
+	if err := b.checkBuilt(); err != nil {
+		return nil, err
+	}
+
+	input, err := b.completeInput(input)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Job{
 		id:          value.NewJobID(),
 		blueprintID: b.id,
 		archiveID:   b.archiveID,
+		image:       b.image,
+		limits:      b.limits,
+		network:     b.network,
+		timeout:     b.timeout,
+		retryPolicy: b.retryPolicy,
 		ownerID:     uid, // Владельцем job не обязательно является владелец скрипта
 		state:       value.JobPending,
 		input:       input,
@@ -102,6 +319,58 @@ func (b *Blueprint) AssembleJob(uid value.UserID, input []value.Value) (*Job, er
 	}, nil
 }
 
+// isArtifactName сообщает, что имя выходного поля типа file можно использовать как имя файла в каталоге /out.
+func isArtifactName(name string) bool {
+	return name != "." && name != ".." && !strings.ContainsAny(name, "/\x00")
+}
+
+// completeInput дополняет входные данные задачи значениями по умолчанию опущенных последних полей и
+// проверяет их по входным полям Blueprint.
+func (b *Blueprint) completeInput(input []value.Value) ([]value.Value, error) {
+	if len(input) > len(b.in) {
+		return nil, domain.NewInvalidInputError(
+			"assemble-values-mismatch",
+			fmt.Sprintf("failed to assemble job: expected at most %d values, got %d", len(b.in), len(input)),
+		)
+	}
+	if err := b.checkOmitted(len(input)); err != nil {
+		return nil, err
+	}
+
+	res := make([]value.Value, len(b.in))
+	copy(res, input)
+	for i, field := range b.in {
+		if i >= len(input) {
+			res[i] = *field.Default()
+			continue
+		}
+		if err := field.Validate(res[i]); err != nil {
+			var iiErr domain.InvalidInputError
+			if !errors.As(err, &iiErr) {
+				return nil, err
+			}
+			return nil, domain.NewInvalidFieldError(
+				iiErr.Field, iiErr.Code, fmt.Sprintf("failed to assemble job: field %d: %s", i, iiErr.Message),
+			)
+		}
+	}
+	return res, nil
+}
+
+// checkOmitted проверяет, что входные поля, начиная с n-го, необязательны и их значения можно опустить.
+func (b *Blueprint) checkOmitted(n int) error {
+	for i := n; i < len(b.in); i++ {
+		if !b.in[i].IsOptional() {
+			return domain.NewInvalidFieldError(
+				b.in[i].Name(),
+				"assemble-value-missing",
+				fmt.Sprintf("failed to assemble job: field %d: value of required field is missing", i),
+			)
+		}
+	}
+	return nil
+}
+
 func (b *Blueprint) IsAvailableFor(uid value.UserID) bool {
 	if b.vis == value.VisibilityPublic {
 		return true
@@ -141,10 +410,38 @@ func (b *Blueprint) Out() []value.Field {
 	return b.out
 }
 
+func (b *Blueprint) Limits() value.ResourceLimits {
+	return b.limits
+}
+
+// Network сообщает, запрашивает ли Blueprint доступ к сети для своих задач.
+func (b *Blueprint) Network() bool {
+	return b.network
+}
+
+// Timeout возвращает ограничение времени выполнения задач; нулевое значение -- ограничение по умолчанию.
+func (b *Blueprint) Timeout() time.Duration {
+	return b.timeout
+}
+
+// RetryPolicy возвращает политику повторного запуска неуспешно завершившихся задач.
+func (b *Blueprint) RetryPolicy() value.RetryPolicy {
+	return b.retryPolicy
+}
+
 func (b *Blueprint) CreatedAt() time.Time {
 	return b.createdAt
 }
 
+func (b *Blueprint) BuildStatus() value.BuildStatus {
+	return b.buildStatus
+}
+
+// Image возвращает тег собранного образа; пустой, пока сборка не завершилась успешно.
+func (b *Blueprint) Image() value.ImageTag {
+	return b.image
+}
+
 func RestoreBlueprint(
 	id value.BlueprintID,
 	ownerID value.UserID,
@@ -154,7 +451,13 @@ func RestoreBlueprint(
 	vis value.Visibility,
 	in []value.Field,
 	out []value.Field,
+	limits value.ResourceLimits,
+	network bool,
+	timeout time.Duration,
+	retryPolicy value.RetryPolicy,
 	createdAt time.Time,
+	buildStatus value.BuildStatus,
+	image value.ImageTag,
 ) (*Blueprint, error) {
 	if id == "" {
 		return nil, errors.New("empty blueprintID")
@@ -176,6 +479,14 @@ func RestoreBlueprint(
 		return nil, errors.New("zero visibility")
 	}
 
+	if buildStatus.IsZero() {
+		return nil, errors.New("zero build status")
+	}
+
+	if buildStatus == value.BuildReady && image == "" {
+		return nil, errors.New("empty image for ready blueprint")
+	}
+
 	if in == nil {
 		in = make([]value.Field, 0)
 	}
@@ -185,14 +496,20 @@ func RestoreBlueprint(
 	}
 
 	return &Blueprint{
-		id:        id,
-		ownerID:   ownerID,
-		archiveID: archiveID,
-		name:      name,
-		desc:      desc,
-		vis:       vis,
-		in:        in,
-		out:       out,
-		createdAt: createdAt,
+		id:          id,
+		ownerID:     ownerID,
+		archiveID:   archiveID,
+		name:        name,
+		desc:        desc,
+		vis:         vis,
+		in:          in,
+		out:         out,
+		limits:      limits,
+		network:     network,
+		timeout:     timeout,
+		retryPolicy: retryPolicy,
+		createdAt:   createdAt,
+		buildStatus: buildStatus,
+		image:       image,
 	}, nil
 }
@@ -0,0 +1,91 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func newBlueprint(t *testing.T, in []value.Field, out []value.Field) (*entity.Blueprint, error) {
+	t.Helper()
+	return entity.NewBlueprint(
+		value.NewUserID(), value.NewFileID(), "blueprint", nil, value.VisibilityPrivate, in, out,
+		value.ResourceLimits{}, false, 0, value.RetryPolicy{},
+	)
+}
+
+// mustBuildBlueprint возвращает Blueprint с собранным образом, по которому можно собирать задачи.
+func mustBuildBlueprint(t *testing.T, in []value.Field, out []value.Field) *entity.Blueprint {
+	t.Helper()
+	b, err := newBlueprint(t, in, out)
+	require.NoError(t, err)
+	require.NoError(t, b.StartBuild())
+	require.NoError(t, b.CompleteBuild(value.NewImageTag("sc-test", b.ID())))
+	return b
+}
+
+func mustNewField(t *testing.T, name string, def *string) value.Field {
+	t.Helper()
+	f, err := value.NewField(value.IntegerValueType, name, nil, nil, nil, value.FieldConstraints{}, def)
+	require.NoError(t, err)
+	return f
+}
+
+func TestNewBlueprint_OptionalInputFields(t *testing.T) {
+	def := "1"
+	required := func(name string) value.Field { return mustNewField(t, name, nil) }
+	optional := func(name string) value.Field { return mustNewField(t, name, &def) }
+
+	tests := []struct {
+		name  string
+		in    []value.Field
+		field string
+	}{
+		{name: "required fields only", in: []value.Field{required("a"), required("b")}},
+		{name: "optional fields only", in: []value.Field{optional("a"), optional("b")}},
+		{name: "optional fields after required", in: []value.Field{required("a"), optional("b"), optional("c")}},
+		{name: "optional field before required", in: []value.Field{optional("a"), required("b")}, field: "a"},
+		{
+			name:  "optional field between required",
+			in:    []value.Field{required("a"), optional("b"), required("c")},
+			field: "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newBlueprint(t, tt.in, nil)
+			if tt.field == "" {
+				require.NoError(t, err)
+				return
+			}
+			var iiErr domain.InvalidInputError
+			require.True(t, errors.As(err, &iiErr))
+			require.Equal(t, "blueprint-optional-before-required", iiErr.Code)
+			require.Equal(t, tt.field, iiErr.Field)
+		})
+	}
+}
+
+func TestBlueprint_AssembleJob_OmittedInput(t *testing.T) {
+	def := "7"
+	b := mustBuildBlueprint(t, []value.Field{mustNewField(t, "a", nil), mustNewField(t, "b", &def)}, nil)
+
+	t.Run("trailing optional value is filled with default", func(t *testing.T) {
+		j, err := b.AssembleJob(b.OwnerID(), []value.Value{value.MustNewIntegerValue("3")})
+		require.NoError(t, err)
+		require.Equal(t, []value.Value{value.MustNewIntegerValue("3"), value.MustNewIntegerValue("7")}, j.Input())
+	})
+
+	t.Run("missing required value is rejected", func(t *testing.T) {
+		_, err := b.AssembleJob(b.OwnerID(), nil)
+		var iiErr domain.InvalidInputError
+		require.True(t, errors.As(err, &iiErr))
+		require.Equal(t, "assemble-value-missing", iiErr.Code)
+		require.Equal(t, "a", iiErr.Field)
+	})
+}
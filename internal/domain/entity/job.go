@@ -6,28 +6,43 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
 	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
 )
 
 var ErrInvalidJobStateChange = errors.New("invalid job state change")
 var ErrJobResultParseFailed = errors.New("job result parse failed")
+var ErrJobCancelled = errors.New("job cancelled")
 
 type Job struct {
 	id          value.JobID
+	parentID    *value.JobID
+	batchID     *value.BatchID
+	scheduleID  *value.ScheduleID
 	blueprintID value.BlueprintID
 	archiveID   value.FileID
+	image       value.ImageTag
+	limits      value.ResourceLimits
+	network     bool
+	timeout     time.Duration
+	retryPolicy value.RetryPolicy
 	ownerID     value.UserID
 	state       value.JobState
 	input       []value.Value
 	out         []value.Field
 	createdAt   time.Time
 
-	startedAt  *time.Time
-	result     *value.JobResult
-	finishedAt *time.Time
+	attempts      []value.JobAttempt
+	nextAttemptAt *time.Time
+	startedAt     *time.Time
+	result        *value.JobResult
+	finishedAt    *time.Time
 }
 
 func (j *Job) Run() error {
+	if j.state == value.JobCancelled {
+		return ErrJobCancelled
+	}
 	if j.state != value.JobPending {
 		return fmt.Errorf(
 			"%w: expected JobPending -> JobRunning, got %s -> JobRunning", ErrInvalidJobStateChange, j.state.String(),
@@ -36,54 +51,173 @@ func (j *Job) Run() error {
 	j.state = value.JobRunning
 	now := time.Now()
 	j.startedAt = &now
+	j.nextAttemptAt = nil
 	return nil
 }
 
 func (j *Job) Finish(res value.Result) error {
+	if j.state == value.JobCancelled {
+		return ErrJobCancelled
+	}
 	if j.state != value.JobRunning {
 		return fmt.Errorf(
 			"%w: expected JobRunning -> JobFinished, got %s -> JobFinished", ErrInvalidJobStateChange, j.state.String(),
 		)
 	}
 	if res.Code().IsSuccess() {
-		out, err := j.parseOutput(res.Output())
+		out, err := j.parseOutput(res)
 		if err != nil {
 			return err
 		}
-		jRes := value.NewSuccessJobResult(out)
-		j.result = &jRes
+		j.complete(value.NewSuccessJobResult(out))
 	} else {
-		jRes := value.NewFailureJobResult(res.Code(), res.Output())
-		j.result = &jRes
+		// Скрипт сообщает об ошибке в stderr; stdout используется, только если лог пуст.
+		msg := res.Log()
+		if msg == "" {
+			msg = res.Output()
+		}
+		j.complete(value.NewFailureJobResult(value.FailureRuntimeError, res.Code(), msg))
+	}
+	return nil
+}
+
+// Fail завершает выполняющуюся задачу с ошибкой, не связанной с кодом возврата скрипта:
+// сборкой образа, превышением времени выполнения, разбором вывода или инфраструктурой.
+func (j *Job) Fail(reason value.FailureReason, message string) error {
+	if j.state == value.JobCancelled {
+		return ErrJobCancelled
+	}
+	if j.state != value.JobRunning {
+		return fmt.Errorf(
+			"%w: expected JobRunning -> JobFinished, got %s -> JobFinished", ErrInvalidJobStateChange, j.state.String(),
+		)
+	}
+	j.complete(value.NewFailureJobResult(reason, -1, message))
+	return nil
+}
+
+// Release возвращает выполняющуюся задачу в ожидание без учёта попытки: выполнение прервано сбоем
+// инфраструктуры, не связанным с самой задачей, и будет повторено при повторной доставке задачи.
+func (j *Job) Release() error {
+	if j.state == value.JobCancelled {
+		return ErrJobCancelled
+	}
+	if j.state != value.JobRunning {
+		return fmt.Errorf(
+			"%w: expected JobRunning -> JobPending, got %s -> JobPending", ErrInvalidJobStateChange, j.state.String(),
+		)
 	}
+	j.state = value.JobPending
+	j.startedAt = nil
+	return nil
+}
+
+// complete завершает текущую попытку результатом res. Если политика повторов допускает ещё одну попытку,
+// задача возвращается в ожидание до NextAttemptAt, иначе завершается с этим результатом.
+func (j *Job) complete(res value.JobResult) {
+	now := time.Now()
+	startedAt := now
+	if j.startedAt != nil {
+		startedAt = *j.startedAt
+	}
+	attempt := len(j.attempts) + 1
+	j.attempts = append(j.attempts, value.NewJobAttempt(attempt, startedAt, now, res))
+
+	if j.retryPolicy.ShouldRetry(attempt, res) {
+		next := now.Add(j.retryPolicy.Delay(attempt))
+		j.nextAttemptAt = &next
+		j.state = value.JobPending
+		return
+	}
+	j.result = &res
 	j.state = value.JobFinished
+	j.finishedAt = &now
+}
+
+// Cancel отменяет ожидающую или выполняющуюся задачу. Остановка контейнера выполняющейся задачи
+// остаётся на стороне вызывающего.
+func (j *Job) Cancel() error {
+	if j.state != value.JobPending && j.state != value.JobRunning {
+		return domain.NewInvalidInputError(
+			"job-cancel-invalid-state",
+			fmt.Sprintf("expected pending or running job to cancel, got %s", j.state.String()),
+		)
+	}
+	j.state = value.JobCancelled
+	j.nextAttemptAt = nil
 	now := time.Now()
 	j.finishedAt = &now
 	return nil
 }
 
-func (j *Job) parseOutput(output string) ([]value.Value, error) {
-	lines := strings.Split(output, "\n")
+func (j *Job) parseOutput(res value.Result) ([]value.Value, error) {
+	// Строки вывода содержат значения выходных полей по порядку, кроме полей типа file: их файлы
+	// сохраняются из контейнера отдельно.
+	lines := strings.Split(res.Output(), "\n")
 	lines = lines[:len(lines)-1]
-	if len(lines) != len(j.out) {
-		return nil, fmt.Errorf("%w: expected %d lines, got %d", ErrJobResultParseFailed, len(j.out), len(lines))
+	if n := len(j.out) - len(j.ArtifactNames()); len(lines) != n {
+		return nil, fmt.Errorf("%w: expected %d lines, got %d", ErrJobResultParseFailed, n, len(lines))
 	}
-	res := make([]value.Value, len(j.out))
-	for i, line := range lines {
-		field := j.out[i]
-		v, err := value.NewValue(field.Type(), line)
+	out := make([]value.Value, len(j.out))
+	line := 0
+	for i, field := range j.out {
+		if field.Type() == value.FileValueType {
+			id, ok := res.Artifacts()[field.Name()]
+			if !ok {
+				return nil, fmt.Errorf(
+					"%w: file '%s' of field %d is not produced", ErrJobResultParseFailed, field.Name(), i,
+				)
+			}
+			v, err := value.NewFileValue(string(id))
+			if err != nil {
+				return nil, fmt.Errorf("%w: field %d: %w", ErrJobResultParseFailed, i, err)
+			}
+			out[i] = v
+			continue
+		}
+		v, err := value.NewValue(field.Type(), lines[line])
+		if err == nil {
+			err = field.Validate(v)
+		}
 		if err != nil {
-			return nil, fmt.Errorf("%w: line=%d: %w", ErrJobResultParseFailed, i+1, err)
+			return nil, fmt.Errorf("%w: line=%d: %w", ErrJobResultParseFailed, line+1, err)
+		}
+		out[i] = v
+		line++
+	}
+	return out, nil
+}
+
+// ArtifactNames возвращает имена выходных полей типа file: скрипт записывает их файлы в каталог /out.
+func (j *Job) ArtifactNames() []string {
+	var names []string
+	for _, f := range j.out {
+		if f.Type() == value.FileValueType {
+			names = append(names, f.Name())
 		}
-		res[i] = v
 	}
-	return res, nil
+	return names
 }
 
 func (j *Job) ID() value.JobID {
 	return j.id
 }
 
+// ParentID возвращает ID задачи, повторным запуском которой является эта задача, либо nil.
+func (j *Job) ParentID() *value.JobID {
+	return j.parentID
+}
+
+// BatchID возвращает ID пакета, в который входит задача, либо nil.
+func (j *Job) BatchID() *value.BatchID {
+	return j.batchID
+}
+
+// ScheduleID возвращает ID расписания, по которому запущена задача, либо nil.
+func (j *Job) ScheduleID() *value.ScheduleID {
+	return j.scheduleID
+}
+
 func (j *Job) BlueprintID() value.BlueprintID {
 	return j.blueprintID
 }
@@ -92,6 +226,27 @@ func (j *Job) ArchiveID() value.FileID {
 	return j.archiveID
 }
 
+// Image возвращает тег образа Blueprint, собранного к моменту создания задачи.
+func (j *Job) Image() value.ImageTag {
+	return j.image
+}
+
+func (j *Job) Limits() value.ResourceLimits {
+	return j.limits
+}
+
+func (j *Job) Network() bool {
+	return j.network
+}
+
+func (j *Job) Timeout() time.Duration {
+	return j.timeout
+}
+
+func (j *Job) RetryPolicy() value.RetryPolicy {
+	return j.retryPolicy
+}
+
 func (j *Job) OwnerID() value.UserID {
 	return j.ownerID
 }
@@ -112,6 +267,18 @@ func (j *Job) CreatedAt() time.Time {
 	return j.createdAt
 }
 
+// Attempts возвращает завершённые попытки выполнения задачи, включая последнюю.
+func (j *Job) Attempts() []value.JobAttempt {
+	return j.attempts
+}
+
+// NextAttemptAt возвращает время, не раньше которого будет выполнен повтор задачи, ожидающей повторной
+// попытки; для остальных задач -- nil.
+func (j *Job) NextAttemptAt() *time.Time {
+	return j.nextAttemptAt
+}
+
+// StartedAt возвращает время запуска текущей или последней попытки выполнения.
 func (j *Job) StartedAt() *time.Time {
 	return j.startedAt
 }
@@ -126,13 +293,23 @@ func (j *Job) FinishedAt() *time.Time {
 
 func RestoreJob(
 	id value.JobID,
+	parentID *value.JobID,
+	batchID *value.BatchID,
+	scheduleID *value.ScheduleID,
 	blueprintID value.BlueprintID,
 	archiveID value.FileID,
+	image value.ImageTag,
+	limits value.ResourceLimits,
+	network bool,
+	timeout time.Duration,
+	retryPolicy value.RetryPolicy,
 	ownerID value.UserID,
 	state value.JobState,
 	input []value.Value,
 	out []value.Field,
 	createdAt time.Time,
+	attempts []value.JobAttempt,
+	nextAttemptAt *time.Time,
 	startedAt *time.Time,
 	result *value.JobResult,
 	finishedAt *time.Time,
@@ -165,17 +342,31 @@ func RestoreJob(
 		out = make([]value.Field, 0)
 	}
 
+	if attempts == nil {
+		attempts = make([]value.JobAttempt, 0)
+	}
+
 	return &Job{
-		id:          id,
-		blueprintID: blueprintID,
-		archiveID:   archiveID,
-		ownerID:     ownerID,
-		state:       state,
-		input:       input,
-		out:         out,
-		createdAt:   createdAt,
-		startedAt:   startedAt,
-		result:      result,
-		finishedAt:  finishedAt,
+		id:            id,
+		parentID:      parentID,
+		batchID:       batchID,
+		scheduleID:    scheduleID,
+		blueprintID:   blueprintID,
+		archiveID:     archiveID,
+		image:         image,
+		limits:        limits,
+		network:       network,
+		timeout:       timeout,
+		retryPolicy:   retryPolicy,
+		ownerID:       ownerID,
+		state:         state,
+		input:         input,
+		out:           out,
+		createdAt:     createdAt,
+		attempts:      attempts,
+		nextAttemptAt: nextAttemptAt,
+		startedAt:     startedAt,
+		result:        result,
+		finishedAt:    finishedAt,
 	}, nil
 }
@@ -0,0 +1,277 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// Pipeline -- конвейер: ориентированный ациклический граф шагов, каждый из которых запускает задачу своего
+// Blueprint. Входные поля шага получают постоянные значения либо значения выходных полей шагов, от которых
+// он зависит. Шаги хранятся в порядке зависимостей: каждый шаг следует за всеми шагами, от которых зависит.
+type Pipeline struct {
+	id        value.PipelineID
+	ownerID   value.UserID
+	name      string
+	desc      *string
+	steps     []value.PipelineStep
+	createdAt time.Time
+}
+
+// NewPipeline собирает конвейер из шагов steps, проверяя их входные данные по полям Blueprint из blueprints.
+func NewPipeline(
+	ownerID value.UserID,
+	name string,
+	desc *string,
+	steps []value.PipelineStep,
+	blueprints map[value.BlueprintID]*Blueprint,
+) (*Pipeline, error) {
+	if ownerID == "" {
+		return nil, errors.New("zero ownerID")
+	}
+
+	if name == "" {
+		return nil, domain.NewInvalidInputError("pipeline-empty-name", "expected not empty pipeline name")
+	}
+
+	if desc != nil && *desc == "" {
+		return nil, errors.New("expected nil or not empty pipeline description")
+	}
+
+	if len(steps) == 0 {
+		return nil, domain.NewInvalidInputError("pipeline-empty", "expected at least one pipeline step")
+	}
+
+	if len(steps) > value.MaxPipelineSteps {
+		return nil, domain.NewInvalidInputError(
+			"pipeline-too-large",
+			fmt.Sprintf("expected at most %d pipeline steps, got %d", value.MaxPipelineSteps, len(steps)),
+		)
+	}
+
+	byName := make(map[string]value.PipelineStep, len(steps))
+	for _, step := range steps {
+		if _, ok := byName[step.Name()]; ok {
+			return nil, domain.NewInvalidInputError(
+				"pipeline-step-duplicate", fmt.Sprintf("duplicate pipeline step name '%s'", step.Name()),
+			)
+		}
+		byName[step.Name()] = step
+	}
+
+	for _, step := range steps {
+		if err := validateStep(step, byName, blueprints); err != nil {
+			return nil, err
+		}
+	}
+
+	sorted, err := sortSteps(steps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pipeline{
+		id:        value.NewPipelineID(),
+		ownerID:   ownerID,
+		name:      name,
+		desc:      desc,
+		steps:     sorted,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// validateStep проверяет источники входных полей шага по полям Blueprint шага и шагов-источников.
+func validateStep(
+	step value.PipelineStep, byName map[string]value.PipelineStep, blueprints map[value.BlueprintID]*Blueprint,
+) error {
+	b, ok := blueprints[step.BlueprintID()]
+	if !ok {
+		return fmt.Errorf("blueprint %s of step '%s' is not provided", step.BlueprintID(), step.Name())
+	}
+
+	if len(step.Input()) > len(b.in) {
+		return domain.NewInvalidInputError(
+			"pipeline-step-inputs-mismatch",
+			fmt.Sprintf(
+				"step '%s': expected at most %d inputs, got %d", step.Name(), len(b.in), len(step.Input()),
+			),
+		)
+	}
+	var iiErr domain.InvalidInputError
+	if err := b.checkOmitted(len(step.Input())); errors.As(err, &iiErr) {
+		return domain.NewInvalidFieldError(
+			iiErr.Field, "pipeline-step-input-missing", fmt.Sprintf("step '%s': %s", step.Name(), iiErr.Message),
+		)
+	}
+
+	for i, in := range step.Input() {
+		if v, ok := in.Const(); ok {
+			if err := b.in[i].Validate(v); err != nil {
+				return domain.NewInvalidInputError(
+					"pipeline-step-input-invalid", fmt.Sprintf("step '%s': field %d: %s", step.Name(), i, err),
+				)
+			}
+			continue
+		}
+
+		src, ok := byName[in.Step()]
+		if !ok {
+			return domain.NewInvalidInputError(
+				"pipeline-step-unknown-source",
+				fmt.Sprintf("step '%s': field %d: unknown source step '%s'", step.Name(), i, in.Step()),
+			)
+		}
+		srcB, ok := blueprints[src.BlueprintID()]
+		if !ok {
+			return fmt.Errorf("blueprint %s of step '%s' is not provided", src.BlueprintID(), src.Name())
+		}
+		if in.Field() >= len(srcB.out) {
+			return domain.NewInvalidInputError(
+				"pipeline-step-source-field-out-of-range",
+				fmt.Sprintf(
+					"step '%s': field %d: step '%s' has %d output fields, got field %d",
+					step.Name(), i, src.Name(), len(srcB.out), in.Field(),
+				),
+			)
+		}
+		if err := b.in[i].ValidateSource(srcB.out[in.Field()]); err != nil {
+			return domain.NewInvalidInputError(
+				"pipeline-step-input-invalid",
+				fmt.Sprintf(
+					"step '%s': field %d: output field %d of step '%s': %s",
+					step.Name(), i, in.Field(), src.Name(), err,
+				),
+			)
+		}
+	}
+	return nil
+}
+
+// sortSteps упорядочивает шаги по зависимостям, сохраняя исходный порядок независимых шагов.
+func sortSteps(steps []value.PipelineStep) ([]value.PipelineStep, error) {
+	placed := make(map[string]bool, len(steps))
+	res := make([]value.PipelineStep, 0, len(steps))
+	for len(res) < len(steps) {
+		progress := false
+		for _, step := range steps {
+			if placed[step.Name()] || !allPlaced(step.Dependencies(), placed) {
+				continue
+			}
+			placed[step.Name()] = true
+			res = append(res, step)
+			progress = true
+		}
+		if !progress {
+			return nil, domain.NewInvalidInputError(
+				"pipeline-cycle", "pipeline steps have a dependency cycle",
+			)
+		}
+	}
+	return res, nil
+}
+
+func allPlaced(names []string, placed map[string]bool) bool {
+	for _, name := range names {
+		if !placed[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// AssembleRun собирает запуск конвейера и задачи его шагов, не зависящих от других. Образы Blueprint всех
+// шагов должны быть собраны.
+func (p *Pipeline) AssembleRun(
+	uid value.UserID, blueprints map[value.BlueprintID]*Blueprint,
+) (*PipelineRun, []*Job, error) {
+	steps := make([]value.StepRun, len(p.steps))
+	for i, step := range p.steps {
+		b, ok := blueprints[step.BlueprintID()]
+		if !ok {
+			return nil, nil, fmt.Errorf("blueprint %s of step '%s' is not provided", step.BlueprintID(), step.Name())
+		}
+		var iiErr domain.InvalidInputError
+		if err := b.checkBuilt(); errors.As(err, &iiErr) {
+			return nil, nil, domain.NewInvalidInputError(
+				iiErr.Code, fmt.Sprintf("step '%s': %s", step.Name(), iiErr.Message),
+			)
+		}
+		steps[i] = value.NewStepRun(step.Name(), value.StepWaiting, nil, nil)
+	}
+
+	run := &PipelineRun{
+		id:         value.NewPipelineRunID(),
+		pipelineID: p.id,
+		ownerID:    uid,
+		state:      value.PipelineRunning,
+		steps:      steps,
+		createdAt:  time.Now(),
+	}
+	jobs, err := run.Advance(p, nil, blueprints)
+	if err != nil {
+		return nil, nil, err
+	}
+	return run, jobs, nil
+}
+
+func (p *Pipeline) ID() value.PipelineID {
+	return p.id
+}
+
+func (p *Pipeline) OwnerID() value.UserID {
+	return p.ownerID
+}
+
+func (p *Pipeline) Name() string {
+	return p.name
+}
+
+func (p *Pipeline) Desc() *string {
+	return p.desc
+}
+
+// Steps возвращает шаги конвейера в порядке зависимостей.
+func (p *Pipeline) Steps() []value.PipelineStep {
+	return p.steps
+}
+
+func (p *Pipeline) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func RestorePipeline(
+	id value.PipelineID,
+	ownerID value.UserID,
+	name string,
+	desc *string,
+	steps []value.PipelineStep,
+	createdAt time.Time,
+) (*Pipeline, error) {
+	if id == "" {
+		return nil, errors.New("empty id")
+	}
+
+	if ownerID == "" {
+		return nil, errors.New("empty ownerID")
+	}
+
+	if name == "" {
+		return nil, errors.New("empty name")
+	}
+
+	if len(steps) == 0 {
+		return nil, errors.New("empty steps")
+	}
+
+	return &Pipeline{
+		id:        id,
+		ownerID:   ownerID,
+		name:      name,
+		desc:      desc,
+		steps:     steps,
+		createdAt: createdAt,
+	}, nil
+}
@@ -0,0 +1,242 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// PipelineRun -- запуск конвейера. Шаги запуска соответствуют шагам конвейера и следуют в том же порядке.
+type PipelineRun struct {
+	id         value.PipelineRunID
+	pipelineID value.PipelineID
+	ownerID    value.UserID
+	state      value.PipelineRunState
+	steps      []value.StepRun
+	createdAt  time.Time
+	finishedAt *time.Time
+}
+
+// Advance продвигает запуск конвейера p: обновляет состояния выполняющихся шагов по их задачам из jobs и
+// собирает задачи шагов, все зависимости которых выполнены успешно. Шаги, зависящие от невыполненного шага,
+// пропускаются. В jobs должны быть задачи всех запущенных шагов; задачи новых шагов собираются по Blueprint
+// из blueprints.
+func (r *PipelineRun) Advance(
+	p *Pipeline, jobs map[value.JobID]*Job, blueprints map[value.BlueprintID]*Blueprint,
+) ([]*Job, error) {
+	if r.pipelineID != p.id {
+		return nil, fmt.Errorf("expected pipeline %s, got %s", r.pipelineID, p.id)
+	}
+	if len(r.steps) != len(p.steps) {
+		return nil, fmt.Errorf("expected %d steps, got %d", len(p.steps), len(r.steps))
+	}
+	if r.state != value.PipelineRunning {
+		return nil, nil
+	}
+
+	index := make(map[string]int, len(r.steps))
+	for i, s := range r.steps {
+		index[s.Name()] = i
+	}
+
+	var res []*Job
+	// Шаги упорядочены по зависимостям, поэтому шаг, зависимость которого завершилась на этом же проходе,
+	// запускается без ожидания следующего продвижения.
+	for i, step := range p.steps {
+		switch r.steps[i].State() {
+		case value.StepRunning:
+			r.steps[i] = observeStep(r.steps[i], jobs)
+		case value.StepWaiting:
+			job, err := r.startStep(i, step, index, jobs, blueprints)
+			if err != nil {
+				return nil, err
+			}
+			if job != nil {
+				res = append(res, job)
+			}
+		}
+	}
+
+	r.finishIfDone()
+	return res, nil
+}
+
+// observeStep возвращает состояние выполняющегося шага по состоянию его задачи.
+func observeStep(s value.StepRun, jobs map[value.JobID]*Job) value.StepRun {
+	job := stepJob(s, jobs)
+	if job == nil {
+		return failStep(s, "job not found")
+	}
+	switch job.State() {
+	case value.JobCancelled:
+		return failStep(s, fmt.Sprintf("job %s cancelled", job.ID()))
+	case value.JobFinished:
+		res := job.Result()
+		if res.Code().IsSuccess() {
+			return value.NewStepRun(s.Name(), value.StepSucceeded, s.JobID(), nil)
+		}
+		return failStep(s, fmt.Sprintf("job %s failed: %s", job.ID(), res.Reason()))
+	}
+	return s
+}
+
+// startStep собирает задачу ожидающего шага step с индексом i, если все его зависимости выполнены, либо
+// завершает шаг без запуска, если задачу собрать невозможно.
+func (r *PipelineRun) startStep(
+	i int,
+	step value.PipelineStep,
+	index map[string]int,
+	jobs map[value.JobID]*Job,
+	blueprints map[value.BlueprintID]*Blueprint,
+) (*Job, error) {
+	for _, dep := range step.Dependencies() {
+		ds := r.steps[index[dep]]
+		switch ds.State() {
+		case value.StepSucceeded:
+			continue
+		case value.StepFailed, value.StepSkipped:
+			msg := fmt.Sprintf("dependency '%s' %s", dep, ds.State())
+			r.steps[i] = value.NewStepRun(step.Name(), value.StepSkipped, nil, &msg)
+			return nil, nil
+		}
+		return nil, nil
+	}
+
+	input := make([]value.Value, len(step.Input()))
+	for k, in := range step.Input() {
+		if v, ok := in.Const(); ok {
+			input[k] = v
+			continue
+		}
+		job := stepJob(r.steps[index[in.Step()]], jobs)
+		if job == nil || job.Result() == nil || in.Field() >= len(job.Result().Output()) {
+			r.steps[i] = failStep(r.steps[i], fmt.Sprintf("output of step '%s' not found", in.Step()))
+			return nil, nil
+		}
+		input[k] = job.Result().Output()[in.Field()]
+	}
+
+	b, ok := blueprints[step.BlueprintID()]
+	if !ok {
+		r.steps[i] = failStep(r.steps[i], fmt.Sprintf("blueprint %s not found", step.BlueprintID()))
+		return nil, nil
+	}
+
+	job, err := b.AssembleJob(r.ownerID, input)
+	var iiErr domain.InvalidInputError
+	if errors.As(err, &iiErr) {
+		r.steps[i] = failStep(r.steps[i], iiErr.Message)
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	r.steps[i] = value.NewStepRun(step.Name(), value.StepRunning, &job.id, nil)
+	return job, nil
+}
+
+// stepJob возвращает задачу шага из jobs либо nil, если задача не запускалась или удалена.
+func stepJob(s value.StepRun, jobs map[value.JobID]*Job) *Job {
+	if s.JobID() == nil {
+		return nil
+	}
+	return jobs[*s.JobID()]
+}
+
+func failStep(s value.StepRun, msg string) value.StepRun {
+	return value.NewStepRun(s.Name(), value.StepFailed, s.JobID(), &msg)
+}
+
+// finishIfDone завершает запуск, если завершены все его шаги.
+func (r *PipelineRun) finishIfDone() {
+	state := value.PipelineSucceeded
+	for _, s := range r.steps {
+		if !s.State().IsTerminal() {
+			return
+		}
+		if s.State() != value.StepSucceeded {
+			state = value.PipelineFailed
+		}
+	}
+	r.state = state
+	now := time.Now()
+	r.finishedAt = &now
+}
+
+func (r *PipelineRun) ID() value.PipelineRunID {
+	return r.id
+}
+
+func (r *PipelineRun) PipelineID() value.PipelineID {
+	return r.pipelineID
+}
+
+func (r *PipelineRun) OwnerID() value.UserID {
+	return r.ownerID
+}
+
+func (r *PipelineRun) State() value.PipelineRunState {
+	return r.state
+}
+
+func (r *PipelineRun) Steps() []value.StepRun {
+	return r.steps
+}
+
+// JobIDs возвращает ID задач запущенных шагов.
+func (r *PipelineRun) JobIDs() []value.JobID {
+	res := make([]value.JobID, 0, len(r.steps))
+	for _, s := range r.steps {
+		if s.JobID() != nil {
+			res = append(res, *s.JobID())
+		}
+	}
+	return res
+}
+
+func (r *PipelineRun) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// FinishedAt возвращает время завершения последнего шага; для выполняющегося запуска -- nil.
+func (r *PipelineRun) FinishedAt() *time.Time {
+	return r.finishedAt
+}
+
+func RestorePipelineRun(
+	id value.PipelineRunID,
+	pipelineID value.PipelineID,
+	ownerID value.UserID,
+	state value.PipelineRunState,
+	steps []value.StepRun,
+	createdAt time.Time,
+	finishedAt *time.Time,
+) (*PipelineRun, error) {
+	if id == "" {
+		return nil, errors.New("empty id")
+	}
+
+	if pipelineID == "" {
+		return nil, errors.New("empty pipelineID")
+	}
+
+	if ownerID == "" {
+		return nil, errors.New("empty ownerID")
+	}
+
+	if state.IsZero() {
+		return nil, errors.New("zero state")
+	}
+
+	return &PipelineRun{
+		id:         id,
+		pipelineID: pipelineID,
+		ownerID:    ownerID,
+		state:      state,
+		steps:      steps,
+		createdAt:  createdAt,
+		finishedAt: finishedAt,
+	}, nil
+}
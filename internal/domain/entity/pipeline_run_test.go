@@ -0,0 +1,245 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// jobOutcome -- исход задачи шага в тестах запуска конвейера.
+type jobOutcome int
+
+const (
+	jobSucceeds jobOutcome = iota
+	jobFails
+	jobCancelled
+)
+
+func completeJob(t *testing.T, j *entity.Job, o jobOutcome) {
+	t.Helper()
+	switch o {
+	case jobSucceeds:
+		require.NoError(t, j.Run())
+		require.NoError(t, j.Finish(value.NewResult(0).WithOutput("1\n")))
+	case jobFails:
+		require.NoError(t, j.Run())
+		require.NoError(t, j.Finish(value.NewResult(1).WithLog("error")))
+	case jobCancelled:
+		require.NoError(t, j.Cancel())
+	}
+}
+
+func stepOf(t *testing.T, r *entity.PipelineRun, id value.JobID) string {
+	t.Helper()
+	for _, s := range r.Steps() {
+		if s.JobID() != nil && *s.JobID() == id {
+			return s.Name()
+		}
+	}
+	t.Fatalf("job %s is not a job of any step", id)
+	return ""
+}
+
+func stepStates(r *entity.PipelineRun) map[string]value.StepState {
+	res := make(map[string]value.StepState, len(r.Steps()))
+	for _, s := range r.Steps() {
+		res[s.Name()] = s.State()
+	}
+	return res
+}
+
+func TestPipelineRun_Advance(t *testing.T) {
+	bs := newPipelineBlueprints(t)
+	chain := []value.PipelineStep{
+		mustNewStep(t, "a", bs.source),
+		mustNewStep(t, "b", bs.unary, mustFrom(t, "a", 0)),
+		mustNewStep(t, "c", bs.unary, mustFrom(t, "b", 0)),
+	}
+	diamond := []value.PipelineStep{
+		mustNewStep(t, "a", bs.source),
+		mustNewStep(t, "b", bs.unary, mustFrom(t, "a", 0)),
+		mustNewStep(t, "c", bs.unary, mustFrom(t, "a", 0)),
+		mustNewStep(t, "d", bs.binary, mustFrom(t, "b", 0), mustFrom(t, "c", 0)),
+	}
+	independent := []value.PipelineStep{
+		mustNewStep(t, "a", bs.source),
+		mustNewStep(t, "b", bs.source),
+		mustNewStep(t, "c", bs.unary, mustFrom(t, "b", 0)),
+	}
+
+	tests := []struct {
+		name     string
+		steps    []value.PipelineStep
+		outcomes map[string]jobOutcome
+		// rounds -- шаги, задачи которых собираются при каждом продвижении запуска.
+		rounds [][]string
+		want   map[string]value.StepState
+		state  value.PipelineRunState
+	}{
+		{
+			name:   "chain succeeds",
+			steps:  chain,
+			rounds: [][]string{{"a"}, {"b"}, {"c"}},
+			want: map[string]value.StepState{
+				"a": value.StepSucceeded, "b": value.StepSucceeded, "c": value.StepSucceeded,
+			},
+			state: value.PipelineSucceeded,
+		},
+		{
+			name:     "failed step stops its dependents",
+			steps:    chain,
+			outcomes: map[string]jobOutcome{"a": jobFails},
+			rounds:   [][]string{{"a"}},
+			want:     map[string]value.StepState{"a": value.StepFailed, "b": value.StepSkipped, "c": value.StepSkipped},
+			state:    value.PipelineFailed,
+		},
+		{
+			name:     "cancelled step stops its dependents",
+			steps:    chain,
+			outcomes: map[string]jobOutcome{"b": jobCancelled},
+			rounds:   [][]string{{"a"}, {"b"}},
+			want: map[string]value.StepState{
+				"a": value.StepSucceeded, "b": value.StepFailed, "c": value.StepSkipped,
+			},
+			state: value.PipelineFailed,
+		},
+		{
+			name:   "diamond succeeds",
+			steps:  diamond,
+			rounds: [][]string{{"a"}, {"b", "c"}, {"d"}},
+			want: map[string]value.StepState{
+				"a": value.StepSucceeded, "b": value.StepSucceeded, "c": value.StepSucceeded, "d": value.StepSucceeded,
+			},
+			state: value.PipelineSucceeded,
+		},
+		{
+			name:     "diamond branch fails",
+			steps:    diamond,
+			outcomes: map[string]jobOutcome{"c": jobFails},
+			rounds:   [][]string{{"a"}, {"b", "c"}},
+			want: map[string]value.StepState{
+				"a": value.StepSucceeded, "b": value.StepSucceeded, "c": value.StepFailed, "d": value.StepSkipped,
+			},
+			state: value.PipelineFailed,
+		},
+		{
+			name:     "diamond root fails",
+			steps:    diamond,
+			outcomes: map[string]jobOutcome{"a": jobFails},
+			rounds:   [][]string{{"a"}},
+			want: map[string]value.StepState{
+				"a": value.StepFailed, "b": value.StepSkipped, "c": value.StepSkipped, "d": value.StepSkipped,
+			},
+			state: value.PipelineFailed,
+		},
+		{
+			name:     "failed step does not stop independent steps",
+			steps:    independent,
+			outcomes: map[string]jobOutcome{"a": jobFails},
+			rounds:   [][]string{{"a", "b"}, {"c"}},
+			want: map[string]value.StepState{
+				"a": value.StepFailed, "b": value.StepSucceeded, "c": value.StepSucceeded,
+			},
+			state: value.PipelineFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := entity.NewPipeline(value.NewUserID(), "pipeline", nil, tt.steps, bs.byID())
+			require.NoError(t, err)
+			r, started, err := p.AssembleRun(p.OwnerID(), bs.byID())
+			require.NoError(t, err)
+
+			jobs := make(map[value.JobID]*entity.Job)
+			var rounds [][]string
+			for len(started) > 0 {
+				var round []string
+				for _, j := range started {
+					name := stepOf(t, r, j.ID())
+					round = append(round, name)
+					completeJob(t, j, tt.outcomes[name])
+					jobs[j.ID()] = j
+				}
+				rounds = append(rounds, round)
+				require.Equal(t, value.PipelineRunning, r.State())
+				started, err = r.Advance(p, jobs, bs.byID())
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.rounds, rounds)
+			require.Equal(t, tt.want, stepStates(r))
+			require.Equal(t, tt.state, r.State())
+			require.NotNil(t, r.FinishedAt())
+		})
+	}
+}
+
+func TestPipelineRun_Advance_Diamond(t *testing.T) {
+	bs := newPipelineBlueprints(t)
+	p, err := entity.NewPipeline(value.NewUserID(), "pipeline", nil, []value.PipelineStep{
+		mustNewStep(t, "a", bs.source),
+		mustNewStep(t, "b", bs.unary, mustFrom(t, "a", 0)),
+		mustNewStep(t, "c", bs.unary, mustFrom(t, "a", 0)),
+		mustNewStep(t, "d", bs.binary, mustFrom(t, "b", 0), mustFrom(t, "c", 0)),
+	}, bs.byID())
+	require.NoError(t, err)
+	r, started, err := p.AssembleRun(p.OwnerID(), bs.byID())
+	require.NoError(t, err)
+	require.Len(t, started, 1)
+
+	a := started[0]
+	require.NoError(t, a.Run())
+	require.NoError(t, a.Finish(value.NewResult(0).WithOutput("1\n")))
+	jobs := map[value.JobID]*entity.Job{a.ID(): a}
+	started, err = r.Advance(p, jobs, bs.byID())
+	require.NoError(t, err)
+	require.Len(t, started, 2)
+	b, c := started[0], started[1]
+	require.Equal(t, []value.Value{value.MustNewIntegerValue("1")}, b.Input())
+	jobs[b.ID()], jobs[c.ID()] = b, c
+
+	t.Run("join step waits for all dependencies", func(t *testing.T) {
+		require.NoError(t, b.Run())
+		require.NoError(t, b.Finish(value.NewResult(0).WithOutput("2\n")))
+		started, err := r.Advance(p, jobs, bs.byID())
+		require.NoError(t, err)
+		require.Empty(t, started)
+		require.Equal(t, value.StepWaiting, stepStates(r)["d"])
+	})
+
+	t.Run("join step receives outputs of all dependencies", func(t *testing.T) {
+		require.NoError(t, c.Run())
+		require.NoError(t, c.Finish(value.NewResult(0).WithOutput("3\n")))
+		started, err := r.Advance(p, jobs, bs.byID())
+		require.NoError(t, err)
+		require.Len(t, started, 1)
+		want := []value.Value{value.MustNewIntegerValue("2"), value.MustNewIntegerValue("3")}
+		require.Equal(t, want, started[0].Input())
+	})
+}
+
+func TestPipelineRun_Advance_MissingJob(t *testing.T) {
+	bs := newPipelineBlueprints(t)
+	p, err := entity.NewPipeline(value.NewUserID(), "pipeline", nil, []value.PipelineStep{
+		mustNewStep(t, "a", bs.source),
+		mustNewStep(t, "b", bs.unary, mustFrom(t, "a", 0)),
+	}, bs.byID())
+	require.NoError(t, err)
+	r, _, err := p.AssembleRun(p.OwnerID(), bs.byID())
+	require.NoError(t, err)
+
+	started, err := r.Advance(p, nil, bs.byID())
+	require.NoError(t, err)
+	require.Empty(t, started)
+	require.Equal(t, map[string]value.StepState{"a": value.StepFailed, "b": value.StepSkipped}, stepStates(r))
+	require.Equal(t, value.PipelineFailed, r.State())
+
+	t.Run("finished run is not advanced", func(t *testing.T) {
+		started, err := r.Advance(p, nil, bs.byID())
+		require.NoError(t, err)
+		require.Empty(t, started)
+	})
+}
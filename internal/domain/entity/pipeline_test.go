@@ -0,0 +1,216 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// pipelineBlueprints -- Blueprint шагов конвейеров в тестах: у каждого одно целочисленное выходное поле.
+type pipelineBlueprints struct {
+	// source не имеет входных полей.
+	source *entity.Blueprint
+	// unary принимает одно целое число.
+	unary *entity.Blueprint
+	// binary принимает два целых числа.
+	binary *entity.Blueprint
+	// text принимает одну строку.
+	text *entity.Blueprint
+}
+
+func newPipelineBlueprints(t *testing.T) pipelineBlueprints {
+	t.Helper()
+	out := []value.Field{mustNewField(t, "y", nil)}
+	s, err := value.NewField(value.StringValueType, "s", nil, nil, nil, value.FieldConstraints{}, nil)
+	require.NoError(t, err)
+	return pipelineBlueprints{
+		source: mustBuildBlueprint(t, nil, out),
+		unary:  mustBuildBlueprint(t, []value.Field{mustNewField(t, "x", nil)}, out),
+		binary: mustBuildBlueprint(t, []value.Field{mustNewField(t, "x1", nil), mustNewField(t, "x2", nil)}, out),
+		text:   mustBuildBlueprint(t, []value.Field{s}, out),
+	}
+}
+
+func (bs pipelineBlueprints) byID() map[value.BlueprintID]*entity.Blueprint {
+	return map[value.BlueprintID]*entity.Blueprint{
+		bs.source.ID(): bs.source,
+		bs.unary.ID():  bs.unary,
+		bs.binary.ID(): bs.binary,
+		bs.text.ID():   bs.text,
+	}
+}
+
+func mustNewStep(t *testing.T, name string, b *entity.Blueprint, in ...value.StepInput) value.PipelineStep {
+	t.Helper()
+	s, err := value.NewPipelineStep(name, b.ID(), in)
+	require.NoError(t, err)
+	return s
+}
+
+func mustFrom(t *testing.T, step string, field int) value.StepInput {
+	t.Helper()
+	in, err := value.NewOutputStepInput(step, field)
+	require.NoError(t, err)
+	return in
+}
+
+func stepNames(steps []value.PipelineStep) []string {
+	names := make([]string, len(steps))
+	for i, s := range steps {
+		names[i] = s.Name()
+	}
+	return names
+}
+
+func TestNewPipeline_Steps(t *testing.T) {
+	bs := newPipelineBlueprints(t)
+
+	tests := []struct {
+		name  string
+		steps []value.PipelineStep
+		want  []string
+		code  string
+	}{
+		{
+			name:  "single step",
+			steps: []value.PipelineStep{mustNewStep(t, "a", bs.source)},
+			want:  []string{"a"},
+		},
+		{
+			name: "steps are sorted by dependencies",
+			steps: []value.PipelineStep{
+				mustNewStep(t, "c", bs.unary, mustFrom(t, "b", 0)),
+				mustNewStep(t, "b", bs.unary, mustFrom(t, "a", 0)),
+				mustNewStep(t, "a", bs.source),
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "independent steps keep their order",
+			steps: []value.PipelineStep{
+				mustNewStep(t, "x", bs.source),
+				mustNewStep(t, "z", bs.unary, mustFrom(t, "y", 0)),
+				mustNewStep(t, "y", bs.source),
+				mustNewStep(t, "w", bs.source),
+			},
+			want: []string{"x", "y", "w", "z"},
+		},
+		{
+			name: "diamond",
+			steps: []value.PipelineStep{
+				mustNewStep(t, "d", bs.binary, mustFrom(t, "b", 0), mustFrom(t, "c", 0)),
+				mustNewStep(t, "c", bs.unary, mustFrom(t, "a", 0)),
+				mustNewStep(t, "b", bs.unary, mustFrom(t, "a", 0)),
+				mustNewStep(t, "a", bs.source),
+			},
+			want: []string{"a", "c", "b", "d"},
+		},
+		{
+			name: "cycle is rejected",
+			steps: []value.PipelineStep{
+				mustNewStep(t, "a", bs.source),
+				mustNewStep(t, "b", bs.binary, mustFrom(t, "a", 0), mustFrom(t, "c", 0)),
+				mustNewStep(t, "c", bs.unary, mustFrom(t, "b", 0)),
+			},
+			code: "pipeline-cycle",
+		},
+		{
+			name:  "self dependency is rejected",
+			steps: []value.PipelineStep{mustNewStep(t, "a", bs.unary, mustFrom(t, "a", 0))},
+			code:  "pipeline-cycle",
+		},
+		{
+			name:  "duplicate step name is rejected",
+			steps: []value.PipelineStep{mustNewStep(t, "a", bs.source), mustNewStep(t, "a", bs.source)},
+			code:  "pipeline-step-duplicate",
+		},
+		{
+			name:  "empty pipeline is rejected",
+			steps: nil,
+			code:  "pipeline-empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := entity.NewPipeline(value.NewUserID(), "pipeline", nil, tt.steps, bs.byID())
+			if tt.code != "" {
+				var iiErr domain.InvalidInputError
+				require.True(t, errors.As(err, &iiErr))
+				require.Equal(t, tt.code, iiErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, stepNames(p.Steps()))
+		})
+	}
+}
+
+func TestNewPipeline_StepInput(t *testing.T) {
+	bs := newPipelineBlueprints(t)
+	source := mustNewStep(t, "a", bs.source)
+
+	tests := []struct {
+		name string
+		step value.PipelineStep
+		code string
+	}{
+		{
+			name: "output mapped to input of the same type",
+			step: mustNewStep(t, "b", bs.unary, mustFrom(t, "a", 0)),
+		},
+		{
+			name: "const and output inputs",
+			step: mustNewStep(
+				t, "b", bs.binary, value.NewConstStepInput(value.MustNewIntegerValue("1")), mustFrom(t, "a", 0),
+			),
+		},
+		{
+			name: "output mapped to input of another type",
+			step: mustNewStep(t, "b", bs.text, mustFrom(t, "a", 0)),
+			code: "pipeline-step-input-invalid",
+		},
+		{
+			name: "const of another type",
+			step: mustNewStep(t, "b", bs.unary, value.NewConstStepInput(value.NewStringValue("1"))),
+			code: "pipeline-step-input-invalid",
+		},
+		{
+			name: "source field out of range",
+			step: mustNewStep(t, "b", bs.unary, mustFrom(t, "a", 1)),
+			code: "pipeline-step-source-field-out-of-range",
+		},
+		{
+			name: "unknown source step",
+			step: mustNewStep(t, "b", bs.unary, mustFrom(t, "x", 0)),
+			code: "pipeline-step-unknown-source",
+		},
+		{
+			name: "more inputs than fields",
+			step: mustNewStep(t, "b", bs.unary, mustFrom(t, "a", 0), mustFrom(t, "a", 0)),
+			code: "pipeline-step-inputs-mismatch",
+		},
+		{
+			name: "required input is missing",
+			step: mustNewStep(t, "b", bs.binary, mustFrom(t, "a", 0)),
+			code: "pipeline-step-input-missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := []value.PipelineStep{source, tt.step}
+			_, err := entity.NewPipeline(value.NewUserID(), "pipeline", nil, steps, bs.byID())
+			if tt.code == "" {
+				require.NoError(t, err)
+				return
+			}
+			var iiErr domain.InvalidInputError
+			require.True(t, errors.As(err, &iiErr))
+			require.Equal(t, tt.code, iiErr.Code)
+		})
+	}
+}
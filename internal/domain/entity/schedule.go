@@ -0,0 +1,218 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// Schedule -- расписание, по которому задачи одного Blueprint запускаются с одними и теми же входными данными.
+// Время срабатывания вычисляется по выражению cron в часовом поясе расписания.
+type Schedule struct {
+	id          value.ScheduleID
+	blueprintID value.BlueprintID
+	ownerID     value.UserID
+	input       []value.Value
+	cron        value.CronExpr
+	location    *time.Location
+	overlap     value.OverlapPolicy
+	enabled     bool
+	lastJobID   *value.JobID
+	nextRunAt   *time.Time
+	createdAt   time.Time
+}
+
+// SetInput заменяет входные данные задач расписания; они проверяются по полям Blueprint расписания, а опущенные
+// значения необязательных полей заменяются значениями по умолчанию.
+func (s *Schedule) SetInput(b *Blueprint, input []value.Value) error {
+	if b.id != s.blueprintID {
+		return fmt.Errorf("expected blueprint %s, got %s", s.blueprintID, b.id)
+	}
+	input, err := b.completeInput(input)
+	if err != nil {
+		return err
+	}
+	s.input = input
+	return nil
+}
+
+// Reschedule меняет выражение cron и часовой пояс расписания и заново вычисляет время срабатывания.
+func (s *Schedule) Reschedule(cron value.CronExpr, location *time.Location) error {
+	if cron.IsZero() {
+		return domain.NewInvalidInputError("schedule-empty-cron", "expected not empty cron expression")
+	}
+	if cron.Next(time.Now().In(location)).IsZero() {
+		return domain.NewInvalidInputError(
+			"schedule-never-fires", fmt.Sprintf("cron expression '%s' never fires", cron.String()),
+		)
+	}
+	s.cron = cron
+	s.location = location
+	if s.enabled {
+		s.advance(time.Now())
+	}
+	return nil
+}
+
+func (s *Schedule) SetOverlapPolicy(p value.OverlapPolicy) error {
+	if p.IsZero() {
+		return domain.NewInvalidInputError("schedule-empty-overlap-policy", "expected not empty overlap policy")
+	}
+	s.overlap = p
+	return nil
+}
+
+// ResolveOverlap применяет политику перекрытия к задаче прошлого срабатывания в состоянии prev: сообщает, нужно
+// ли пропустить срабатывание, либо нужно ли отменить прошлую задачу перед запуском новой.
+func (s *Schedule) ResolveOverlap(prev value.JobState) (skip bool, cancelPrev bool) {
+	if prev.IsTerminal() {
+		return false, false
+	}
+	switch s.overlap {
+	case value.OverlapForbid:
+		return true, false
+	case value.OverlapReplace:
+		return false, true
+	}
+	return false, false
+}
+
+// Enable включает расписание; первое срабатывание -- ближайшее после текущего момента.
+func (s *Schedule) Enable() {
+	if s.enabled {
+		return
+	}
+	s.enabled = true
+	s.advance(time.Now())
+}
+
+func (s *Schedule) Disable() {
+	s.enabled = false
+	s.nextRunAt = nil
+}
+
+// IsDue сообщает, что включённому расписанию пора сработать.
+func (s *Schedule) IsDue(now time.Time) bool {
+	return s.enabled && s.nextRunAt != nil && !now.Before(*s.nextRunAt)
+}
+
+// Skip пропускает срабатывание без запуска задачи.
+func (s *Schedule) Skip(now time.Time) {
+	s.advance(now)
+}
+
+// advance переносит срабатывание на ближайшее после now. Пропущенные, пока сервис не работал, срабатывания
+// не наверстываются.
+func (s *Schedule) advance(now time.Time) {
+	next := s.cron.Next(now.In(s.location))
+	if next.IsZero() {
+		s.Disable()
+		return
+	}
+	s.nextRunAt = &next
+}
+
+func (s *Schedule) ID() value.ScheduleID {
+	return s.id
+}
+
+func (s *Schedule) BlueprintID() value.BlueprintID {
+	return s.blueprintID
+}
+
+func (s *Schedule) OwnerID() value.UserID {
+	return s.ownerID
+}
+
+func (s *Schedule) Input() []value.Value {
+	return s.input
+}
+
+func (s *Schedule) Cron() value.CronExpr {
+	return s.cron
+}
+
+func (s *Schedule) Location() *time.Location {
+	return s.location
+}
+
+func (s *Schedule) OverlapPolicy() value.OverlapPolicy {
+	return s.overlap
+}
+
+func (s *Schedule) Enabled() bool {
+	return s.enabled
+}
+
+// LastJobID возвращает ID задачи последнего срабатывания либо nil.
+func (s *Schedule) LastJobID() *value.JobID {
+	return s.lastJobID
+}
+
+// NextRunAt возвращает время следующего срабатывания; для выключенного расписания -- nil.
+func (s *Schedule) NextRunAt() *time.Time {
+	return s.nextRunAt
+}
+
+func (s *Schedule) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func RestoreSchedule(
+	id value.ScheduleID,
+	blueprintID value.BlueprintID,
+	ownerID value.UserID,
+	input []value.Value,
+	cron value.CronExpr,
+	location *time.Location,
+	overlap value.OverlapPolicy,
+	enabled bool,
+	lastJobID *value.JobID,
+	nextRunAt *time.Time,
+	createdAt time.Time,
+) (*Schedule, error) {
+	if id == "" {
+		return nil, errors.New("empty id")
+	}
+
+	if blueprintID == "" {
+		return nil, errors.New("empty blueprintID")
+	}
+
+	if ownerID == "" {
+		return nil, errors.New("empty ownerID")
+	}
+
+	if cron.IsZero() {
+		return nil, errors.New("empty cron")
+	}
+
+	if location == nil {
+		return nil, errors.New("nil location")
+	}
+
+	if overlap.IsZero() {
+		return nil, errors.New("empty overlap policy")
+	}
+
+	if input == nil {
+		input = make([]value.Value, 0)
+	}
+
+	return &Schedule{
+		id:          id,
+		blueprintID: blueprintID,
+		ownerID:     ownerID,
+		input:       input,
+		cron:        cron,
+		location:    location,
+		overlap:     overlap,
+		enabled:     enabled,
+		lastJobID:   lastJobID,
+		nextRunAt:   nextRunAt,
+		createdAt:   createdAt,
+	}, nil
+}
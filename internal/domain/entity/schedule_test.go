@@ -0,0 +1,164 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func mustRestoreSchedule(t *testing.T, cron string, tz string, overlap value.OverlapPolicy) *entity.Schedule {
+	t.Helper()
+	loc, err := value.LocationFromString(tz)
+	require.NoError(t, err)
+	s, err := entity.RestoreSchedule(
+		value.NewScheduleID(), value.NewBlueprintID(), value.NewUserID(), nil, value.MustCronExprFromString(cron),
+		loc, overlap, true, nil, nil, time.Now(),
+	)
+	require.NoError(t, err)
+	return s
+}
+
+func TestSchedule_ResolveOverlap(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     value.OverlapPolicy
+		prev       value.JobState
+		skip       bool
+		cancelPrev bool
+	}{
+		{name: "allow while previous is running", policy: value.OverlapAllow, prev: value.JobRunning},
+		{name: "allow while previous is pending", policy: value.OverlapAllow, prev: value.JobPending},
+		{name: "forbid while previous is running", policy: value.OverlapForbid, prev: value.JobRunning, skip: true},
+		{name: "forbid while previous is pending", policy: value.OverlapForbid, prev: value.JobPending, skip: true},
+		{name: "forbid after previous finished", policy: value.OverlapForbid, prev: value.JobFinished},
+		{name: "forbid after previous cancelled", policy: value.OverlapForbid, prev: value.JobCancelled},
+		{
+			name: "replace while previous is running", policy: value.OverlapReplace, prev: value.JobRunning,
+			cancelPrev: true,
+		},
+		{name: "replace after previous finished", policy: value.OverlapReplace, prev: value.JobFinished},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mustRestoreSchedule(t, "@hourly", "UTC", tt.policy)
+			skip, cancelPrev := s.ResolveOverlap(tt.prev)
+			require.Equal(t, tt.skip, skip)
+			require.Equal(t, tt.cancelPrev, cancelPrev)
+		})
+	}
+}
+
+func TestSchedule_EnableDisable(t *testing.T) {
+	b := mustBuildBlueprint(t, nil, nil)
+	s, err := b.AssembleSchedule(
+		b.OwnerID(), nil, value.MustCronExprFromString("@hourly"), time.UTC, value.OverlapAllow, false,
+	)
+	require.NoError(t, err)
+	future := time.Now().Add(24 * time.Hour)
+
+	t.Run("disabled schedule is never due", func(t *testing.T) {
+		require.False(t, s.Enabled())
+		require.Nil(t, s.NextRunAt())
+		require.False(t, s.IsDue(future))
+	})
+
+	t.Run("enabled schedule is due at the next run", func(t *testing.T) {
+		s.Enable()
+		require.True(t, s.Enabled())
+		next := s.NextRunAt()
+		require.NotNil(t, next)
+		require.True(t, next.After(time.Now()))
+		require.False(t, s.IsDue(next.Add(-time.Second)))
+		require.True(t, s.IsDue(*next))
+	})
+
+	t.Run("enabling an enabled schedule keeps the next run", func(t *testing.T) {
+		next := *s.NextRunAt()
+		s.Enable()
+		require.Equal(t, next, *s.NextRunAt())
+	})
+
+	t.Run("fired schedule is moved to the following run", func(t *testing.T) {
+		next := *s.NextRunAt()
+		job, err := b.AssembleScheduledJob(s, next)
+		require.NoError(t, err)
+		require.Equal(t, s.ID(), *job.ScheduleID())
+		require.Equal(t, job.ID(), *s.LastJobID())
+		require.Equal(t, next.Add(time.Hour), *s.NextRunAt())
+		require.False(t, s.IsDue(next))
+	})
+
+	t.Run("disabled schedule loses the next run", func(t *testing.T) {
+		s.Disable()
+		require.False(t, s.Enabled())
+		require.Nil(t, s.NextRunAt())
+		require.False(t, s.IsDue(future))
+	})
+}
+
+func TestSchedule_NextRunAt(t *testing.T) {
+	tests := []struct {
+		name string
+		cron string
+		tz   string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "UTC",
+			cron: "0 9 * * *",
+			tz:   "UTC",
+			now:  time.Date(2026, 1, 10, 7, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time of day is in the schedule timezone",
+			cron: "0 9 * * *",
+			tz:   "Europe/Moscow",
+			now:  time.Date(2026, 1, 10, 7, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 11, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "winter offset",
+			cron: "0 9 * * *",
+			tz:   "Europe/Berlin",
+			now:  time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 10, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "summer offset",
+			cron: "0 9 * * *",
+			tz:   "Europe/Berlin",
+			now:  time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 7, 10, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of week is in the schedule timezone",
+			cron: "0 9 * * mon",
+			tz:   "Asia/Tokyo",
+			// Воскресенье по UTC, но уже понедельник в Токио.
+			now:  time.Date(2026, 1, 11, 23, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "negative offset",
+			cron: "30 23 * * *",
+			tz:   "America/New_York",
+			now:  time.Date(2026, 1, 11, 3, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 1, 11, 4, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mustRestoreSchedule(t, tt.cron, tt.tz, value.OverlapAllow)
+			s.Skip(tt.now)
+			require.NotNil(t, s.NextRunAt())
+			require.Equal(t, tt.want, s.NextRunAt().UTC())
+			require.Equal(t, tt.tz, s.NextRunAt().Location().String())
+		})
+	}
+}
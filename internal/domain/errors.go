@@ -11,6 +11,8 @@ var ErrInvalidCredentials = errors.New("invalid credentials")
 type InvalidInputError struct {
 	Code    string
 	Message string
+	// Field -- название поля входных данных, к которому относится ошибка, либо пустая строка.
+	Field string
 }
 
 func NewInvalidInputError(code string, message string) InvalidInputError {
@@ -20,6 +22,15 @@ func NewInvalidInputError(code string, message string) InvalidInputError {
 	}
 }
 
+// NewInvalidFieldError создаёт ошибку входных данных, относящуюся к полю field.
+func NewInvalidFieldError(field string, code string, message string) InvalidInputError {
+	return InvalidInputError{
+		Code:    code,
+		Message: message,
+		Field:   field,
+	}
+}
+
 func (e InvalidInputError) Error() string {
 	return fmt.Sprintf("invalid input: %s: %s", e.Code, e.Message)
 }
@@ -0,0 +1,9 @@
+package value
+
+const BatchIDLength = 8
+
+type BatchID string
+
+func NewBatchID() BatchID {
+	return BatchID(NewShortUUID(BatchIDLength))
+}
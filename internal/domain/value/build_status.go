@@ -0,0 +1,44 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// BuildStatus описывает состояние сборки образа Blueprint.
+type BuildStatus struct {
+	s string
+}
+
+var (
+	BuildQueued   = BuildStatus{"queued"}
+	BuildBuilding = BuildStatus{"building"}
+	BuildReady    = BuildStatus{"ready"}
+	BuildFailed   = BuildStatus{"failed"}
+)
+
+func BuildStatusFromString(s string) (BuildStatus, error) {
+	switch s {
+	case "queued":
+		return BuildQueued, nil
+	case "building":
+		return BuildBuilding, nil
+	case "ready":
+		return BuildReady, nil
+	case "failed":
+		return BuildFailed, nil
+	}
+	return BuildQueued, domain.NewInvalidInputError(
+		"build-status-invalid",
+		fmt.Sprintf("invalid build status: expected one of ['queued', 'building', 'ready', 'failed'], got '%s'", s),
+	)
+}
+
+func (s BuildStatus) String() string {
+	return s.s
+}
+
+func (s BuildStatus) IsZero() bool {
+	return s.s == ""
+}
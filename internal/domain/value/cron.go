@@ -0,0 +1,233 @@
+package value
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// cronSearchYears -- на сколько лет вперёд ищется ближайшее срабатывание расписания.
+const cronSearchYears = 5
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+var cronDayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string // Имена значений начиная с min.
+}
+
+var (
+	cronMinute = cronField{"minute", 0, 59, nil}
+	cronHour   = cronField{"hour", 0, 23, nil}
+	cronDom    = cronField{"day of month", 1, 31, nil}
+	cronMonth  = cronField{"month", 1, 12, cronMonthNames}
+	// День недели 7, как и 0, -- воскресенье.
+	cronDow = cronField{"day of week", 0, 7, cronDayNames}
+)
+
+// CronExpr -- расписание в формате cron из пяти полей: минуты, часы, дни месяца, месяцы и дни недели. Поле
+// допускает *, значения, диапазоны a-b, шаг /n и их списки через запятую; месяцы и дни недели -- также
+// трёхбуквенные английские имена. Поддерживаются сокращения @yearly, @monthly, @weekly, @daily и @hourly.
+type CronExpr struct {
+	s      string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// Если ограничены и дни месяца, и дни недели, день подходит при совпадении любого из них, как в cron.
+	domAny bool
+	dowAny bool
+}
+
+func CronExprFromString(s string) (CronExpr, error) {
+	expr := strings.TrimSpace(s)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return CronExpr{}, domain.NewInvalidInputError(
+			"cron-invalid", fmt.Sprintf("expected 5 cron fields, got %d in '%s'", len(parts), s),
+		)
+	}
+
+	c := CronExpr{s: strings.TrimSpace(s)}
+	var err error
+	if c.minute, err = cronMinute.parse(parts[0]); err != nil {
+		return CronExpr{}, err
+	}
+	if c.hour, err = cronHour.parse(parts[1]); err != nil {
+		return CronExpr{}, err
+	}
+	if c.dom, err = cronDom.parse(parts[2]); err != nil {
+		return CronExpr{}, err
+	}
+	if c.month, err = cronMonth.parse(parts[3]); err != nil {
+		return CronExpr{}, err
+	}
+	if c.dow, err = cronDow.parse(parts[4]); err != nil {
+		return CronExpr{}, err
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domAny = strings.HasPrefix(parts[2], "*")
+	c.dowAny = strings.HasPrefix(parts[4], "*")
+	return c, nil
+}
+
+func MustCronExprFromString(s string) CronExpr {
+	c, err := CronExprFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (c CronExpr) String() string {
+	return c.s
+}
+
+func (c CronExpr) IsZero() bool {
+	return c.s == ""
+}
+
+// Next возвращает ближайшее время срабатывания строго после t в часовом поясе t либо нулевое время, если
+// расписание не срабатывает в ближайшие годы (например, 30 февраля).
+func (c CronExpr) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		if !cronHas(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !cronHas(c.hour, t.Hour()) {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			// При переводе часов назад тот же час повторяется, и начало следующего часа может оказаться
+			// не позже t.
+			if !next.After(t) {
+				next = t.Add(time.Hour).Truncate(time.Minute)
+			}
+			t = next
+			continue
+		}
+		if !cronHas(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c CronExpr) dayMatches(t time.Time) bool {
+	dom := cronHas(c.dom, t.Day())
+	dow := cronHas(c.dow, int(t.Weekday()))
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func cronHas(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+func (f cronField) parse(s string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(s, ",") {
+		bits, err := f.parseRange(part)
+		if err != nil {
+			return 0, domain.NewInvalidInputError(
+				"cron-invalid", fmt.Sprintf("invalid cron %s field '%s': %s", f.name, s, err.Error()),
+			)
+		}
+		set |= bits
+	}
+	return set, nil
+}
+
+func (f cronField) parseRange(s string) (uint64, error) {
+	rng, stepStr, hasStep := strings.Cut(s, "/")
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepStr)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("expected positive step, got '%s'", stepStr)
+		}
+	}
+
+	lo, hi := f.min, f.max
+	switch {
+	case rng == "*":
+	case strings.Contains(rng, "-"):
+		loStr, hiStr, _ := strings.Cut(rng, "-")
+		var err error
+		if lo, err = f.parseValue(loStr); err != nil {
+			return 0, err
+		}
+		if hi, err = f.parseValue(hiStr); err != nil {
+			return 0, err
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("expected range start not greater than end, got %d-%d", lo, hi)
+		}
+	default:
+		var err error
+		if lo, err = f.parseValue(rng); err != nil {
+			return 0, err
+		}
+		// Одиночное значение с шагом (a/n) означает диапазон от a до конца поля.
+		if !hasStep {
+			hi = lo
+		}
+	}
+
+	var set uint64
+	for v := lo; v <= hi; v += step {
+		set |= 1 << uint(v)
+	}
+	return set, nil
+}
+
+func (f cronField) parseValue(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("expected number, got '%s'", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("expected value between %d and %d, got %d", f.min, f.max, v)
+	}
+	return v, nil
+}
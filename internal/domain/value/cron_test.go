@@ -0,0 +1,56 @@
+package value_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func TestCronExprFromString(t *testing.T) {
+	for _, s := range []string{"* * * * *", "*/15 0-6 1,15 jan-jun mon-fri", "0 12 * * 7", "@daily", "5/10 * * * *"} {
+		_, err := value.CronExprFromString(s)
+		require.NoError(t, err, s)
+	}
+	for _, s := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "@never"} {
+		_, err := value.CronExprFromString(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestCronExpr_Next(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	from := time.Date(2025, 1, 31, 23, 59, 30, 0, moscow) // Пятница.
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 2, 1, 0, 0, 0, 0, moscow)},
+		{"@daily", time.Date(2025, 2, 1, 0, 0, 0, 0, moscow)},
+		{"30 2 * * *", time.Date(2025, 2, 1, 2, 30, 0, 0, moscow)},
+		{"0 9 * * mon-fri", time.Date(2025, 2, 3, 9, 0, 0, 0, moscow)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, moscow)},
+		// Ограничены и день месяца, и день недели: подходит любой из них.
+		{"0 0 15 * sun", time.Date(2025, 2, 2, 0, 0, 0, 0, moscow)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got := value.MustCronExprFromString(tt.expr).Next(from)
+			require.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestCronExpr_NextSkipsMissingLocalTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// 30 марта 2025 года в Берлине часы переводятся с 02:00 на 03:00.
+	got := value.MustCronExprFromString("30 2 * * *").Next(time.Date(2025, 3, 30, 0, 0, 0, 0, berlin))
+	require.Equal(t, time.Date(2025, 3, 31, 2, 30, 0, 0, berlin), got)
+}
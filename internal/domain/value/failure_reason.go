@@ -0,0 +1,61 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// FailureReason описывает причину неуспешного завершения задачи.
+type FailureReason struct {
+	s string
+}
+
+var (
+	FailureBuildFailed         = FailureReason{"build_failed"}
+	FailureTimeout             = FailureReason{"timeout"}
+	FailureOutOfMemory         = FailureReason{"out_of_memory"}
+	FailureOutputParseFailed   = FailureReason{"output_parse_failed"}
+	FailureRuntimeError        = FailureReason{"runtime_error"}
+	FailureInfrastructureError = FailureReason{"infrastructure_error"}
+	FailureInterrupted         = FailureReason{"interrupted"}        // Исполнитель задачи аварийно завершился
+	FailureInputFileMissing    = FailureReason{"input_file_missing"} // Входной файл задачи удалён
+)
+
+func FailureReasonFromString(s string) (FailureReason, error) {
+	switch s {
+	case "build_failed":
+		return FailureBuildFailed, nil
+	case "timeout":
+		return FailureTimeout, nil
+	case "out_of_memory":
+		return FailureOutOfMemory, nil
+	case "output_parse_failed":
+		return FailureOutputParseFailed, nil
+	case "runtime_error":
+		return FailureRuntimeError, nil
+	case "infrastructure_error":
+		return FailureInfrastructureError, nil
+	case "interrupted":
+		return FailureInterrupted, nil
+	case "input_file_missing":
+		return FailureInputFileMissing, nil
+	}
+	return FailureReason{}, domain.NewInvalidInputError(
+		"failure-reason-invalid",
+		fmt.Sprintf(
+			"invalid failure reason: expected one of ['build_failed', 'timeout', 'out_of_memory', "+
+				"'output_parse_failed', 'runtime_error', 'infrastructure_error', 'interrupted', "+
+				"'input_file_missing'], got '%s'",
+			s,
+		),
+	)
+}
+
+func (r FailureReason) String() string {
+	return r.s
+}
+
+func (r FailureReason) IsZero() bool {
+	return r.s == ""
+}
@@ -3,6 +3,8 @@ package value
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/bmstu-itstech/scriptum-back/internal/domain"
 )
@@ -12,9 +14,26 @@ type Field struct {
 	name string
 	desc *string
 	unit *string
+	// options -- допустимые значения поля типа EnumValueType; для остальных типов -- nil.
+	options []string
+	// constraints -- ограничения значений входного поля.
+	constraints FieldConstraints
+	// def -- значение необязательного входного поля, если оно опущено; для обязательных полей -- nil.
+	def *Value
 }
 
-func NewField(t Type, name string, desc *string, unit *string) (Field, error) {
+// MaxFieldOptions -- наибольшее число вариантов значения поля-перечисления.
+const MaxFieldOptions = 256
+
+func NewField(
+	t Type,
+	name string,
+	desc *string,
+	unit *string,
+	options []string,
+	constraints FieldConstraints,
+	def *string,
+) (Field, error) {
 	if t.IsZero() {
 		return Field{}, errors.New("field type is zero")
 	}
@@ -31,21 +50,115 @@ func NewField(t Type, name string, desc *string, unit *string) (Field, error) {
 		return Field{}, errors.New("field unit is not nil but empty")
 	}
 
-	return Field{
-		t:    t,
-		name: name,
-		desc: desc,
-		unit: unit,
-	}, nil
+	if err := validateOptions(t, name, options); err != nil {
+		return Field{}, err
+	}
+	if len(options) == 0 {
+		options = nil
+	}
+
+	f := Field{
+		t:           t,
+		name:        name,
+		desc:        desc,
+		unit:        unit,
+		options:     options,
+		constraints: constraints,
+	}
+
+	if def != nil && t == FileValueType {
+		// Файл по умолчанию принадлежал бы автору Blueprint, а не запускающему задачу пользователю.
+		return Field{}, domain.NewInvalidFieldError(
+			name, "field-default-unsupported", "default values are not supported for file fields",
+		)
+	}
+	if def != nil {
+		v, err := NewValue(t, *def)
+		if err == nil {
+			err = f.Validate(v)
+		}
+		if err != nil {
+			return Field{}, domain.NewInvalidFieldError(
+				name, "field-default-invalid", fmt.Sprintf("invalid default value: %s", err.Error()),
+			)
+		}
+		f.def = &v
+	}
+
+	return f, nil
 }
 
+func validateOptions(t Type, name string, options []string) error {
+	if t != EnumValueType {
+		if len(options) > 0 {
+			return domain.NewInvalidInputError(
+				"field-unexpected-options",
+				fmt.Sprintf("field '%s': options are allowed for enum fields only", name),
+			)
+		}
+		return nil
+	}
+
+	if len(options) == 0 {
+		return domain.NewInvalidInputError(
+			"field-empty-options", fmt.Sprintf("field '%s': expected at least one enum option", name),
+		)
+	}
+	if len(options) > MaxFieldOptions {
+		return domain.NewInvalidInputError(
+			"field-too-many-options",
+			fmt.Sprintf("field '%s': expected at most %d enum options, got %d", name, MaxFieldOptions, len(options)),
+		)
+	}
+	seen := make(map[string]struct{}, len(options))
+	for _, o := range options {
+		if o == "" {
+			return domain.NewInvalidInputError(
+				"field-empty-option", fmt.Sprintf("field '%s': expected not empty enum option", name),
+			)
+		}
+		if _, ok := seen[o]; ok {
+			return domain.NewInvalidInputError(
+				"field-duplicate-option", fmt.Sprintf("field '%s': duplicate enum option '%s'", name, o),
+			)
+		}
+		seen[o] = struct{}{}
+	}
+	return nil
+}
+
+// Validate проверяет значение v по типу, вариантам перечисления и ограничениям поля. Ошибки относятся к полю.
 func (f Field) Validate(v Value) error {
 	if f.t != v.t {
-		return domain.NewInvalidInputError(
+		return domain.NewInvalidFieldError(
+			f.name,
 			"field-mismatch",
 			fmt.Sprintf("field type mismatch: expected '%s', got '%s'", f.t.String(), v.t.String()),
 		)
 	}
+	if f.t == EnumValueType && !slices.Contains(f.options, v.s) {
+		return domain.NewInvalidFieldError(
+			f.name,
+			"field-option-invalid",
+			fmt.Sprintf(
+				"invalid enum value: expected one of ['%s'], got '%s'", strings.Join(f.options, "', '"), v.s,
+			),
+		)
+	}
+	return f.constraints.Check(f.name, v)
+}
+
+// ValidateSource проверяет, что значения поля src можно передать в поле f: типы полей совпадают, а каждый
+// вариант перечисления src допустим и для f. Ограничения f проверяются при запуске задачи по самому значению.
+func (f Field) ValidateSource(src Field) error {
+	if f.t != src.t {
+		return f.Validate(Value{t: src.t})
+	}
+	for _, o := range src.options {
+		if err := f.Validate(Value{t: src.t, s: o}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -64,3 +177,22 @@ func (f Field) Desc() *string {
 func (f Field) Unit() *string {
 	return f.unit
 }
+
+// Options возвращает допустимые значения поля-перечисления; для полей остальных типов -- nil.
+func (f Field) Options() []string {
+	return f.options
+}
+
+func (f Field) Constraints() FieldConstraints {
+	return f.constraints
+}
+
+// Default возвращает значение, которое подставляется вместо опущенного значения поля, либо nil.
+func (f Field) Default() *Value {
+	return f.def
+}
+
+// IsOptional сообщает, что значение поля можно опустить: у поля есть значение по умолчанию.
+func (f Field) IsOptional() bool {
+	return f.def != nil
+}
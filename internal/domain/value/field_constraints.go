@@ -0,0 +1,180 @@
+package value
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// MaxFieldPatternLength ограничивает длину регулярного выражения ограничения поля.
+const MaxFieldPatternLength = 1024
+
+// FieldConstraints -- ограничения на значения входного поля: границы для integer и real, длина и регулярное
+// выражение для string. Неуказанные ограничения не проверяются; нулевое значение не ограничивает ничего.
+type FieldConstraints struct {
+	min       *string
+	max       *string
+	minLength *int
+	maxLength *int
+	pattern   *string
+	re        *regexp.Regexp
+}
+
+// NewFieldConstraints проверяет, что ограничения применимы к полю name типа t и не противоречат друг другу.
+// Ошибки относятся к полю name. Регулярное выражение pattern должно совпадать со значением целиком.
+func NewFieldConstraints(
+	t Type, name string, minValue, maxValue *string, minLength, maxLength *int, pattern *string,
+) (FieldConstraints, error) {
+	numeric := t == IntegerValueType || t == RealValueType
+	if (minValue != nil || maxValue != nil) && !numeric {
+		return FieldConstraints{}, domain.NewInvalidFieldError(
+			name, "field-constraint-unsupported",
+			fmt.Sprintf("min and max are supported for integer and real fields only, got '%s'", t.String()),
+		)
+	}
+	if (minLength != nil || maxLength != nil || pattern != nil) && t != StringValueType {
+		return FieldConstraints{}, domain.NewInvalidFieldError(
+			name, "field-constraint-unsupported",
+			fmt.Sprintf("length and pattern are supported for string fields only, got '%s'", t.String()),
+		)
+	}
+
+	c := FieldConstraints{min: minValue, max: maxValue, minLength: minLength, maxLength: maxLength, pattern: pattern}
+
+	for _, bound := range []*string{minValue, maxValue} {
+		if bound == nil {
+			continue
+		}
+		// NaN и бесконечность -- корректные значения real, но не границы: NaN несравнимо ни с каким значением.
+		if _, err := NewValue(t, *bound); err != nil || !isFiniteBound(t, *bound) {
+			return FieldConstraints{}, domain.NewInvalidFieldError(
+				name, "field-constraint-invalid",
+				fmt.Sprintf("invalid bound: expected %s, got '%s'", t.String(), *bound),
+			)
+		}
+	}
+	if minValue != nil && maxValue != nil && compareNumbers(t, *minValue, *maxValue) > 0 {
+		return FieldConstraints{}, domain.NewInvalidFieldError(
+			name, "field-constraint-invalid", fmt.Sprintf("expected min <= max, got %s > %s", *minValue, *maxValue),
+		)
+	}
+
+	if minLength != nil && *minLength < 0 {
+		return FieldConstraints{}, domain.NewInvalidFieldError(
+			name, "field-constraint-invalid", fmt.Sprintf("expected non-negative min length, got %d", *minLength),
+		)
+	}
+	if maxLength != nil && *maxLength < 0 {
+		return FieldConstraints{}, domain.NewInvalidFieldError(
+			name, "field-constraint-invalid", fmt.Sprintf("expected non-negative max length, got %d", *maxLength),
+		)
+	}
+	if minLength != nil && maxLength != nil && *minLength > *maxLength {
+		return FieldConstraints{}, domain.NewInvalidFieldError(
+			name, "field-constraint-invalid",
+			fmt.Sprintf("expected min length <= max length, got %d > %d", *minLength, *maxLength),
+		)
+	}
+
+	if pattern != nil {
+		if len(*pattern) > MaxFieldPatternLength {
+			return FieldConstraints{}, domain.NewInvalidFieldError(
+				name, "field-constraint-invalid",
+				fmt.Sprintf("expected pattern of at most %d bytes, got %d", MaxFieldPatternLength, len(*pattern)),
+			)
+		}
+		re, err := regexp.Compile(`^(?:` + *pattern + `)$`)
+		if err != nil {
+			return FieldConstraints{}, domain.NewInvalidFieldError(
+				name, "field-constraint-invalid", fmt.Sprintf("invalid pattern: %s", err.Error()),
+			)
+		}
+		c.re = re
+	}
+
+	return c, nil
+}
+
+// Check проверяет значение v того же типа, что и поле, по ограничениям. Ошибки относятся к полю field.
+func (c FieldConstraints) Check(field string, v Value) error {
+	if c.min != nil && compareNumbers(v.t, v.s, *c.min) < 0 {
+		return domain.NewInvalidFieldError(
+			field, "field-value-below-min", fmt.Sprintf("expected value >= %s, got %s", *c.min, v.s),
+		)
+	}
+	if c.max != nil && compareNumbers(v.t, v.s, *c.max) > 0 {
+		return domain.NewInvalidFieldError(
+			field, "field-value-above-max", fmt.Sprintf("expected value <= %s, got %s", *c.max, v.s),
+		)
+	}
+
+	n := utf8.RuneCountInString(v.s)
+	if c.minLength != nil && n < *c.minLength {
+		return domain.NewInvalidFieldError(
+			field, "field-value-too-short",
+			fmt.Sprintf("expected at least %d characters, got %d", *c.minLength, n),
+		)
+	}
+	if c.maxLength != nil && n > *c.maxLength {
+		return domain.NewInvalidFieldError(
+			field, "field-value-too-long",
+			fmt.Sprintf("expected at most %d characters, got %d", *c.maxLength, n),
+		)
+	}
+	if c.re != nil && !c.re.MatchString(v.s) {
+		return domain.NewInvalidFieldError(
+			field, "field-value-pattern-mismatch",
+			fmt.Sprintf("value '%s' does not match pattern '%s'", v.s, *c.pattern),
+		)
+	}
+	return nil
+}
+
+func isFiniteBound(t Type, s string) bool {
+	if t != RealValueType {
+		return true
+	}
+	f, _ := strconv.ParseFloat(s, 64)
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// compareNumbers сравнивает корректные записи a и b чисел типа t и возвращает -1, 0 или 1.
+func compareNumbers(t Type, a, b string) int {
+	if t == IntegerValueType {
+		x, _ := strconv.ParseInt(a, 10, 64)
+		y, _ := strconv.ParseInt(b, 10, 64)
+		return cmp.Compare(x, y)
+	}
+	x, _ := strconv.ParseFloat(a, 64)
+	y, _ := strconv.ParseFloat(b, 64)
+	return cmp.Compare(x, y)
+}
+
+func (c FieldConstraints) IsZero() bool {
+	return c.min == nil && c.max == nil && c.minLength == nil && c.maxLength == nil && c.pattern == nil
+}
+
+func (c FieldConstraints) Min() *string {
+	return c.min
+}
+
+func (c FieldConstraints) Max() *string {
+	return c.max
+}
+
+func (c FieldConstraints) MinLength() *int {
+	return c.minLength
+}
+
+func (c FieldConstraints) MaxLength() *int {
+	return c.maxLength
+}
+
+func (c FieldConstraints) Pattern() *string {
+	return c.pattern
+}
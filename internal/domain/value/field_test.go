@@ -0,0 +1,175 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func TestNewBooleanValue(t *testing.T) {
+	t.Run("canonical form", func(t *testing.T) {
+		v, err := value.NewBooleanValue("True")
+		require.NoError(t, err)
+		require.Equal(t, "true", v.String())
+	})
+
+	t.Run("invalid boolean is rejected", func(t *testing.T) {
+		_, err := value.NewBooleanValue("yes")
+		require.Error(t, err)
+	})
+}
+
+func TestNewFileValue(t *testing.T) {
+	t.Run("file ID", func(t *testing.T) {
+		id := value.NewFileID()
+		v, err := value.NewFileValue(string(id))
+		require.NoError(t, err)
+		got, ok := v.FileID()
+		require.True(t, ok)
+		require.Equal(t, id, got)
+	})
+
+	t.Run("path is rejected", func(t *testing.T) {
+		_, err := value.NewFileValue("../../x")
+		require.Error(t, err)
+	})
+}
+
+func TestFieldValidate(t *testing.T) {
+	options := []string{"linear", "cubic", "spline"}
+	f, err := value.NewField(value.EnumValueType, "method", nil, nil, options, value.FieldConstraints{}, nil)
+	require.NoError(t, err)
+
+	t.Run("enum option is accepted", func(t *testing.T) {
+		v, err := value.NewEnumValue("cubic")
+		require.NoError(t, err)
+		require.NoError(t, f.Validate(v))
+	})
+
+	t.Run("unknown enum option is rejected", func(t *testing.T) {
+		v, err := value.NewEnumValue("quadratic")
+		require.NoError(t, err)
+		require.Error(t, f.Validate(v))
+	})
+
+	t.Run("string value is rejected", func(t *testing.T) {
+		require.Error(t, f.Validate(value.NewStringValue("cubic")))
+	})
+
+	t.Run("enum source must be a subset", func(t *testing.T) {
+		subset := []string{"linear", "cubic"}
+		src, err := value.NewField(value.EnumValueType, "m", nil, nil, subset, value.FieldConstraints{}, nil)
+		require.NoError(t, err)
+		require.NoError(t, f.ValidateSource(src))
+		require.Error(t, src.ValidateSource(f))
+	})
+}
+
+func TestNewFieldOptions(t *testing.T) {
+	t.Run("enum without options is rejected", func(t *testing.T) {
+		_, err := value.NewField(value.EnumValueType, "method", nil, nil, nil, value.FieldConstraints{}, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate options are rejected", func(t *testing.T) {
+		options := []string{"linear", "linear"}
+		_, err := value.NewField(value.EnumValueType, "method", nil, nil, options, value.FieldConstraints{}, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("options of non-enum field are rejected", func(t *testing.T) {
+		options := []string{"true"}
+		_, err := value.NewField(value.BooleanValueType, "flag", nil, nil, options, value.FieldConstraints{}, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestFieldConstraints(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	n := func(n int) *int { return &n }
+
+	t.Run("integer bounds", func(t *testing.T) {
+		c, err := value.NewFieldConstraints(value.IntegerValueType, "sample_size", ptr("1"), ptr("100"), nil, nil, nil)
+		require.NoError(t, err)
+		f, err := value.NewField(value.IntegerValueType, "sample_size", nil, nil, nil, c, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, f.Validate(value.MustNewIntegerValue("100")))
+
+		err = f.Validate(value.MustNewIntegerValue("-5"))
+		var iiErr domain.InvalidInputError
+		require.ErrorAs(t, err, &iiErr)
+		require.Equal(t, "field-value-below-min", iiErr.Code)
+		require.Equal(t, "sample_size", iiErr.Field)
+	})
+
+	t.Run("string length and pattern", func(t *testing.T) {
+		c, err := value.NewFieldConstraints(value.StringValueType, "code", nil, nil, n(2), n(4), ptr("[a-z]+"))
+		require.NoError(t, err)
+		f, err := value.NewField(value.StringValueType, "code", nil, nil, nil, c, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, f.Validate(value.NewStringValue("abc")))
+		require.Error(t, f.Validate(value.NewStringValue("a")))
+		require.Error(t, f.Validate(value.NewStringValue("abcde")))
+		require.Error(t, f.Validate(value.NewStringValue("ab1")))
+	})
+
+	t.Run("min greater than max is rejected", func(t *testing.T) {
+		_, err := value.NewFieldConstraints(value.RealValueType, "x", ptr("1.5"), ptr("0.5"), nil, nil, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("error refers to the field", func(t *testing.T) {
+		_, err := value.NewFieldConstraints(value.RealValueType, "tol", ptr("1.5"), ptr("0.5"), nil, nil, nil)
+		var iiErr domain.InvalidInputError
+		require.ErrorAs(t, err, &iiErr)
+		require.Equal(t, "field-constraint-invalid", iiErr.Code)
+		require.Equal(t, "tol", iiErr.Field)
+	})
+
+	t.Run("non-finite real bounds are rejected", func(t *testing.T) {
+		for _, bound := range []string{"NaN", "Inf", "+Inf", "-Inf", "1e400"} {
+			_, err := value.NewFieldConstraints(value.RealValueType, "x", ptr(bound), nil, nil, nil, nil)
+			require.Error(t, err, bound)
+			_, err = value.NewFieldConstraints(value.RealValueType, "x", nil, ptr(bound), nil, nil, nil)
+			require.Error(t, err, bound)
+		}
+	})
+
+	t.Run("length of integer field is rejected", func(t *testing.T) {
+		_, err := value.NewFieldConstraints(value.IntegerValueType, "x", nil, nil, n(1), nil, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid pattern is rejected", func(t *testing.T) {
+		_, err := value.NewFieldConstraints(value.StringValueType, "x", nil, nil, nil, nil, ptr("[a-"))
+		require.Error(t, err)
+	})
+}
+
+func TestFieldDefault(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	t.Run("default makes field optional", func(t *testing.T) {
+		f, err := value.NewField(value.RealValueType, "tol", nil, nil, nil, value.FieldConstraints{}, ptr("0.01"))
+		require.NoError(t, err)
+		require.True(t, f.IsOptional())
+		require.Equal(t, "0.01", f.Default().String())
+	})
+
+	t.Run("default of wrong type is rejected", func(t *testing.T) {
+		_, err := value.NewField(value.IntegerValueType, "n", nil, nil, nil, value.FieldConstraints{}, ptr("abc"))
+		require.Error(t, err)
+	})
+
+	t.Run("default violating constraints is rejected", func(t *testing.T) {
+		c, err := value.NewFieldConstraints(value.IntegerValueType, "n", ptr("1"), nil, nil, nil, nil)
+		require.NoError(t, err)
+		_, err = value.NewField(value.IntegerValueType, "n", nil, nil, nil, c, ptr("0"))
+		require.Error(t, err)
+	})
+}
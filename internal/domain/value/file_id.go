@@ -1,5 +1,12 @@
 package value
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
 const FileIDLength = 8
 
 type FileID string
@@ -7,3 +14,14 @@ type FileID string
 func NewFileID() FileID {
 	return FileID(NewShortUUID(FileIDLength))
 }
+
+// FileIDFromString проверяет, что s -- корректный ID файла. ID используется в путях хранилища и контейнера
+// задачи, поэтому допускаются только символы алфавита NewShortUUID.
+func FileIDFromString(s string) (FileID, error) {
+	if len(s) != FileIDLength || strings.Trim(s, alphabet) != "" {
+		return "", domain.NewInvalidInputError(
+			"file-id-invalid", fmt.Sprintf("expected file ID of %d alphanumeric characters, got '%s'", FileIDLength, s),
+		)
+	}
+	return FileID(s), nil
+}
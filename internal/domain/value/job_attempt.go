@@ -0,0 +1,37 @@
+package value
+
+import "time"
+
+// JobAttempt -- завершённая попытка выполнения задачи.
+type JobAttempt struct {
+	number     int
+	startedAt  time.Time
+	finishedAt time.Time
+	result     JobResult
+}
+
+func NewJobAttempt(number int, startedAt time.Time, finishedAt time.Time, result JobResult) JobAttempt {
+	return JobAttempt{
+		number:     number,
+		startedAt:  startedAt,
+		finishedAt: finishedAt,
+		result:     result,
+	}
+}
+
+// Number возвращает номер попытки, начиная с 1.
+func (a JobAttempt) Number() int {
+	return a.number
+}
+
+func (a JobAttempt) StartedAt() time.Time {
+	return a.startedAt
+}
+
+func (a JobAttempt) FinishedAt() time.Time {
+	return a.finishedAt
+}
+
+func (a JobAttempt) Result() JobResult {
+	return a.result
+}
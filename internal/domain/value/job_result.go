@@ -4,9 +4,10 @@ type JobResult struct {
 	code    ExitCode
 	output  []Value
 	message *string
+	reason  FailureReason
 }
 
-func NewJobResult(code ExitCode, output []Value, message *string) JobResult {
+func NewJobResult(code ExitCode, output []Value, message *string, reason FailureReason) JobResult {
 	if output == nil {
 		output = make([]Value, 0)
 	}
@@ -14,15 +15,16 @@ func NewJobResult(code ExitCode, output []Value, message *string) JobResult {
 		code:    code,
 		output:  output,
 		message: message,
+		reason:  reason,
 	}
 }
 
 func NewSuccessJobResult(out []Value) JobResult {
-	return NewJobResult(0, out, nil)
+	return NewJobResult(0, out, nil, FailureReason{})
 }
 
-func NewFailureJobResult(code ExitCode, message string) JobResult {
-	return NewJobResult(code, nil, &message)
+func NewFailureJobResult(reason FailureReason, code ExitCode, message string) JobResult {
+	return NewJobResult(code, nil, &message, reason)
 }
 
 func (r JobResult) Code() ExitCode {
@@ -36,3 +38,8 @@ func (r JobResult) Output() []Value {
 func (r JobResult) Message() *string {
 	return r.message
 }
+
+// Reason возвращает причину неуспешного завершения; для успешного результата -- нулевое значение.
+func (r JobResult) Reason() FailureReason {
+	return r.reason
+}
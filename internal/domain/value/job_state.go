@@ -11,9 +11,10 @@ type JobState struct {
 }
 
 var (
-	JobPending  = JobState{"pending"}
-	JobRunning  = JobState{"running"}
-	JobFinished = JobState{"finished"}
+	JobPending   = JobState{"pending"}
+	JobRunning   = JobState{"running"}
+	JobFinished  = JobState{"finished"}
+	JobCancelled = JobState{"cancelled"}
 )
 
 func JobStateFromString(s string) (JobState, error) {
@@ -24,10 +25,12 @@ func JobStateFromString(s string) (JobState, error) {
 		return JobRunning, nil
 	case "finished":
 		return JobFinished, nil
+	case "cancelled":
+		return JobCancelled, nil
 	}
 	return JobPending, domain.NewInvalidInputError(
 		"job-state-invalid",
-		fmt.Sprintf("invalid job state: expected one of ['pending', 'running', 'finished'], got '%s'", s),
+		fmt.Sprintf("invalid job state: expected one of ['pending', 'running', 'finished', 'cancelled'], got '%s'", s),
 	)
 }
 
@@ -38,3 +41,8 @@ func (j JobState) String() string {
 func (j JobState) IsZero() bool {
 	return j.s == ""
 }
+
+// IsTerminal сообщает, что задача больше не изменит своего состояния.
+func (j JobState) IsTerminal() bool {
+	return j == JobFinished || j == JobCancelled
+}
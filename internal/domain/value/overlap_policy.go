@@ -0,0 +1,44 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// OverlapPolicy определяет, что делает расписание, если задача прошлого срабатывания ещё не завершена.
+type OverlapPolicy struct {
+	s string
+}
+
+var (
+	// OverlapAllow -- запустить новую задачу параллельно с предыдущей.
+	OverlapAllow = OverlapPolicy{"allow"}
+	// OverlapForbid -- пропустить срабатывание.
+	OverlapForbid = OverlapPolicy{"forbid"}
+	// OverlapReplace -- отменить предыдущую задачу и запустить новую.
+	OverlapReplace = OverlapPolicy{"replace"}
+)
+
+func OverlapPolicyFromString(s string) (OverlapPolicy, error) {
+	switch s {
+	case "allow":
+		return OverlapAllow, nil
+	case "forbid":
+		return OverlapForbid, nil
+	case "replace":
+		return OverlapReplace, nil
+	}
+	return OverlapPolicy{}, domain.NewInvalidInputError(
+		"overlap-policy-invalid",
+		fmt.Sprintf("invalid overlap policy: expected one of ['allow', 'forbid', 'replace'], got '%s'", s),
+	)
+}
+
+func (p OverlapPolicy) String() string {
+	return p.s
+}
+
+func (p OverlapPolicy) IsZero() bool {
+	return p.s == ""
+}
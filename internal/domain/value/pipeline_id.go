@@ -0,0 +1,17 @@
+package value
+
+const PipelineIDLength = 8
+
+type PipelineID string
+
+func NewPipelineID() PipelineID {
+	return PipelineID(NewShortUUID(PipelineIDLength))
+}
+
+const PipelineRunIDLength = 8
+
+type PipelineRunID string
+
+func NewPipelineRunID() PipelineRunID {
+	return PipelineRunID(NewShortUUID(PipelineRunIDLength))
+}
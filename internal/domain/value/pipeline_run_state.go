@@ -0,0 +1,43 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// PipelineRunState -- состояние запуска конвейера. Запуск завершается, когда завершены все его шаги.
+type PipelineRunState struct {
+	s string
+}
+
+var (
+	PipelineRunning = PipelineRunState{"running"}
+	// PipelineSucceeded -- все шаги завершились успешно.
+	PipelineSucceeded = PipelineRunState{"succeeded"}
+	// PipelineFailed -- хотя бы один шаг не выполнен.
+	PipelineFailed = PipelineRunState{"failed"}
+)
+
+func PipelineRunStateFromString(s string) (PipelineRunState, error) {
+	switch s {
+	case "running":
+		return PipelineRunning, nil
+	case "succeeded":
+		return PipelineSucceeded, nil
+	case "failed":
+		return PipelineFailed, nil
+	}
+	return PipelineRunState{}, domain.NewInvalidInputError(
+		"pipeline-run-state-invalid",
+		fmt.Sprintf("invalid pipeline run state: expected one of ['running', 'succeeded', 'failed'], got '%s'", s),
+	)
+}
+
+func (s PipelineRunState) String() string {
+	return s.s
+}
+
+func (s PipelineRunState) IsZero() bool {
+	return s.s == ""
+}
@@ -0,0 +1,142 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// MaxPipelineSteps ограничивает число шагов одного конвейера.
+const MaxPipelineSteps = 32
+
+// StepInput -- источник значения входного поля шага конвейера: постоянное значение либо выходное поле
+// одного из шагов, от которых зависит этот шаг.
+type StepInput struct {
+	value *Value
+	step  string
+	field int
+}
+
+func NewConstStepInput(v Value) StepInput {
+	return StepInput{value: &v}
+}
+
+// NewOutputStepInput возвращает источник, ссылающийся на выходное поле с индексом field шага step.
+func NewOutputStepInput(step string, field int) (StepInput, error) {
+	if step == "" {
+		return StepInput{}, domain.NewInvalidInputError(
+			"step-input-empty-step", "expected not empty source step name",
+		)
+	}
+	if field < 0 {
+		return StepInput{}, domain.NewInvalidInputError(
+			"step-input-negative-field", fmt.Sprintf("expected non-negative source field index, got %d", field),
+		)
+	}
+	return StepInput{step: step, field: field}, nil
+}
+
+// Const возвращает постоянное значение; false, если источник -- выходное поле шага.
+func (i StepInput) Const() (Value, bool) {
+	if i.value == nil {
+		return Value{}, false
+	}
+	return *i.value, true
+}
+
+// Step возвращает имя шага-источника; пустое для постоянного значения.
+func (i StepInput) Step() string {
+	return i.step
+}
+
+// Field возвращает индекс выходного поля шага-источника.
+func (i StepInput) Field() int {
+	return i.field
+}
+
+// PipelineStep -- шаг конвейера: задача Blueprint, входные данные которой задаются источниками Input,
+// по одному на каждое входное поле Blueprint.
+type PipelineStep struct {
+	name        string
+	blueprintID BlueprintID
+	input       []StepInput
+}
+
+func NewPipelineStep(name string, blueprintID BlueprintID, input []StepInput) (PipelineStep, error) {
+	if name == "" {
+		return PipelineStep{}, domain.NewInvalidInputError("pipeline-step-empty-name", "expected not empty step name")
+	}
+	if blueprintID == "" {
+		return PipelineStep{}, domain.NewInvalidInputError(
+			"pipeline-step-empty-blueprint-id", "expected not empty step blueprint ID",
+		)
+	}
+	if input == nil {
+		input = make([]StepInput, 0)
+	}
+	return PipelineStep{
+		name:        name,
+		blueprintID: blueprintID,
+		input:       input,
+	}, nil
+}
+
+func (s PipelineStep) Name() string {
+	return s.name
+}
+
+func (s PipelineStep) BlueprintID() BlueprintID {
+	return s.blueprintID
+}
+
+func (s PipelineStep) Input() []StepInput {
+	return s.input
+}
+
+// Dependencies возвращает имена шагов, выходные поля которых используются шагом, без повторов.
+func (s PipelineStep) Dependencies() []string {
+	res := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, in := range s.input {
+		if in.step == "" || seen[in.step] {
+			continue
+		}
+		seen[in.step] = true
+		res = append(res, in.step)
+	}
+	return res
+}
+
+// StepRun -- состояние шага в запуске конвейера. Message поясняет, почему шаг не выполнен.
+type StepRun struct {
+	name    string
+	state   StepState
+	jobID   *JobID
+	message *string
+}
+
+func NewStepRun(name string, state StepState, jobID *JobID, message *string) StepRun {
+	return StepRun{
+		name:    name,
+		state:   state,
+		jobID:   jobID,
+		message: message,
+	}
+}
+
+func (s StepRun) Name() string {
+	return s.name
+}
+
+func (s StepRun) State() StepState {
+	return s.state
+}
+
+// JobID возвращает ID задачи шага либо nil, если задача не запускалась.
+func (s StepRun) JobID() *JobID {
+	return s.jobID
+}
+
+func (s StepRun) Message() *string {
+	return s.message
+}
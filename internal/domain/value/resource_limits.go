@@ -0,0 +1,119 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// ResourceLimits описывает ограничения ресурсов контейнера задачи. Нулевое значение поля означает отсутствие
+// собственного ограничения: в этом случае действует глобальное ограничение, заданное администратором.
+type ResourceLimits struct {
+	memoryMB int64
+	cpus     float64
+	pids     int64
+	tmpfsMB  int64
+}
+
+func NewResourceLimits(memoryMB int64, cpus float64, pids int64, tmpfsMB int64) (ResourceLimits, error) {
+	if memoryMB < 0 {
+		return ResourceLimits{}, domain.NewInvalidInputError(
+			"resource-limits-negative-memory", fmt.Sprintf("expected non-negative memory limit, got %d", memoryMB),
+		)
+	}
+
+	if cpus < 0 {
+		return ResourceLimits{}, domain.NewInvalidInputError(
+			"resource-limits-negative-cpus", fmt.Sprintf("expected non-negative CPU limit, got %g", cpus),
+		)
+	}
+
+	if pids < 0 {
+		return ResourceLimits{}, domain.NewInvalidInputError(
+			"resource-limits-negative-pids", fmt.Sprintf("expected non-negative PIDs limit, got %d", pids),
+		)
+	}
+
+	if tmpfsMB < 0 {
+		return ResourceLimits{}, domain.NewInvalidInputError(
+			"resource-limits-negative-tmpfs", fmt.Sprintf("expected non-negative tmpfs size, got %d", tmpfsMB),
+		)
+	}
+
+	return ResourceLimits{
+		memoryMB: memoryMB,
+		cpus:     cpus,
+		pids:     pids,
+		tmpfsMB:  tmpfsMB,
+	}, nil
+}
+
+func MustNewResourceLimits(memoryMB int64, cpus float64, pids int64, tmpfsMB int64) ResourceLimits {
+	l, err := NewResourceLimits(memoryMB, cpus, pids, tmpfsMB)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// CheckWithin возвращает ошибку, если хотя бы одно из заданных ограничений превышает соответствующее
+// ограничение caps. Нулевые поля caps не ограничивают.
+func (l ResourceLimits) CheckWithin(caps ResourceLimits) error {
+	switch {
+	case caps.memoryMB > 0 && l.memoryMB > caps.memoryMB:
+		return domain.NewInvalidInputError(
+			"resource-limits-memory-exceeded",
+			fmt.Sprintf("memory limit %d MB exceeds maximum of %d MB", l.memoryMB, caps.memoryMB),
+		)
+	case caps.cpus > 0 && l.cpus > caps.cpus:
+		return domain.NewInvalidInputError(
+			"resource-limits-cpus-exceeded",
+			fmt.Sprintf("CPU limit %g exceeds maximum of %g", l.cpus, caps.cpus),
+		)
+	case caps.pids > 0 && l.pids > caps.pids:
+		return domain.NewInvalidInputError(
+			"resource-limits-pids-exceeded",
+			fmt.Sprintf("PIDs limit %d exceeds maximum of %d", l.pids, caps.pids),
+		)
+	case caps.tmpfsMB > 0 && l.tmpfsMB > caps.tmpfsMB:
+		return domain.NewInvalidInputError(
+			"resource-limits-tmpfs-exceeded",
+			fmt.Sprintf("tmpfs size %d MB exceeds maximum of %d MB", l.tmpfsMB, caps.tmpfsMB),
+		)
+	}
+	return nil
+}
+
+// Effective возвращает ограничения, которые следует применить к контейнеру: незаданные поля заменяются
+// значениями caps, превышающие caps -- ограничиваются ими.
+func (l ResourceLimits) Effective(caps ResourceLimits) ResourceLimits {
+	return ResourceLimits{
+		memoryMB: effectiveLimit(l.memoryMB, caps.memoryMB),
+		cpus:     effectiveLimit(l.cpus, caps.cpus),
+		pids:     effectiveLimit(l.pids, caps.pids),
+		tmpfsMB:  effectiveLimit(l.tmpfsMB, caps.tmpfsMB),
+	}
+}
+
+func effectiveLimit[T int64 | float64](v, limit T) T {
+	if v == 0 || (limit > 0 && v > limit) {
+		return limit
+	}
+	return v
+}
+
+func (l ResourceLimits) MemoryMB() int64 {
+	return l.memoryMB
+}
+
+func (l ResourceLimits) CPUs() float64 {
+	return l.cpus
+}
+
+func (l ResourceLimits) Pids() int64 {
+	return l.pids
+}
+
+func (l ResourceLimits) TmpfsMB() int64 {
+	return l.tmpfsMB
+}
@@ -0,0 +1,34 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func TestResourceLimits_Effective(t *testing.T) {
+	caps := value.MustNewResourceLimits(1024, 2, 0, 64)
+
+	t.Run("unset limits fall back to caps", func(t *testing.T) {
+		l := value.MustNewResourceLimits(0, 0, 0, 0).Effective(caps)
+		require.Equal(t, caps, l)
+	})
+
+	t.Run("limits above caps are clamped", func(t *testing.T) {
+		l := value.MustNewResourceLimits(4096, 1, 128, 32).Effective(caps)
+		require.Equal(t, int64(1024), l.MemoryMB())
+		require.InDelta(t, 1.0, l.CPUs(), 1e-9)
+		require.Equal(t, int64(128), l.Pids()) // Нулевое ограничение caps не ограничивает
+		require.Equal(t, int64(32), l.TmpfsMB())
+	})
+}
+
+func TestResourceLimits_CheckWithin(t *testing.T) {
+	caps := value.MustNewResourceLimits(1024, 2, 0, 64)
+
+	require.NoError(t, value.MustNewResourceLimits(512, 2, 1000, 0).CheckWithin(caps))
+	require.Error(t, value.MustNewResourceLimits(2048, 0, 0, 0).CheckWithin(caps))
+	require.Error(t, value.MustNewResourceLimits(0, 2.5, 0, 0).CheckWithin(caps))
+}
@@ -1,8 +1,13 @@
 package value
 
+// Result -- итог выполнения скрипта в контейнере. Вывод (stdout) содержит результат по протоколу
+// Blueprint, лог (stderr) -- отладочные сообщения и ошибки скрипта. Файлы выходных полей типа file
+// сохраняются отдельно и передаются по имени поля.
 type Result struct {
-	code   ExitCode
-	output string
+	code      ExitCode
+	output    string
+	log       string
+	artifacts map[string]FileID
 }
 
 func NewResult(code ExitCode) Result {
@@ -16,6 +21,17 @@ func (r Result) WithOutput(o string) Result {
 	return r
 }
 
+func (r Result) WithLog(l string) Result {
+	r.log = l
+	return r
+}
+
+// WithArtifacts задаёт ID сохранённых файлов выходных полей типа file по именам полей.
+func (r Result) WithArtifacts(a map[string]FileID) Result {
+	r.artifacts = a
+	return r
+}
+
 func (r Result) Code() ExitCode {
 	return r.code
 }
@@ -23,3 +39,11 @@ func (r Result) Code() ExitCode {
 func (r Result) Output() string {
 	return r.output
 }
+
+func (r Result) Log() string {
+	return r.log
+}
+
+func (r Result) Artifacts() map[string]FileID {
+	return r.artifacts
+}
@@ -0,0 +1,123 @@
+package value
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// MaxRetryAttempts -- наибольшее допустимое число попыток выполнения задачи.
+const MaxRetryAttempts = 10
+
+// RetryOn определяет, какие неуспешные завершения задачи повторяются.
+type RetryOn struct {
+	s string
+}
+
+var (
+	// RetryOnInfrastructure -- только сбои инфраструктуры и прерывание исполнителя.
+	RetryOnInfrastructure = RetryOn{"infrastructure"}
+	// RetryOnAnyFailure -- также ненулевой код возврата, превышение времени выполнения и нехватка памяти.
+	RetryOnAnyFailure = RetryOn{"any_failure"}
+)
+
+func RetryOnFromString(s string) (RetryOn, error) {
+	switch s {
+	case "infrastructure":
+		return RetryOnInfrastructure, nil
+	case "any_failure":
+		return RetryOnAnyFailure, nil
+	}
+	return RetryOn{}, domain.NewInvalidInputError(
+		"retry-on-invalid",
+		fmt.Sprintf("invalid retry condition: expected one of ['infrastructure', 'any_failure'], got '%s'", s),
+	)
+}
+
+func (r RetryOn) String() string {
+	return r.s
+}
+
+func (r RetryOn) IsZero() bool {
+	return r.s == ""
+}
+
+// RetryPolicy -- политика повторного запуска неуспешно завершившейся задачи. Нулевое значение -- единственная
+// попытка без повторов.
+type RetryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+	on          RetryOn
+}
+
+// NewRetryPolicy создаёт политику не более чем из maxAttempts попыток (включая первую) с задержкой backoff
+// перед первым повтором, удваивающейся перед каждым следующим. Нулевое on -- RetryOnInfrastructure.
+func NewRetryPolicy(maxAttempts int, backoff time.Duration, on RetryOn) (RetryPolicy, error) {
+	if maxAttempts < 0 || maxAttempts > MaxRetryAttempts {
+		return RetryPolicy{}, domain.NewInvalidInputError(
+			"retry-policy-invalid-max-attempts",
+			fmt.Sprintf("expected max attempts between 0 and %d, got %d", MaxRetryAttempts, maxAttempts),
+		)
+	}
+
+	if backoff < 0 {
+		return RetryPolicy{}, domain.NewInvalidInputError(
+			"retry-policy-negative-backoff", fmt.Sprintf("expected non-negative backoff, got %s", backoff),
+		)
+	}
+
+	if on.IsZero() {
+		on = RetryOnInfrastructure
+	}
+
+	return RetryPolicy{
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		on:          on,
+	}, nil
+}
+
+func MustNewRetryPolicy(maxAttempts int, backoff time.Duration, on RetryOn) RetryPolicy {
+	p, err := NewRetryPolicy(maxAttempts, backoff, on)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// ShouldRetry сообщает, нужно ли повторить задачу, попытка attempt (начиная с 1) которой завершилась
+// результатом res. Сбой сборки образа и неверный вывод скрипта не повторяются: повтор их не исправит.
+func (p RetryPolicy) ShouldRetry(attempt int, res JobResult) bool {
+	if attempt >= p.maxAttempts {
+		return false
+	}
+	switch res.Reason() {
+	case FailureInfrastructureError, FailureInterrupted:
+		return true
+	case FailureRuntimeError, FailureTimeout, FailureOutOfMemory:
+		return p.on == RetryOnAnyFailure
+	}
+	return false
+}
+
+// Delay возвращает задержку перед попыткой, следующей за attempt (начиная с 1).
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	return p.backoff << (attempt - 1)
+}
+
+// MaxAttempts возвращает наибольшее число попыток; значения 0 и 1 означают отсутствие повторов.
+func (p RetryPolicy) MaxAttempts() int {
+	return p.maxAttempts
+}
+
+func (p RetryPolicy) Backoff() time.Duration {
+	return p.backoff
+}
+
+func (p RetryPolicy) On() RetryOn {
+	if p.on.IsZero() {
+		return RetryOnInfrastructure
+	}
+	return p.on
+}
@@ -0,0 +1,45 @@
+package value_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	infra := value.NewFailureJobResult(value.FailureInfrastructureError, -1, "docker unavailable")
+	runtime := value.NewFailureJobResult(value.FailureRuntimeError, 1, "exception")
+	parse := value.NewFailureJobResult(value.FailureOutputParseFailed, -1, "invalid output")
+	missing := value.NewFailureJobResult(value.FailureInputFileMissing, -1, "input file not found")
+	success := value.NewSuccessJobResult(nil)
+
+	t.Run("zero policy never retries", func(t *testing.T) {
+		require.False(t, value.RetryPolicy{}.ShouldRetry(1, infra))
+	})
+
+	t.Run("infrastructure policy retries infrastructure errors only", func(t *testing.T) {
+		p := value.MustNewRetryPolicy(3, time.Second, value.RetryOnInfrastructure)
+		require.True(t, p.ShouldRetry(1, infra))
+		require.True(t, p.ShouldRetry(2, infra))
+		require.False(t, p.ShouldRetry(3, infra))
+		require.False(t, p.ShouldRetry(1, runtime))
+		require.False(t, p.ShouldRetry(1, success))
+	})
+
+	t.Run("any failure policy retries non-zero exit codes", func(t *testing.T) {
+		p := value.MustNewRetryPolicy(3, time.Second, value.RetryOnAnyFailure)
+		require.True(t, p.ShouldRetry(1, runtime))
+		require.False(t, p.ShouldRetry(1, parse))
+		require.False(t, p.ShouldRetry(1, missing))
+	})
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := value.MustNewRetryPolicy(4, 5*time.Second, value.RetryOnInfrastructure)
+	require.Equal(t, 5*time.Second, p.Delay(1))
+	require.Equal(t, 10*time.Second, p.Delay(2))
+	require.Equal(t, 20*time.Second, p.Delay(3))
+}
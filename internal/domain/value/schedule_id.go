@@ -0,0 +1,9 @@
+package value
+
+const ScheduleIDLength = 8
+
+type ScheduleID string
+
+func NewScheduleID() ScheduleID {
+	return ScheduleID(NewShortUUID(ScheduleIDLength))
+}
@@ -0,0 +1,59 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// StepState -- состояние шага запуска конвейера.
+type StepState struct {
+	s string
+}
+
+var (
+	// StepWaiting -- шаг ожидает завершения шагов, от которых зависит.
+	StepWaiting = StepState{"waiting"}
+	// StepRunning -- задача шага запущена и ещё не завершена.
+	StepRunning = StepState{"running"}
+	// StepSucceeded -- задача шага завершилась успешно.
+	StepSucceeded = StepState{"succeeded"}
+	// StepFailed -- задачу шага не удалось запустить, либо она завершилась неуспешно или была отменена.
+	StepFailed = StepState{"failed"}
+	// StepSkipped -- шаг не запускался, так как не выполнен один из шагов, от которых он зависит.
+	StepSkipped = StepState{"skipped"}
+)
+
+func StepStateFromString(s string) (StepState, error) {
+	switch s {
+	case "waiting":
+		return StepWaiting, nil
+	case "running":
+		return StepRunning, nil
+	case "succeeded":
+		return StepSucceeded, nil
+	case "failed":
+		return StepFailed, nil
+	case "skipped":
+		return StepSkipped, nil
+	}
+	return StepState{}, domain.NewInvalidInputError(
+		"step-state-invalid",
+		fmt.Sprintf(
+			"invalid step state: expected one of ['waiting', 'running', 'succeeded', 'failed', 'skipped'], got '%s'", s,
+		),
+	)
+}
+
+// IsTerminal сообщает, что состояние шага больше не изменится.
+func (s StepState) IsTerminal() bool {
+	return s == StepSucceeded || s == StepFailed || s == StepSkipped
+}
+
+func (s StepState) String() string {
+	return s.s
+}
+
+func (s StepState) IsZero() bool {
+	return s.s == ""
+}
@@ -0,0 +1,140 @@
+package value
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// MaxBatchSize -- наибольшее число задач в одном пакете.
+const MaxBatchSize = 1000
+
+// rangeEpsilon допускает погрешность вещественного шага при проверке, входит ли stop в диапазон.
+const rangeEpsilon = 1e-9
+
+// RangeValues возвращает значения числового типа t от start до stop включительно с шагом step.
+func RangeValues(t Type, start, stop, step string) ([]Value, error) {
+	switch t {
+	case IntegerValueType:
+		return integerRange(start, stop, step)
+	case RealValueType:
+		return realRange(start, stop, step)
+	}
+	return nil, domain.NewInvalidInputError(
+		"range-type-invalid",
+		fmt.Sprintf("range is supported for integer and real values only, got '%s'", t.String()),
+	)
+}
+
+func integerRange(start, stop, step string) ([]Value, error) {
+	var bounds [3]int64
+	for i, s := range []string{start, stop, step} {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, domain.NewInvalidInputError(
+				"range-value-invalid", fmt.Sprintf("validation error: expected integer, got '%s'", s),
+			)
+		}
+		bounds[i] = n
+	}
+	from, to, by := bounds[0], bounds[1], bounds[2]
+	if err := checkRange(from, to, by); err != nil {
+		return nil, err
+	}
+
+	// Разность границ может не поместиться в int64, но всегда помещается в uint64. Значения вычисляются
+	// по индексу: i*by не превышает разности, поэтому from + i*by не выходит за stop.
+	span := uint64(to) - uint64(from)
+	if span/uint64(by) >= MaxBatchSize {
+		return nil, errRangeTooLarge()
+	}
+	res := make([]Value, span/uint64(by)+1)
+	for i := range res {
+		n := int64(uint64(from) + uint64(i)*uint64(by))
+		res[i] = Value{t: IntegerValueType, s: strconv.FormatInt(n, 10)}
+	}
+	return res, nil
+}
+
+func realRange(start, stop, step string) ([]Value, error) {
+	var bounds [3]float64
+	for i, s := range []string{start, stop, step} {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, domain.NewInvalidInputError(
+				"range-value-invalid", fmt.Sprintf("validation error: expected real, got '%s'", s),
+			)
+		}
+		bounds[i] = f
+	}
+	from, to, by := bounds[0], bounds[1], bounds[2]
+	if err := checkRange(from, to, by); err != nil {
+		return nil, err
+	}
+	if (to-from)/by+1 > MaxBatchSize {
+		return nil, errRangeTooLarge()
+	}
+
+	// Значения вычисляются от start, а не накоплением шага, и округляются до 15 значащих цифр, чтобы
+	// погрешность двоичного представления не попадала во входные данные (0.1 * 3 -> 0.3, а не 0.30000000000000004).
+	n := int(math.Floor((to-from)/by+rangeEpsilon)) + 1
+	res := make([]Value, n)
+	for i := range res {
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(from+float64(i)*by, 'g', 15, 64), 64)
+		res[i] = Value{t: RealValueType, s: strconv.FormatFloat(f, 'g', -1, 64)}
+	}
+	return res, nil
+}
+
+func checkRange[T int64 | float64](from, to, by T) error {
+	if by <= 0 {
+		return domain.NewInvalidInputError(
+			"range-step-invalid", fmt.Sprintf("expected positive range step, got %v", by),
+		)
+	}
+	if to < from {
+		return domain.NewInvalidInputError(
+			"range-bounds-invalid", fmt.Sprintf("expected range stop %v not less than start %v", to, from),
+		)
+	}
+	return nil
+}
+
+func errRangeTooLarge() error {
+	return domain.NewInvalidInputError(
+		"range-too-large", fmt.Sprintf("range expands to more than %d values", MaxBatchSize),
+	)
+}
+
+// CartesianProduct возвращает все наборы значений, составленные выбором по одному значению из каждой оси
+// axes; последняя ось изменяется быстрее всего. Число наборов не может превышать MaxBatchSize.
+func CartesianProduct(axes [][]Value) ([][]Value, error) {
+	total := 1
+	for i, axis := range axes {
+		if len(axis) == 0 {
+			return nil, domain.NewInvalidInputError(
+				"sweep-axis-empty", fmt.Sprintf("sweep axis %d has no values", i),
+			)
+		}
+		total *= len(axis)
+		if total > MaxBatchSize {
+			return nil, domain.NewInvalidInputError(
+				"sweep-too-large", fmt.Sprintf("sweep expands to more than %d value sets", MaxBatchSize),
+			)
+		}
+	}
+
+	res := make([][]Value, total)
+	for i := range res {
+		set := make([]Value, len(axes))
+		k := i
+		for j := len(axes) - 1; j >= 0; j-- {
+			set[j] = axes[j][k%len(axes[j])]
+			k /= len(axes[j])
+		}
+		res[i] = set
+	}
+	return res, nil
+}
@@ -0,0 +1,83 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func valueStrings(vs []value.Value) []string {
+	res := make([]string, len(vs))
+	for i, v := range vs {
+		res[i] = v.String()
+	}
+	return res
+}
+
+func TestRangeValues(t *testing.T) {
+	t.Run("integer range includes stop", func(t *testing.T) {
+		vs, err := value.RangeValues(value.IntegerValueType, "1", "7", "3")
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "4", "7"}, valueStrings(vs))
+	})
+
+	t.Run("real range hides binary rounding error", func(t *testing.T) {
+		vs, err := value.RangeValues(value.RealValueType, "0", "0.3", "0.1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"0", "0.1", "0.2", "0.3"}, valueStrings(vs))
+	})
+
+	t.Run("string range is rejected", func(t *testing.T) {
+		_, err := value.RangeValues(value.StringValueType, "a", "b", "c")
+		require.Error(t, err)
+	})
+
+	t.Run("integer range at int64 limits", func(t *testing.T) {
+		vs, err := value.RangeValues(value.IntegerValueType, "9223372036854775802", "9223372036854775807", "10")
+		require.NoError(t, err)
+		require.Equal(t, []string{"9223372036854775802"}, valueStrings(vs))
+
+		vs, err = value.RangeValues(value.IntegerValueType, "9223372036854775805", "9223372036854775807", "1")
+		require.NoError(t, err)
+		want := []string{"9223372036854775805", "9223372036854775806", "9223372036854775807"}
+		require.Equal(t, want, valueStrings(vs))
+
+		vs, err = value.RangeValues(
+			value.IntegerValueType, "-9223372036854775808", "9223372036854775807", "9223372036854775807",
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"-9223372036854775808", "-1", "9223372036854775806"}, valueStrings(vs))
+	})
+
+	t.Run("integer range spanning int64 is rejected", func(t *testing.T) {
+		_, err := value.RangeValues(value.IntegerValueType, "-9223372036854775808", "9223372036854775807", "1")
+		require.Error(t, err)
+	})
+
+	t.Run("integer range stop below start is rejected", func(t *testing.T) {
+		_, err := value.RangeValues(value.IntegerValueType, "9223372036854775807", "9223372036854775806", "1")
+		require.Error(t, err)
+	})
+
+	t.Run("non-positive step is rejected", func(t *testing.T) {
+		_, err := value.RangeValues(value.IntegerValueType, "1", "5", "0")
+		require.Error(t, err)
+	})
+}
+
+func TestCartesianProduct(t *testing.T) {
+	a := []value.Value{value.MustNewIntegerValue("1"), value.MustNewIntegerValue("2")}
+	b := []value.Value{value.NewStringValue("x"), value.NewStringValue("y"), value.NewStringValue("z")}
+
+	sets, err := value.CartesianProduct([][]value.Value{a, b})
+	require.NoError(t, err)
+	require.Len(t, sets, 6)
+	require.Equal(t, []string{"1", "x"}, valueStrings(sets[0]))
+	require.Equal(t, []string{"1", "z"}, valueStrings(sets[2]))
+	require.Equal(t, []string{"2", "x"}, valueStrings(sets[3]))
+
+	_, err = value.CartesianProduct([][]value.Value{a, {}})
+	require.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package value
+
+import (
+	"fmt"
+	"time"
+	// Расписания не должны зависеть от базы часовых поясов в образе сервиса.
+	_ "time/tzdata"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain"
+)
+
+// LocationFromString возвращает часовой пояс по имени базы IANA, например Europe/Moscow. Пустое имя -- UTC.
+func LocationFromString(s string) (*time.Location, error) {
+	// time.LoadLocation трактует "Local" как часовой пояс сервера, который не должен влиять на расписания.
+	if s == "Local" {
+		return nil, domain.NewInvalidInputError("timezone-invalid", "invalid timezone: 'Local'")
+	}
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return nil, domain.NewInvalidInputError("timezone-invalid", fmt.Sprintf("invalid timezone: '%s'", s))
+	}
+	return loc, nil
+}
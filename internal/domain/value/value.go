@@ -52,6 +52,41 @@ func NewStringValue(s string) Value {
 	return Value{t: StringValueType, s: s}
 }
 
+// NewBooleanValue принимает запись логического значения в любом виде, допустимом strconv.ParseBool
+// ("1", "True", "FALSE" и т. п.), и хранит её в каноническом виде "true"/"false".
+func NewBooleanValue(s string) (Value, error) {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return Value{}, domain.NewInvalidInputError(
+			"value-type-invalid",
+			fmt.Sprintf("validation error: expected boolean, got '%s'", s),
+		)
+	}
+	return Value{
+		t: BooleanValueType,
+		s: strconv.FormatBool(b),
+	}, nil
+}
+
+// NewEnumValue создаёт значение перечисления. Принадлежность значения вариантам поля проверяет Field.Validate.
+func NewEnumValue(s string) (Value, error) {
+	if s == "" {
+		return Value{}, domain.NewInvalidInputError(
+			"value-type-invalid", "validation error: expected not empty enum value",
+		)
+	}
+	return Value{t: EnumValueType, s: s}, nil
+}
+
+// NewFileValue создаёт значение-ссылку на загруженный файл с ID s. Задача получает путь к файлу в контейнере.
+func NewFileValue(s string) (Value, error) {
+	id, err := FileIDFromString(s)
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{t: FileValueType, s: string(id)}, nil
+}
+
 func NewValue(t Type, s string) (Value, error) {
 	switch t {
 	case IntegerValueType:
@@ -62,6 +97,15 @@ func NewValue(t Type, s string) (Value, error) {
 
 	case StringValueType:
 		return NewStringValue(s), nil
+
+	case BooleanValueType:
+		return NewBooleanValue(s)
+
+	case EnumValueType:
+		return NewEnumValue(s)
+
+	case FileValueType:
+		return NewFileValue(s)
 	}
 	return Value{}, domain.NewInvalidInputError(
 		"value-type-invalid",
@@ -86,3 +130,11 @@ func (v Value) String() string {
 func (v Value) Type() Type {
 	return v.t
 }
+
+// FileID возвращает ID файла значения типа FileValueType; для остальных типов ok == false.
+func (v Value) FileID() (id FileID, ok bool) {
+	if v.t != FileValueType {
+		return "", false
+	}
+	return FileID(v.s), true
+}
@@ -14,6 +14,9 @@ var (
 	IntegerValueType = Type{"integer"}
 	RealValueType    = Type{"real"}
 	StringValueType  = Type{"string"}
+	BooleanValueType = Type{"boolean"}
+	EnumValueType    = Type{"enum"}
+	FileValueType    = Type{"file"}
 )
 
 func TypeFromString(s string) (Type, error) {
@@ -24,10 +27,18 @@ func TypeFromString(s string) (Type, error) {
 		return RealValueType, nil
 	case "string":
 		return StringValueType, nil
+	case "boolean":
+		return BooleanValueType, nil
+	case "enum":
+		return EnumValueType, nil
+	case "file":
+		return FileValueType, nil
 	}
 	return Type{}, domain.NewInvalidInputError(
 		"type-invalid",
-		fmt.Sprintf("invalid value type: expected one of ['integer', 'real', 'string'], got %s", s),
+		fmt.Sprintf(
+			"invalid value type: expected one of ['integer', 'real', 'string', 'boolean', 'enum', 'file'], got %s", s,
+		),
 	)
 }
 
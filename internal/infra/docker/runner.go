@@ -1,26 +1,36 @@
 package docker
 
 import (
-	"bufio"
+	"archive/tar"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"path"
 	"strings"
+	"time"
 
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/pkg/stdcopy"
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	"github.com/moby/moby/api/types/network"
 	"github.com/moby/moby/client"
 
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
 	"github.com/bmstu-itstech/scriptum-back/internal/config"
 	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
 )
 
 type Runner struct {
-	cli *client.Client
-	l   *slog.Logger
-	cfg config.Docker
+	cli  *client.Client
+	l    *slog.Logger
+	cfg  config.Docker
+	caps value.ResourceLimits
 }
 
 func NewRunner(cfg config.Docker, l *slog.Logger) (*Runner, error) {
@@ -28,11 +38,16 @@ func NewRunner(cfg config.Docker, l *slog.Logger) (*Runner, error) {
 		return nil, errors.New("nil logger")
 	}
 
+	caps, err := value.NewResourceLimits(cfg.Limits.MemoryMB, cfg.Limits.CPUs, cfg.Limits.Pids, cfg.Limits.TmpfsMB)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker limits: %w", err)
+	}
+
 	cli, err := client.New(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create docker client: %w", err)
 	}
-	return &Runner{cli, l, cfg}, nil
+	return &Runner{cli, l, cfg, caps}, nil
 }
 
 func MustNewRunner(cfg config.Docker, l *slog.Logger) *Runner {
@@ -43,7 +58,9 @@ func MustNewRunner(cfg config.Docker, l *slog.Logger) *Runner {
 	return r
 }
 
-func (r *Runner) Build(ctx context.Context, buildCtx io.Reader, id value.BlueprintID) (value.ImageTag, error) {
+func (r *Runner) Build(
+	ctx context.Context, buildCtx io.Reader, id value.BlueprintID, log io.Writer,
+) (value.ImageTag, error) {
 	l := r.l.With(
 		slog.String("op", "docker.Runner.Build"),
 		slog.String("blueprint_id", string(id)),
@@ -62,40 +79,181 @@ func (r *Runner) Build(ctx context.Context, buildCtx io.Reader, id value.Bluepri
 	}
 	defer func() { _ = res.Body.Close() }()
 
-	// Не знаю почему, но сборка не идёт, если не прочесть res.Body
-	read, err := io.ReadAll(res.Body)
+	// Сборка идёт, пока читается res.Body; об ошибке сборки Docker сообщает только в этом потоке.
+	err = r.readBuildStream(res.Body, log)
+	if err != nil {
+		l.DebugContext(ctx, "Docker build failed", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	err = r.addOutputDir(ctx, image)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return "", fmt.Errorf("failed to add output directory: %w", err)
 	}
-	l.Debug(string(read))
 
 	l.DebugContext(ctx, "Docker build finished")
 	return image, nil
 }
 
-func (r *Runner) Run(ctx context.Context, image value.ImageTag, input []value.Value) (value.Result, error) {
-	ctx, cancel := context.WithTimeout(ctx, r.cfg.RunnerTimeout)
+// addOutputDir добавляет в образ image каталог outputDir, доступный для записи любому пользователю, как /tmp.
+// При запуске задачи том outputDir наполняется из образа вместе с правами каталога, поэтому задача может
+// записать файлы выходных полей от имени пользователя контейнера (docker.security.user) и при неизменяемой
+// корневой файловой системе. Каталог outputDir, уже существующий в образе, становится доступным для записи.
+func (r *Runner) addOutputDir(ctx context.Context, image value.ImageTag) error {
+	resp, err := r.cli.ContainerCreate(ctx, client.ContainerCreateOptions{
+		Image:  string(image),
+		Config: &container.Config{},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	defer func() {
+		_, _ = r.cli.ContainerRemove(
+			context.WithoutCancel(ctx), resp.ID, client.ContainerRemoveOptions{Force: true, RemoveVolumes: true},
+		)
+	}()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     strings.TrimPrefix(outputDir, "/") + "/",
+		Mode:     0o1777,
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	_, err = r.cli.CopyToContainer(ctx, resp.ID, client.CopyToContainerOptions{
+		DestinationPath: "/",
+		Content:         &buf,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy output directory: %w", err)
+	}
+
+	// Тег переходит к новому образу; прежний образ остаётся его родителем и удаляется вместе с ним (см. Cleanup).
+	_, err = r.cli.ContainerCommit(ctx, resp.ID, client.ContainerCommitOptions{Reference: string(image)})
+	if err != nil {
+		return fmt.Errorf("failed to commit image: %w", err)
+	}
+	return nil
+}
+
+// buildMessage -- сообщение JSON-потока ответа Docker на ImageBuild.
+type buildMessage struct {
+	Stream      string `json:"stream"`
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	ErrorDetail *struct {
+		Message string `json:"message"`
+	} `json:"errorDetail"`
+}
+
+func (r *Runner) readBuildStream(rd io.Reader, log io.Writer) error {
+	dec := json.NewDecoder(rd)
+	for {
+		var msg buildMessage
+		err := dec.Decode(&msg)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode build output: %w", err)
+		}
+
+		switch {
+		case msg.ErrorDetail != nil || msg.Error != "":
+			errMsg := msg.Error
+			if msg.ErrorDetail != nil && msg.ErrorDetail.Message != "" {
+				errMsg = msg.ErrorDetail.Message
+			}
+			_, _ = fmt.Fprintln(log, errMsg)
+			return fmt.Errorf("%w: %s", ports.ErrImageBuildFailed, errMsg)
+		case msg.Stream != "":
+			_, _ = io.WriteString(log, msg.Stream)
+		case msg.Status != "":
+			_, _ = fmt.Fprintln(log, msg.Status)
+		}
+	}
+}
+
+func (r *Runner) Run(ctx context.Context, spec ports.RunSpec) (_ value.Result, err error) {
+	timeout := r.timeout(spec.Timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	l := r.l.With(
 		slog.String("op", "docker.Runner.Run"),
-		slog.String("image", string(image)),
+		slog.String("job_id", string(spec.JobID)),
+		slog.String("image", string(spec.Image)),
 	)
 
+	if spec.Network && !r.cfg.Security.AllowNetwork {
+		l.WarnContext(ctx, "network access requested but not allowed, running without network")
+	}
+
+	limits := spec.Limits.Effective(r.caps)
+	hc := r.hostConfig(limits, spec.Network && r.cfg.Security.AllowNetwork)
+	if len(spec.Files) > 0 {
+		// Анонимный том, а не tmpfs: до запуска контейнера файлы можно скопировать только в том.
+		hc.Mounts = append(hc.Mounts, mount.Mount{Type: mount.TypeVolume, Target: inputDir})
+	}
+	if len(spec.Artifacts) > 0 {
+		// Том, в отличие от tmpfs, сохраняет файлы после остановки контейнера до его удаления. Права на запись
+		// в него пользователь контейнера получает из образа, см. addOutputDir.
+		hc.Mounts = append(hc.Mounts, mount.Mount{Type: mount.TypeVolume, Target: outputDir})
+	}
+
 	l.DebugContext(ctx, "Docker container creating started")
 	resp, err := r.cli.ContainerCreate(ctx, client.ContainerCreateOptions{
-		Image: string(image),
+		Name:  r.containerName(spec.JobID),
+		Image: string(spec.Image),
 		Config: &container.Config{
 			OpenStdin:   true,
 			AttachStdin: true,
 			StdinOnce:   true,
+			User:        r.cfg.Security.User,
 		},
+		HostConfig: hc,
 	})
 	if err != nil {
 		return value.Result{}, fmt.Errorf("failed to create container: %w", err)
 	}
 	l.DebugContext(ctx, "Docker container created")
 
+	defer func() {
+		if err == nil {
+			return
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("%w: exceeded %s: %w", ports.ErrRunTimeout, timeout, err)
+		}
+		// Контекст мог истечь, поэтому контейнер удаляется с новым контекстом.
+		_, rmErr := r.cli.ContainerRemove(
+			context.WithoutCancel(ctx), resp.ID, client.ContainerRemoveOptions{Force: true, RemoveVolumes: true},
+		)
+		if rmErr != nil && !cerrdefs.IsNotFound(rmErr) {
+			l.WarnContext(ctx, "failed to remove container", slog.String("error", rmErr.Error()))
+		}
+	}()
+
+	if len(spec.Files) > 0 {
+		l.DebugContext(ctx, "Docker container input files copying", slog.Int("files", len(spec.Files)))
+		err = r.copyInputFiles(ctx, resp.ID, spec.Files)
+		if err != nil {
+			return value.Result{}, fmt.Errorf("failed to copy input files: %w", err)
+		}
+	}
+
+	if spec.BeforeStart != nil {
+		if err = spec.BeforeStart(ctx); err != nil {
+			return value.Result{}, err
+		}
+	}
+
 	l.DebugContext(ctx, "Docker container starting")
 	_, err = r.cli.ContainerStart(ctx, resp.ID, client.ContainerStartOptions{})
 	if err != nil {
@@ -114,13 +272,30 @@ func (r *Runner) Run(ctx context.Context, image value.ImageTag, input []value.Va
 	l.DebugContext(ctx, "Docker container attached")
 
 	l.DebugContext(ctx, "Docker container writing")
-	n, err := attach.Conn.Write(r.marshallInput(input))
+	n, err := attach.Conn.Write(r.marshallInput(spec.Input))
 	_ = attach.Conn.Close()
 	if err != nil {
 		return value.Result{}, fmt.Errorf("failed to write input: %w", err)
 	}
 	l.DebugContext(ctx, "Docker container input written", slog.Int("bytes", n))
 
+	// Follow держит поток открытым до остановки контейнера, поэтому вывод передаётся в spec.Stdout и
+	// spec.Stderr по мере появления, а к моменту ожидания контейнер уже завершён.
+	out, err := r.cli.ContainerLogs(ctx, resp.ID, client.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	})
+	if err != nil {
+		return value.Result{}, fmt.Errorf("failed to get container logs: %w", err)
+	}
+	defer func() { _ = out.Close() }()
+
+	stdout, stderr, err := r.readDockerLogs(out, spec.Stdout, spec.Stderr)
+	if err != nil {
+		return value.Result{}, fmt.Errorf("failed to get container logs: %w", err)
+	}
+
 	l.DebugContext(ctx, "Docker container waiting")
 	wRes := r.cli.ContainerWait(ctx, resp.ID, client.ContainerWaitOptions{
 		Condition: container.WaitConditionNotRunning,
@@ -133,26 +308,31 @@ func (r *Runner) Run(ctx context.Context, image value.ImageTag, input []value.Va
 			return value.Result{}, fmt.Errorf("failed to wait container: %w", err)
 		}
 	case res := <-wRes.Result:
-		result = value.NewResult(value.ExitCode(res.StatusCode))
+		result = value.NewResult(value.ExitCode(res.StatusCode)).WithOutput(stdout).WithLog(stderr)
 	}
 	l.DebugContext(ctx, "Docker container exited", slog.Int("exit_code", int(result.Code())))
 
-	out, err := r.cli.ContainerLogs(ctx, resp.ID, client.ContainerLogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-	})
+	inspect, err := r.cli.ContainerInspect(ctx, resp.ID, client.ContainerInspectOptions{})
 	if err != nil {
-		return result, fmt.Errorf("failed to get container logs: %w", err)
+		return value.Result{}, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if inspect.Container.State != nil && inspect.Container.State.OOMKilled {
+		return value.Result{}, fmt.Errorf(
+			"%w: memory limit of %d MB exceeded", ports.ErrOutOfMemory, limits.MemoryMB(),
+		)
 	}
-	defer func() { _ = out.Close() }()
 
-	output, err := r.readDockerLogs(out)
-	if err != nil {
-		return result, fmt.Errorf("failed to get container logs: %w", err)
+	if result.Code().IsSuccess() && len(spec.Artifacts) > 0 {
+		l.DebugContext(ctx, "Docker container artifacts collecting", slog.Int("artifacts", len(spec.Artifacts)))
+		var artifacts map[string]value.FileID
+		artifacts, err = r.collectArtifacts(ctx, resp.ID, spec)
+		if err != nil {
+			return value.Result{}, fmt.Errorf("failed to collect artifacts: %w", err)
+		}
+		result = result.WithArtifacts(artifacts)
 	}
-	result = result.WithOutput(output)
 
-	_, err = r.cli.ContainerRemove(ctx, resp.ID, client.ContainerRemoveOptions{})
+	_, err = r.cli.ContainerRemove(ctx, resp.ID, client.ContainerRemoveOptions{RemoveVolumes: true})
 	if err != nil {
 		return result, fmt.Errorf("failed to remove container: %w", err)
 	}
@@ -160,37 +340,261 @@ func (r *Runner) Run(ctx context.Context, image value.ImageTag, input []value.Va
 	return result, nil
 }
 
+func (r *Runner) Stop(ctx context.Context, id value.JobID) error {
+	l := r.l.With(
+		slog.String("op", "docker.Runner.Stop"),
+		slog.String("job_id", string(id)),
+	)
+
+	// Force отправляет SIGKILL работающему контейнеру перед удалением; ожидающий его Run получит ошибку.
+	_, err := r.cli.ContainerRemove(
+		ctx, r.containerName(id), client.ContainerRemoveOptions{Force: true, RemoveVolumes: true},
+	)
+	if cerrdefs.IsNotFound(err) {
+		l.DebugContext(ctx, "Docker container not found")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to remove container: %w", err)
+	}
+	l.DebugContext(ctx, "Docker container stopped and removed")
+
+	return nil
+}
+
+func (r *Runner) Running(ctx context.Context) ([]value.JobID, error) {
+	prefix := r.containerName("")
+	res, err := r.cli.ContainerList(ctx, client.ContainerListOptions{
+		All:     true,
+		Filters: make(client.Filters).Add("name", "^/"+prefix),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	ids := make([]value.JobID, 0, len(res.Items))
+	for _, c := range res.Items {
+		for _, name := range c.Names {
+			if id, ok := strings.CutPrefix(name, "/"+prefix); ok {
+				ids = append(ids, value.JobID(id))
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+// timeout возвращает ограничение времени выполнения контейнера с учётом ограничений администратора.
+func (r *Runner) timeout(requested time.Duration) time.Duration {
+	t := requested
+	if t == 0 {
+		t = r.cfg.RunnerTimeout
+	}
+	if r.cfg.MaxTimeout > 0 && t > r.cfg.MaxTimeout {
+		t = r.cfg.MaxTimeout
+	}
+	return t
+}
+
+func (r *Runner) hostConfig(limits value.ResourceLimits, withNetwork bool) *container.HostConfig {
+	hc := &container.HostConfig{
+		ReadonlyRootfs: r.cfg.Security.ReadOnlyRootfs,
+	}
+	if !withNetwork {
+		hc.NetworkMode = network.NetworkNone
+	}
+	if r.cfg.Security.DropCapabilities {
+		hc.CapDrop = []string{"ALL"}
+	}
+	if r.cfg.Security.NoNewPrivileges {
+		hc.SecurityOpt = []string{"no-new-privileges"}
+	}
+
+	if limits.MemoryMB() > 0 {
+		hc.Memory = limits.MemoryMB() << 20
+		hc.MemorySwap = hc.Memory // Без swap сверх ограничения памяти
+	}
+	if limits.CPUs() > 0 {
+		hc.NanoCPUs = int64(limits.CPUs() * 1e9)
+	}
+	if limits.Pids() > 0 {
+		pids := limits.Pids()
+		hc.PidsLimit = &pids
+	}
+	if limits.TmpfsMB() > 0 {
+		hc.Tmpfs = map[string]string{"/tmp": fmt.Sprintf("size=%dm", limits.TmpfsMB())}
+	} else if hc.ReadonlyRootfs {
+		// Единственное доступное для записи место при неизменяемой корневой файловой системе
+		hc.Tmpfs = map[string]string{"/tmp": ""}
+	}
+	return hc
+}
+
+// containerName возвращает имя контейнера задачи, по которому его можно найти из другого запроса.
+func (r *Runner) containerName(id value.JobID) string {
+	return fmt.Sprintf("%s-job-%s", r.cfg.ImagePrefix, id)
+}
+
+// inputDir -- каталог контейнера, в котором задача находит файлы входных значений типа file.
+const inputDir = "/input"
+
+// inputFilePath возвращает путь к файлу id в контейнере задачи.
+func inputFilePath(id value.FileID) string {
+	return path.Join(inputDir, string(id))
+}
+
+// outputDir -- каталог контейнера, в который задача записывает файлы выходных полей типа file. Каталог
+// доступен для записи любому пользователю контейнера, см. addOutputDir.
+const outputDir = "/out"
+
+// marshallInput записывает входные значения по одному на строку; вместо ID файла записывается путь к нему.
 func (r *Runner) marshallInput(input []value.Value) []byte {
 	var buf bytes.Buffer
 	for _, v := range input {
-		buf.WriteString(v.String())
+		if id, ok := v.FileID(); ok {
+			buf.WriteString(inputFilePath(id))
+		} else {
+			buf.WriteString(v.String())
+		}
 		buf.WriteRune('\n')
 	}
 	return buf.Bytes()
 }
 
-func (r *Runner) readDockerLogs(rd io.Reader) (string, error) {
-	br := bufio.NewReader(rd)
-	var builder strings.Builder
-	for {
-		_, err := br.Discard(8) // 8-байтный заголовок Docker в выводе
+// copyInputFiles копирует файлы входных значений в каталог inputDir созданного, но ещё не запущенного
+// контейнера. Файлы доступны только для чтения.
+func (r *Runner) copyInputFiles(ctx context.Context, containerID string, files map[value.FileID]io.Reader) error {
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(writeInputFilesTar(pw, files))
+	}()
+	defer func() { _ = pr.Close() }()
+
+	_, err := r.cli.CopyToContainer(ctx, containerID, client.CopyToContainerOptions{
+		DestinationPath: inputDir,
+		Content:         pr,
+	})
+	return err
+}
+
+// writeInputFilesTar записывает файлы в архив tar. Размер файла нужен заголовку архива заранее, поэтому
+// содержимое сначала сохраняется во временный файл.
+func writeInputFilesTar(w io.Writer, files map[value.FileID]io.Reader) error {
+	tw := tar.NewWriter(w)
+	for id, rd := range files {
+		if err := writeInputFileTar(tw, id, rd); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func writeInputFileTar(tw *tar.Writer, id value.FileID, rd io.Reader) error {
+	tmp, err := os.CreateTemp("", "scriptum-input-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	size, err := io.Copy(tmp, rd)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", id, err)
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     string(id),
+		Mode:     0o444,
+		Size:     size,
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, tmp)
+	return err
+}
+
+// collectArtifacts копирует файлы spec.Artifacts из каталога outputDir остановленного контейнера и сохраняет их
+// через spec.SaveArtifact. Незаписанные файлы, а также каталоги и ссылки вместо файлов пропускаются.
+func (r *Runner) collectArtifacts(
+	ctx context.Context, containerID string, spec ports.RunSpec,
+) (map[string]value.FileID, error) {
+	res := make(map[string]value.FileID, len(spec.Artifacts))
+	for _, name := range spec.Artifacts {
+		cp, err := r.cli.CopyFromContainer(ctx, containerID, client.CopyFromContainerOptions{
+			SourcePath: path.Join(outputDir, name),
+		})
+		if cerrdefs.IsNotFound(err) {
+			continue
+		}
 		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return "", fmt.Errorf("failed to read Docker 8-byte header in output: %w", err)
+			return nil, fmt.Errorf("failed to copy artifact %s: %w", name, err)
 		}
-		line, err := br.ReadString('\n')
+		id, ok, err := saveArtifact(ctx, name, cp.Content, spec.SaveArtifact)
+		_ = cp.Content.Close()
 		if err != nil {
-			return "", fmt.Errorf("failed to readline Docker output: %w", err)
+			return nil, fmt.Errorf("failed to save artifact %s: %w", name, err)
 		}
-		builder.WriteString(line)
+		if ok {
+			res[name] = id
+		}
+	}
+	return res, nil
+}
+
+// saveArtifact сохраняет через save единственный файл архива tar, полученного из контейнера, и сообщает,
+// был ли он сохранён.
+func saveArtifact(
+	ctx context.Context,
+	name string,
+	rd io.Reader,
+	save func(ctx context.Context, name string, content io.Reader) (value.FileID, error),
+) (value.FileID, bool, error) {
+	tr := tar.NewReader(rd)
+	hdr, err := tr.Next()
+	if errors.Is(err, io.EOF) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if hdr.Typeflag != tar.TypeReg {
+		return "", false, nil
+	}
+	id, err := save(ctx, name, tr)
+	if err != nil {
+		return "", false, err
+	}
+	return id, true, nil
+}
+
+// readDockerLogs разделяет мультиплексированный поток логов контейнера на stdout и stderr
+// по 8-байтным заголовкам кадров Docker, дублируя их в необязательные stdoutW и stderrW.
+func (r *Runner) readDockerLogs(rd io.Reader, stdoutW, stderrW io.Writer) (string, string, error) {
+	var stdout, stderr strings.Builder
+	_, err := stdcopy.StdCopy(teeWriter(&stdout, stdoutW), teeWriter(&stderr, stderrW), rd)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to demultiplex Docker output: %w", err)
+	}
+	return stdout.String(), stderr.String(), nil
+}
+
+func teeWriter(w io.Writer, extra io.Writer) io.Writer {
+	if extra == nil {
+		return w
 	}
-	return builder.String(), nil
+	return io.MultiWriter(w, extra)
 }
 
 func (r *Runner) Cleanup(ctx context.Context, image value.ImageTag) error {
-	_, err := r.cli.ImageRemove(ctx, string(image), client.ImageRemoveOptions{})
+	// PruneChildren удаляет и образ сборки, родительский для образа с каталогом outputDir.
+	_, err := r.cli.ImageRemove(ctx, string(image), client.ImageRemoveOptions{PruneChildren: true})
 	if err != nil {
 		return fmt.Errorf("failed to remove image: %w", err)
 	}
@@ -2,12 +2,14 @@ package docker_test
 
 import (
 	"context"
+	"io"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
 	"github.com/bmstu-itstech/scriptum-back/internal/config"
 	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
 	"github.com/bmstu-itstech/scriptum-back/internal/infra/docker"
@@ -36,7 +38,7 @@ func TestRunner_Adder(t *testing.T) {
 	buildCtx, err := os.Open(archivePath)
 	require.NoError(t, err)
 
-	l := logs.NewLogger(config.Logging{Level: "debug"})
+	l := logs.NewLogger("local")
 	ctx := context.Background()
 	ctx, cancelFn := context.WithTimeout(ctx, dockerTimeout)
 	defer cancelFn()
@@ -46,7 +48,7 @@ func TestRunner_Adder(t *testing.T) {
 		RunnerTimeout: dockerTimeout,
 	}
 	r := docker.MustNewRunner(cfg, l)
-	image, err := r.Build(ctx, buildCtx, value.NewBlueprintID())
+	image, err := r.Build(ctx, buildCtx, value.NewBlueprintID(), io.Discard)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		err = r.Cleanup(context.Background(), image)
@@ -56,28 +58,41 @@ func TestRunner_Adder(t *testing.T) {
 	})
 
 	t.Run("successfully added", func(t *testing.T) {
-		res, err2 := r.Run(ctx, image, []value.Value{
-			value.MustNewIntegerValue("1"),
-			value.MustNewIntegerValue("2"),
+		res, err2 := r.Run(ctx, ports.RunSpec{
+			JobID: value.NewJobID(),
+			Image: image,
+			Input: []value.Value{
+				value.MustNewIntegerValue("1"),
+				value.MustNewIntegerValue("2"),
+			},
 		})
 		require.NoError(t, err2)
 		require.Equal(t, value.NewResult(0).WithOutput("3\n"), res)
 	})
 
 	t.Run("should return exception on invalid input", func(t *testing.T) {
-		res, err2 := r.Run(ctx, image, []value.Value{
-			value.MustNewIntegerValue("1"),
-			value.NewStringValue("a"),
+		res, err2 := r.Run(ctx, ports.RunSpec{
+			JobID: value.NewJobID(),
+			Image: image,
+			Input: []value.Value{
+				value.MustNewIntegerValue("1"),
+				value.NewStringValue("a"),
+			},
 		})
 		require.NoError(t, err2)
 		require.NotEqual(t, value.ExitCode(0), res.Code())
-		require.NotEmpty(t, res.Output())
+		require.Empty(t, res.Output())
+		require.NotEmpty(t, res.Log())
 	})
 
 	t.Run("should return error if image not found", func(t *testing.T) {
-		_, err = r.Run(ctx, "invalid", []value.Value{
-			value.MustNewIntegerValue("1"),
-			value.MustNewIntegerValue("2"),
+		_, err = r.Run(ctx, ports.RunSpec{
+			JobID: value.NewJobID(),
+			Image: "invalid",
+			Input: []value.Value{
+				value.MustNewIntegerValue("1"),
+				value.MustNewIntegerValue("2"),
+			},
 		})
 		require.Error(t, err)
 	})
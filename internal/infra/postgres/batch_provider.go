@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) Batch(ctx context.Context, id value.BatchID) (dto.Batch, error) {
+	var rB readBatchRow
+	var jobIDs []string
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		rB, err = r.selectReadBatchRow(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		jobIDs, err = r.selectBatchJobIDs(ctx, tx, string(id))
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.Batch{}, fmt.Errorf("%w: %s", ports.ErrBatchNotFound, string(id))
+	}
+	if err != nil {
+		return dto.Batch{}, err
+	}
+
+	return readBatchRowToDTO(rB, jobIDs), nil
+}
+
+func (r *Repository) BatchJobs(ctx context.Context, id value.BatchID) ([]dto.Job, error) {
+	var rJs []readJobRow
+	var rIFs map[string][]jobFieldRow
+	var rOFs map[string][]jobFieldRow
+	var rIVs map[string][]jobValueRow
+	var rOVs map[string][]jobValueRow
+	var rAs map[string][]jobAttemptRow
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		rJs, err = r.selectBatchReadJobRows(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		ids := idsFromJobs(rJs)
+		rIFs, err = r.selectJobsInputFieldsRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rOFs, err = r.selectJobsOutputFieldsRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rIVs, err = r.selectJobsInputValuesRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rOVs, err = r.selectJobsOutputValuesRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rAs, err = r.selectJobsAttemptRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	js := make([]dto.Job, len(rJs))
+	for i, rJ := range rJs {
+		js[i] = readJobRowToDTO(rJ, rIFs[rJ.ID], rOFs[rJ.ID], rIVs[rJ.ID], rOVs[rJ.ID], rAs[rJ.ID])
+	}
+
+	return js, nil
+}
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+)
+
+func (r *Repository) SaveBatch(ctx context.Context, batch *entity.Batch, jobs []*entity.Job) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := r.insertBatchRow(ctx, tx, batchRowFromDomain(batch)); err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			if err := r.saveJob(ctx, tx, job); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
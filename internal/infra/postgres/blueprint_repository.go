@@ -71,6 +71,80 @@ func (r *Repository) SaveBlueprint(ctx context.Context, blueprint *entity.Bluepr
 	return err
 }
 
+func (r *Repository) UpdateBlueprint(
+	ctx context.Context,
+	id value.BlueprintID,
+	updateFn func(ctx2 context.Context, blueprint *entity.Blueprint) error,
+) error {
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		rB, err := r.selectBlueprintRow(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		rIs, err := r.selectBlueprintInputFieldRows(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		rOs, err := r.selectBlueprintOutputFieldRows(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		blueprint, err := blueprintRowToDomain(rB, rIs, rOs)
+		if err != nil {
+			return err
+		}
+		err = updateFn(ctx, blueprint)
+		if err != nil {
+			return err
+		}
+		return r.updateBlueprintRow(ctx, tx, blueprintRowFromDomain(blueprint))
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ports.ErrBlueprintNotFound, id)
+	}
+	return err
+}
+
+func (r *Repository) UnbuiltBlueprints(ctx context.Context) ([]*entity.Blueprint, error) {
+	var rBs []blueprintRow
+	var rIs map[string][]blueprintFieldRow
+	var rOs map[string][]blueprintFieldRow
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		rBs, err = r.selectUnbuiltBlueprintRows(ctx, tx)
+		if err != nil {
+			return err
+		}
+		ids := make([]string, len(rBs))
+		for i, rB := range rBs {
+			ids[i] = rB.ID
+		}
+		rIs, err = r.selectBlueprintsInputFieldRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rOs, err = r.selectBlueprintsOutputFieldRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bs := make([]*entity.Blueprint, len(rBs))
+	for i, rB := range rBs {
+		bs[i], err = blueprintRowToDomain(rB, rIs[rB.ID], rOs[rB.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bs, nil
+}
+
 func (r *Repository) DeleteBlueprint(ctx context.Context, id value.BlueprintID) error {
 	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
 		err := r.softDeleteBlueprintRow(ctx, r.db, string(id))
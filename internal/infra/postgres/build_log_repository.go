@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) SaveBuildLog(ctx context.Context, id value.BlueprintID, log string) error {
+	return r.upsertBuildLogRow(ctx, r.db, buildLogRow{BlueprintID: string(id), Log: log})
+}
+
+func (r *Repository) BuildLog(ctx context.Context, id value.BlueprintID) (string, error) {
+	row, err := r.selectBuildLogRow(ctx, r.db, string(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %s", ports.ErrBuildLogNotFound, string(id))
+	}
+	if err != nil {
+		return "", err
+	}
+	return row.Log, nil
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/config"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+)
+
+// BuildQueue -- очередь сборок образов поверх самих Blueprint: сохранённая со статусом 'queued' Blueprint
+// уже поставлена в очередь, поэтому публикация ничего не делает, а исполнители периодически опрашивают таблицу.
+//
+// Как и в JobQueue, сборка захватывается на cfg.LeaseTimeout, и если захвативший её экземпляр аварийно
+// завершится, после истечения аренды сборку заберёт другой.
+type BuildQueue struct {
+	r   *Repository
+	cfg config.Queue
+	l   *slog.Logger
+}
+
+func NewBuildQueue(r *Repository, cfg config.Queue, l *slog.Logger) *BuildQueue {
+	return &BuildQueue{r, cfg, l}
+}
+
+func (q *BuildQueue) PublishBlueprintBuild(_ context.Context, _ *entity.Blueprint) error {
+	return nil
+}
+
+func (q *BuildQueue) Listen(ctx context.Context, callback func(ctx context.Context, blueprintID string) error) error {
+	l := q.l.With(slog.String("op", "postgres.BuildQueue.Listen"))
+
+	l.InfoContext(ctx, "listening build queue", slog.Duration("poll_interval", q.cfg.PollInterval))
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			blueprintID, err := q.r.claimBuildRow(ctx, q.r.db, q.cfg.LeaseTimeout)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			} else if errors.Is(err, sql.ErrNoRows) {
+				break
+			} else if err != nil {
+				l.ErrorContext(ctx, "failed to claim blueprint build", slog.String("error", err.Error()))
+				break
+			}
+			go q.handle(ctx, blueprintID, callback)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *BuildQueue) handle(
+	ctx context.Context, blueprintID string, callback func(ctx context.Context, blueprintID string) error,
+) {
+	l := q.l.With(
+		slog.String("op", "postgres.BuildQueue.handle"),
+		slog.String("blueprintID", blueprintID),
+	)
+
+	// Неудачная обработка не повторяется до истечения аренды: статус сборки остаётся 'building'.
+	if err := callback(context.Background(), blueprintID); err != nil {
+		l.ErrorContext(ctx, "failed to handle blueprint build", slog.String("error", err.Error()))
+		return
+	}
+	l.InfoContext(ctx, "handled blueprint build")
+}
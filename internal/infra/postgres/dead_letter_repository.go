@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) SaveDeadLetter(ctx context.Context, id value.JobID, reason string, attempts int) error {
+	return r.upsertDeadLetterRow(ctx, r.db, deadLetterRow{JobID: string(id), Error: reason, Attempts: attempts})
+}
+
+func (r *Repository) RequeueDeadLetter(ctx context.Context, id value.JobID) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		err := r.deleteDeadLetterRow(ctx, tx, string(id))
+		if errors.Is(err, pgutils.ErrNoAffectedRows) {
+			return fmt.Errorf("%w: %s", ports.ErrDeadLetterNotFound, id)
+		} else if err != nil {
+			return err
+		}
+		return r.insertOutboxRow(ctx, tx, string(id))
+	})
+}
+
+func (r *Repository) DeadLetter(ctx context.Context, id value.JobID) (dto.DeadLetter, error) {
+	row, err := r.selectDeadLetterRow(ctx, r.db, string(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.DeadLetter{}, fmt.Errorf("%w: %s", ports.ErrDeadLetterNotFound, id)
+	} else if err != nil {
+		return dto.DeadLetter{}, err
+	}
+	return deadLetterRowToDTO(row), nil
+}
+
+func (r *Repository) DeadLetters(ctx context.Context) ([]dto.DeadLetter, error) {
+	rows, err := r.selectDeadLetterRows(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]dto.DeadLetter, len(rows))
+	for i, row := range rows {
+		res[i] = deadLetterRowToDTO(row)
+	}
+	return res, nil
+}
+
+// deadLetterQueued перекладывает задачу захвата claim из очереди в недоставленные. Если запись захвата уже
+// заменена, возвращает pgutils.ErrNoAffectedRows.
+func (r *Repository) deadLetterQueued(ctx context.Context, claim queueClaim, reason string, attempts int) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := r.ackQueueRow(ctx, tx, claim); err != nil {
+			return err
+		}
+		return r.upsertDeadLetterRow(ctx, tx, deadLetterRow{JobID: claim.JobID, Error: reason, Attempts: attempts})
+	})
+}
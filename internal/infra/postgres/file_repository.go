@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) SaveFile(ctx context.Context, id value.FileID, ownerID value.UserID, name string) error {
+	return r.insertFileRow(ctx, r.db, fileRow{ID: string(id), OwnerID: string(ownerID), Name: name})
+}
+
+func (r *Repository) FileOwner(ctx context.Context, id value.FileID) (value.UserID, error) {
+	row, err := r.selectFileRow(ctx, r.db, string(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %s", ports.ErrFileNotFound, string(id))
+	}
+	if err != nil {
+		return "", err
+	}
+	return value.UserID(row.OwnerID), nil
+}
@@ -0,0 +1,315 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/config"
+)
+
+const jobEventsChannel = "job_events"
+
+// Полезная нагрузка NOTIFY должна быть короче 8000 байт; запас оставлен на случай изменения кодировки.
+const maxNotifyPayloadBytes = 7900
+
+const (
+	// outputFlushInterval -- наибольшая задержка строк вывода; чаще одного уведомления за интервал для задачи
+	// отправляются, только если накопленные строки не помещаются в одно уведомление.
+	outputFlushInterval = 200 * time.Millisecond
+	// maxPendingOutputBytes ограничивает неотправленный вывод одной задачи; строки сверх него отбрасываются.
+	maxPendingOutputBytes = 256 << 10
+)
+
+const (
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+	listenerPingInterval = 90 * time.Second
+)
+
+// jobEventNotification -- полезная нагрузка NOTIFY: смена состояния или пачка строк вывода одного потока.
+type jobEventNotification struct {
+	JobID  string   `json:"jobId"`
+	Kind   string   `json:"kind"`
+	State  string   `json:"state,omitempty"`
+	Stream string   `json:"stream,omitempty"`
+	Lines  []string `json:"lines,omitempty"`
+}
+
+// JobEventNotifier рассылает события Job всем экземплярам сервиса через NOTIFY; подписчиков каждого экземпляра
+// оповещает его JobEventListener.
+//
+// Строки вывода не отправляются по одной: они накапливаются и рассылаются пачками не чаще раза в
+// outputFlushInterval для каждой задачи, поэтому многословный скрипт не нагружает базу данных. Если вывод
+// задачи накапливается быстрее, чем отправляется, строки сверх maxPendingOutputBytes отбрасываются -- вывод
+// целиком сохраняется в логе задачи. Смена состояния отправляется сразу, после накопленного вывода задачи.
+type JobEventNotifier struct {
+	r *Repository
+	l *slog.Logger
+
+	// sendMu упорядочивает отправку: вывод, забранный на отправку, уходит раньше следующих событий задачи.
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[string]*pendingOutput
+}
+
+// pendingOutput -- неотправленный вывод задачи.
+type pendingOutput struct {
+	events  []dto.JobEvent
+	bytes   int
+	dropped int
+	timer   *time.Timer
+}
+
+func NewJobEventNotifier(r *Repository, l *slog.Logger) *JobEventNotifier {
+	return &JobEventNotifier{r: r, l: l, pending: make(map[string]*pendingOutput)}
+}
+
+func (n *JobEventNotifier) PublishJobEvent(ctx context.Context, e dto.JobEvent) error {
+	if e.Kind == dto.JobEventOutput {
+		n.buffer(e)
+		return nil
+	}
+
+	n.sendMu.Lock()
+	defer n.sendMu.Unlock()
+	ns := n.outputNotifications(e.JobID, n.take(e.JobID))
+	ns = append(ns, jobEventNotification{JobID: e.JobID, Kind: e.Kind, State: e.State})
+	return n.notify(ctx, ns)
+}
+
+// buffer добавляет строку вывода к неотправленному выводу задачи и назначает его отправку.
+func (n *JobEventNotifier) buffer(e dto.JobEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	p, ok := n.pending[e.JobID]
+	if !ok {
+		p = &pendingOutput{}
+		n.pending[e.JobID] = p
+	}
+	if p.bytes+len(e.Line) > maxPendingOutputBytes {
+		p.dropped++
+		return
+	}
+	p.events = append(p.events, e)
+	p.bytes += len(e.Line)
+	if p.timer == nil {
+		p.timer = time.AfterFunc(outputFlushInterval, func() { n.flush(e.JobID) })
+	}
+}
+
+// take забирает неотправленный вывод задачи либо возвращает nil, если его нет.
+func (n *JobEventNotifier) take(jobID string) *pendingOutput {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	p, ok := n.pending[jobID]
+	if !ok {
+		return nil
+	}
+	delete(n.pending, jobID)
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	return p
+}
+
+func (n *JobEventNotifier) flush(jobID string) {
+	n.sendMu.Lock()
+	defer n.sendMu.Unlock()
+	err := n.notify(context.Background(), n.outputNotifications(jobID, n.take(jobID)))
+	if err != nil {
+		n.l.Warn("failed to notify job output", slog.String("job_id", jobID), slog.String("error", err.Error()))
+	}
+}
+
+// outputNotifications группирует строки вывода в уведомления по потокам, сохраняя порядок строк.
+func (n *JobEventNotifier) outputNotifications(jobID string, p *pendingOutput) []jobEventNotification {
+	if p == nil {
+		return nil
+	}
+	if p.dropped > 0 {
+		n.l.Warn(
+			"job output is produced faster than notified, lines dropped",
+			slog.String("job_id", jobID),
+			slog.Int("dropped", p.dropped),
+		)
+	}
+	var ns []jobEventNotification
+	for _, e := range p.events {
+		if len(ns) == 0 || ns[len(ns)-1].Stream != e.Stream {
+			ns = append(ns, jobEventNotification{JobID: e.JobID, Kind: e.Kind, Stream: e.Stream})
+		}
+		ns[len(ns)-1].Lines = append(ns[len(ns)-1].Lines, e.Line)
+	}
+	return ns
+}
+
+// notify отправляет уведомления по порядку, разбивая пачки строк так, чтобы полезная нагрузка каждого
+// уведомления не превышала maxNotifyPayloadBytes.
+func (n *JobEventNotifier) notify(ctx context.Context, ns []jobEventNotification) error {
+	for _, notification := range ns {
+		payloads, err := encodeJobEventNotification(notification)
+		if err != nil {
+			return err
+		}
+		for _, payload := range payloads {
+			if err = n.r.notifyJobEvent(ctx, n.r.db, payload); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// encodeJobEventNotification кодирует уведомление в одну или несколько полезных нагрузок не длиннее
+// maxNotifyPayloadBytes. Размер проверяется после кодирования: экранирование JSON может удлинить строку в
+// несколько раз. Строка, не помещающаяся в уведомление даже одна, обрезается.
+func encodeJobEventNotification(n jobEventNotification) ([][]byte, error) {
+	if len(n.Lines) == 0 {
+		payload, err := json.Marshal(n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshall job event: %w", err)
+		}
+		return [][]byte{payload}, nil
+	}
+
+	var payloads [][]byte
+	var batch []string
+	var batchPayload []byte
+	for _, line := range n.Lines {
+		for {
+			n.Lines = append(batch, line)
+			payload, err := json.Marshal(n)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshall job event: %w", err)
+			}
+			if len(payload) <= maxNotifyPayloadBytes {
+				batch = n.Lines
+				batchPayload = payload
+				break
+			}
+			if len(batch) > 0 {
+				payloads = append(payloads, batchPayload)
+				batch = nil
+				continue
+			}
+			// Строка не помещается в уведомление одна: оставляем наибольший префикс, который помещается.
+			line, err = truncateLine(n, line)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return append(payloads, batchPayload), nil
+}
+
+// truncateLine возвращает наибольший префикс line, с которым закодированное уведомление n из одной
+// строки не превышает maxNotifyPayloadBytes. Длина закодированной строки зависит от экранирования
+// символов, поэтому префикс ищется двоичным поиском по размеру закодированного уведомления.
+func truncateLine(n jobEventNotification, line string) (string, error) {
+	lo, hi := 0, len(line)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		n.Lines = []string{strings.ToValidUTF8(line[:mid], "")}
+		payload, err := json.Marshal(n)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshall job event: %w", err)
+		}
+		if len(payload) <= maxNotifyPayloadBytes {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return strings.ToValidUTF8(line[:lo], ""), nil
+}
+
+// JobEventListener получает события Job, опубликованные любым экземпляром сервиса через
+// JobEventNotifier, и передаёт их локальным подписчикам через p. События, опубликованные во время
+// переподключения к базе данных, теряются.
+type JobEventListener struct {
+	cfg config.Postgres
+	p   ports.JobEventPublisher
+	l   *slog.Logger
+}
+
+func NewJobEventListener(cfg config.Postgres, p ports.JobEventPublisher, l *slog.Logger) *JobEventListener {
+	return &JobEventListener{cfg, p, l}
+}
+
+func (jl *JobEventListener) Run(ctx context.Context) error {
+	l := jl.l.With(slog.String("op", "postgres.JobEventListener.Run"))
+
+	listener := pq.NewListener(
+		jl.cfg.URI, listenerMinReconnect, listenerMaxReconnect,
+		func(_ pq.ListenerEventType, err error) {
+			if err != nil {
+				l.WarnContext(ctx, "job events listener connection error", slog.String("error", err.Error()))
+			}
+		},
+	)
+	defer func() { _ = listener.Close() }()
+	if err := listener.Listen(jobEventsChannel); err != nil {
+		l.ErrorContext(ctx, "failed to listen job events", slog.String("error", err.Error()))
+		return err
+	}
+
+	l.InfoContext(ctx, "listening job events")
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-ticker.C:
+			// Проверка соединения, при обрыве которого pq переподключается
+			go func() { _ = listener.Ping() }()
+
+		case n := <-listener.Notify:
+			if n == nil {
+				// Соединение восстановлено после обрыва
+				l.InfoContext(ctx, "job events listener reconnected")
+				continue
+			}
+			jl.forward(ctx, n.Extra)
+		}
+	}
+}
+
+func (jl *JobEventListener) forward(ctx context.Context, payload string) {
+	var n jobEventNotification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		jl.l.ErrorContext(ctx, "failed to unmarshal job event", slog.String("error", err.Error()))
+		return
+	}
+	if n.Kind != dto.JobEventOutput {
+		jl.publish(ctx, dto.JobEvent{JobID: n.JobID, Kind: n.Kind, State: n.State})
+		return
+	}
+	// Подписчики получают вывод построчно, как если бы строки были опубликованы по одной.
+	for _, line := range n.Lines {
+		jl.publish(ctx, dto.JobEvent{JobID: n.JobID, Kind: n.Kind, Stream: n.Stream, Line: line})
+	}
+}
+
+func (jl *JobEventListener) publish(ctx context.Context, e dto.JobEvent) {
+	if err := jl.p.PublishJobEvent(ctx, e); err != nil {
+		jl.l.ErrorContext(
+			ctx, "failed to publish job event",
+			slog.String("job_id", e.JobID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+)
+
+func TestEncodeJobEventNotification(t *testing.T) {
+	decode := func(t *testing.T, payloads [][]byte) []string {
+		var lines []string
+		for _, payload := range payloads {
+			require.LessOrEqual(t, len(payload), maxNotifyPayloadBytes)
+			var n jobEventNotification
+			require.NoError(t, json.Unmarshal(payload, &n))
+			require.Equal(t, "job", n.JobID)
+			lines = append(lines, n.Lines...)
+		}
+		return lines
+	}
+	output := func(lines ...string) jobEventNotification {
+		return jobEventNotification{JobID: "job", Kind: dto.JobEventOutput, Stream: dto.StreamStdout, Lines: lines}
+	}
+
+	t.Run("state notification is not split", func(t *testing.T) {
+		payloads, err := encodeJobEventNotification(jobEventNotification{JobID: "job", Kind: "state", State: "running"})
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+	})
+
+	t.Run("short lines are batched into one notification", func(t *testing.T) {
+		payloads, err := encodeJobEventNotification(output("a", "b", "c"))
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+		require.Equal(t, []string{"a", "b", "c"}, decode(t, payloads))
+	})
+
+	t.Run("lines exceeding the payload limit together are split", func(t *testing.T) {
+		line := strings.Repeat("x", 3000)
+		payloads, err := encodeJobEventNotification(output(line, line, line))
+		require.NoError(t, err)
+		require.Len(t, payloads, 2)
+		require.Equal(t, []string{line, line, line}, decode(t, payloads))
+	})
+
+	t.Run("line growing after escaping is truncated to fit", func(t *testing.T) {
+		// Каждый управляющий байт кодируется шестью: \u0001.
+		line := strings.Repeat("\x01", 4000)
+		payloads, err := encodeJobEventNotification(output(line))
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+		lines := decode(t, payloads)
+		require.Len(t, lines, 1)
+		require.True(t, strings.HasPrefix(line, lines[0]))
+		require.NotEmpty(t, lines[0])
+	})
+
+	t.Run("truncated line remains valid UTF-8", func(t *testing.T) {
+		line := strings.Repeat("\"ж", 4000)
+		payloads, err := encodeJobEventNotification(output(line))
+		require.NoError(t, err)
+		lines := decode(t, payloads)
+		require.Len(t, lines, 1)
+		require.True(t, strings.HasPrefix(line, lines[0]))
+	})
+}
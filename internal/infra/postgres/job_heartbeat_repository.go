@@ -0,0 +1,11 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) BeatJob(ctx context.Context, id value.JobID) error {
+	return r.updateJobHeartbeat(ctx, r.db, string(id))
+}
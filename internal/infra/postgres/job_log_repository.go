@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) SaveJobLog(ctx context.Context, id value.JobID, log string) error {
+	return r.upsertJobLogRow(ctx, r.db, jobLogRow{JobID: string(id), Log: log})
+}
+
+func (r *Repository) JobLog(ctx context.Context, id value.JobID) (string, error) {
+	row, err := r.selectJobLogRow(ctx, r.db, string(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %s", ports.ErrJobLogNotFound, string(id))
+	}
+	if err != nil {
+		return "", err
+	}
+	return row.Log, nil
+}
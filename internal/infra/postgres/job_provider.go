@@ -20,6 +20,7 @@ func (r *Repository) Job(ctx context.Context, id value.JobID) (dto.Job, error) {
 	var rOFs []jobFieldRow
 	var rIVs []jobValueRow
 	var rOVs []jobValueRow
+	var rAs []jobAttemptRow
 
 	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
 		var err error
@@ -43,6 +44,10 @@ func (r *Repository) Job(ctx context.Context, id value.JobID) (dto.Job, error) {
 		if err != nil {
 			return err
 		}
+		rAs, err = r.selectJobAttemptRows(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
 		return nil
 	})
 	if errors.Is(err, sql.ErrNoRows) {
@@ -52,7 +57,7 @@ func (r *Repository) Job(ctx context.Context, id value.JobID) (dto.Job, error) {
 		return dto.Job{}, err
 	}
 
-	return readJobRowToDTO(rJ, rIFs, rOFs, rIVs, rOVs), nil
+	return readJobRowToDTO(rJ, rIFs, rOFs, rIVs, rOVs, rAs), nil
 }
 
 func (r *Repository) UserJobs(ctx context.Context, uid value.UserID) ([]dto.Job, error) {
@@ -61,6 +66,7 @@ func (r *Repository) UserJobs(ctx context.Context, uid value.UserID) ([]dto.Job,
 	var rOFs map[string][]jobFieldRow
 	var rIVs map[string][]jobValueRow
 	var rOVs map[string][]jobValueRow
+	var rAs map[string][]jobAttemptRow
 
 	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
 		var err error
@@ -85,6 +91,10 @@ func (r *Repository) UserJobs(ctx context.Context, uid value.UserID) ([]dto.Job,
 		if err != nil {
 			return err
 		}
+		rAs, err = r.selectJobsAttemptRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
 		return nil
 	})
 	if err != nil {
@@ -93,7 +103,7 @@ func (r *Repository) UserJobs(ctx context.Context, uid value.UserID) ([]dto.Job,
 
 	js := make([]dto.Job, len(rJs))
 	for i, rJ := range rJs {
-		js[i] = readJobRowToDTO(rJ, rIFs[rJ.ID], rOFs[rJ.ID], rIVs[rJ.ID], rOVs[rJ.ID])
+		js[i] = readJobRowToDTO(rJ, rIFs[rJ.ID], rOFs[rJ.ID], rIVs[rJ.ID], rOVs[rJ.ID], rAs[rJ.ID])
 	}
 
 	return js, nil
@@ -109,6 +119,7 @@ func (r *Repository) UserJobsWithState(
 	var rOFs map[string][]jobFieldRow
 	var rIVs map[string][]jobValueRow
 	var rOVs map[string][]jobValueRow
+	var rAs map[string][]jobAttemptRow
 
 	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
 		var err error
@@ -133,6 +144,10 @@ func (r *Repository) UserJobsWithState(
 		if err != nil {
 			return err
 		}
+		rAs, err = r.selectJobsAttemptRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
 		return nil
 	})
 	if err != nil {
@@ -141,7 +156,7 @@ func (r *Repository) UserJobsWithState(
 
 	js := make([]dto.Job, len(rJs))
 	for i, rJ := range rJs {
-		js[i] = readJobRowToDTO(rJ, rIFs[rJ.ID], rOFs[rJ.ID], rIVs[rJ.ID], rOVs[rJ.ID])
+		js[i] = readJobRowToDTO(rJ, rIFs[rJ.ID], rOFs[rJ.ID], rIVs[rJ.ID], rOVs[rJ.ID], rAs[rJ.ID])
 	}
 
 	return js, nil
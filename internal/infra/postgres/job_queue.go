@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/config"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/pkg/workerpool"
+)
+
+// JobQueue -- очередь задач в таблице job.queue. В отличие от очереди в памяти, переживает перезапуск сервиса
+// и разделяется между всеми его экземплярами: задачу забирает тот, кто первым её захватит.
+//
+// Захват задачи -- аренда на cfg.LeaseTimeout: запись остаётся в очереди до завершения обработки, и если
+// захвативший задачу экземпляр аварийно завершится, после истечения аренды её заберёт другой. Неуспешная
+// обработка повторяется с задержкой до cfg.MaxRetries раз, после чего задача попадает в job.dead_letters.
+//
+// Экземпляр захватывает не больше задач, чем у него свободных обработчиков (cfg.MaxConcurrentJobs), поэтому
+// остальные задачи дожидаются в очереди, оставаясь доступными другим экземплярам.
+type JobQueue struct {
+	r    *Repository
+	cfg  config.Queue
+	pool *workerpool.Pool
+	l    *slog.Logger
+}
+
+func NewJobQueue(r *Repository, cfg config.Queue, l *slog.Logger) *JobQueue {
+	return &JobQueue{r, cfg, workerpool.New(cfg.MaxConcurrentJobs), l}
+}
+
+func (q *JobQueue) PublishJob(ctx context.Context, job *entity.Job) error {
+	return q.r.insertQueueRow(ctx, q.r.db, string(job.ID()))
+}
+
+func (q *JobQueue) Listen(ctx context.Context, callback func(ctx context.Context, jobID string) error) error {
+	l := q.l.With(slog.String("op", "postgres.JobQueue.Listen"))
+
+	l.InfoContext(ctx, "listening job queue", slog.Duration("poll_interval", q.cfg.PollInterval))
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		// Забираем задачи, пока очередь не опустеет, и только затем ждём следующего опроса.
+		for {
+			if err := q.pool.Acquire(ctx); err != nil {
+				return err
+			}
+			claim, err := q.r.claimQueueRow(ctx, q.r.db, q.cfg.LeaseTimeout)
+			if err != nil {
+				q.pool.Release()
+			}
+			if ctx.Err() != nil {
+				if err == nil {
+					// Задача захвачена одновременно с остановкой: без снятия захвата она дождалась бы
+					// истечения аренды.
+					q.pool.Release()
+					q.release(ctx, l, claim)
+				}
+				return ctx.Err()
+			} else if errors.Is(err, sql.ErrNoRows) {
+				break
+			} else if err != nil {
+				l.ErrorContext(ctx, "failed to claim job", slog.String("error", err.Error()))
+				break
+			}
+			go func() {
+				defer q.pool.Release()
+				q.handle(ctx, claim, callback)
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WorkerStats возвращает число выполняемых и ожидающих задач всех экземпляров сервиса, поэтому доступна и
+// процессу, который сам задачи не выполняет. MaxConcurrentJobs -- ограничение одного экземпляра.
+func (q *JobQueue) WorkerStats(ctx context.Context) (dto.WorkerStats, error) {
+	busy, err := q.r.countRunningJobRows(ctx, q.r.db)
+	if err != nil {
+		return dto.WorkerStats{}, err
+	}
+	depth, err := q.r.countReadyQueueRows(ctx, q.r.db)
+	if err != nil {
+		return dto.WorkerStats{}, err
+	}
+	return dto.WorkerStats{
+		MaxConcurrentJobs: q.pool.Stats().Size,
+		BusyWorkers:       busy,
+		QueueDepth:        depth,
+	}, nil
+}
+
+// release возвращает захваченную, но не обработанную задачу в очередь.
+func (q *JobQueue) release(ctx context.Context, l *slog.Logger, claim queueClaim) {
+	err := q.r.releaseQueueRow(context.WithoutCancel(ctx), q.r.db, claim)
+	if err != nil {
+		l.ErrorContext(
+			ctx, "failed to release job", slog.String("jobID", claim.JobID), slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (q *JobQueue) handle(
+	ctx context.Context, claim queueClaim, callback func(ctx context.Context, jobID string) error,
+) {
+	l := q.l.With(
+		slog.String("op", "postgres.JobQueue.handle"),
+		slog.String("jobID", claim.JobID),
+	)
+
+	// Запись очереди изменяется и после отмены ctx, иначе задача останется захваченной до истечения аренды.
+	dbCtx := context.WithoutCancel(ctx)
+
+	err := callback(context.Background(), claim.JobID)
+	if err == nil {
+		err = q.r.ackQueueRow(dbCtx, q.r.db, claim)
+		if errors.Is(err, pgutils.ErrNoAffectedRows) {
+			// Обработка поставила задачу на повторную доставку или аренда истекла и задачу захватили заново.
+			l.InfoContext(ctx, "handled job, queue row already replaced")
+			return
+		} else if err != nil {
+			l.ErrorContext(ctx, "failed to remove job from queue", slog.String("error", err.Error()))
+			return
+		}
+		l.InfoContext(ctx, "handled job")
+		return
+	}
+
+	attempt, fErr := q.r.failQueueRow(dbCtx, q.r.db, claim, q.cfg.RetryBackoff)
+	if errors.Is(fErr, sql.ErrNoRows) {
+		l.WarnContext(
+			ctx, "failed to handle job, queue row already replaced",
+			slog.String("error", err.Error()),
+		)
+		return
+	} else if fErr != nil {
+		l.ErrorContext(ctx, "failed to release job", slog.String("error", fErr.Error()))
+		return
+	}
+	if attempt <= q.cfg.MaxRetries {
+		delay := q.cfg.RetryDelay(attempt)
+		l.WarnContext(
+			ctx, "failed to handle job, retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	l.ErrorContext(
+		ctx, "failed to handle job, moving to dead letters",
+		slog.Int("attempts", attempt),
+		slog.String("error", err.Error()),
+	)
+	dErr := q.r.deadLetterQueued(dbCtx, claim, err.Error(), attempt)
+	if errors.Is(dErr, pgutils.ErrNoAffectedRows) {
+		l.WarnContext(ctx, "job queue row already replaced, not moving to dead letters")
+	} else if dErr != nil {
+		l.ErrorContext(ctx, "failed to move job to dead letters", slog.String("error", dErr.Error()))
+	}
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/config"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+	"github.com/bmstu-itstech/scriptum-back/pkg/logs"
+)
+
+// Тест требует базу данных с применёнными миграциями и пустой очередью job.queue.
+func TestJobQueue_ZeroBackoffRetry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+	uri := os.Getenv("POSTGRES_URI")
+	if uri == "" {
+		t.Skip("POSTGRES_URI is not set")
+	}
+
+	ctx := context.Background()
+	l := logs.NewLogger("local")
+	r := MustNewRepository(config.Postgres{URI: uri})
+	q := NewJobQueue(r, config.Queue{LeaseTimeout: time.Minute, MaxRetries: 3, MaxConcurrentJobs: 1}, l)
+	relay := NewOutboxRelay(r, q, time.Second, l)
+
+	b, err := entity.NewBlueprint(
+		value.NewUserID(), value.NewFileID(), "zero backoff", nil, value.VisibilityPrivate, nil, nil,
+		value.ResourceLimits{}, false, 0, value.MustNewRetryPolicy(2, 0, value.RetryOnAnyFailure),
+	)
+	require.NoError(t, err)
+	require.NoError(t, b.StartBuild())
+	require.NoError(t, b.CompleteBuild(value.NewImageTag("sc-test", b.ID())))
+	require.NoError(t, r.SaveBlueprint(ctx, b))
+
+	job, err := b.AssembleJob(b.OwnerID(), nil)
+	require.NoError(t, err)
+	require.NoError(t, r.SaveJob(ctx, job))
+	_, err = relay.relay(ctx)
+	require.NoError(t, err)
+
+	claim, err := r.claimQueueRow(ctx, r.db, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, string(job.ID()), claim.JobID)
+
+	// Попытка завершается неуспешно, и повторная доставка без задержки попадает в очередь раньше, чем
+	// обработка прошлой доставки подтверждена.
+	q.handle(ctx, claim, func(ctx context.Context, jobID string) error {
+		err2 := r.UpdateJob(ctx, value.JobID(jobID), func(_ context.Context, j *entity.Job) error {
+			if err3 := j.Run(); err3 != nil {
+				return err3
+			}
+			return j.Fail(value.FailureTimeout, "timeout")
+		})
+		if err2 != nil {
+			return err2
+		}
+		_, err2 = relay.relay(ctx)
+		return err2
+	})
+
+	retry, err := r.claimQueueRow(ctx, r.db, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, string(job.ID()), retry.JobID)
+	require.NotEqual(t, claim.ClaimID, retry.ClaimID)
+	require.NoError(t, r.ackQueueRow(ctx, r.db, retry))
+}
+
+// Тест требует базу данных с применёнными миграциями и пустой очередью job.queue.
+func TestJobQueue_ListenReleasesClaimOnCancel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+	uri := os.Getenv("POSTGRES_URI")
+	if uri == "" {
+		t.Skip("POSTGRES_URI is not set")
+	}
+
+	ctx := context.Background()
+	l := logs.NewLogger("local")
+	r := MustNewRepository(config.Postgres{URI: uri})
+	q := NewJobQueue(r, config.Queue{LeaseTimeout: time.Hour, MaxConcurrentJobs: 1}, l)
+	relay := NewOutboxRelay(r, q, time.Second, l)
+
+	b, err := entity.NewBlueprint(
+		value.NewUserID(), value.NewFileID(), "release on cancel", nil, value.VisibilityPrivate, nil, nil,
+		value.ResourceLimits{}, false, 0, value.RetryPolicy{},
+	)
+	require.NoError(t, err)
+	require.NoError(t, b.StartBuild())
+	require.NoError(t, b.CompleteBuild(value.NewImageTag("sc-test", b.ID())))
+	require.NoError(t, r.SaveBlueprint(ctx, b))
+
+	job, err := b.AssembleJob(b.OwnerID(), nil)
+	require.NoError(t, err)
+	require.NoError(t, r.SaveJob(ctx, job))
+	_, err = relay.relay(ctx)
+	require.NoError(t, err)
+
+	jobID := string(job.ID())
+	claim, err := r.claimQueueRow(ctx, r.db, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, jobID, claim.JobID)
+
+	// Захват, не обработанный из-за остановки, снова доступен без ожидания аренды.
+	q.release(ctx, l, claim)
+	retry, err := r.claimQueueRow(ctx, r.db, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, jobID, retry.JobID)
+	require.NoError(t, r.ackQueueRow(ctx, r.db, retry))
+}
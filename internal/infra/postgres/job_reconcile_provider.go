@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) OrphanedJobs(ctx context.Context, timeout time.Duration) ([]value.JobID, error) {
+	ids, err := r.selectOrphanedJobIDs(ctx, r.db, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return jobIDsToDomain(ids), nil
+}
+
+func (r *Repository) UndeliveredJobs(ctx context.Context) ([]value.JobID, error) {
+	ids, err := r.selectUndeliveredJobIDs(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	return jobIDsToDomain(ids), nil
+}
+
+func jobIDsToDomain(ids []string) []value.JobID {
+	res := make([]value.JobID, len(ids))
+	for i, id := range ids {
+		res[i] = value.JobID(id)
+	}
+	return res
+}
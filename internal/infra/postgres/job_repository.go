@@ -29,6 +29,12 @@ func (r *Repository) saveJob(ctx context.Context, ec sqlx.ExtContext, job *entit
 	if err := r.insertJobRow(ctx, ec, rJob); err != nil {
 		return err
 	}
+	if job.State() == value.JobPending {
+		// Публикация задачи фиксируется вместе с ней и выполняется OutboxRelay.
+		if err := r.insertOutboxRow(ctx, ec, string(job.ID())); err != nil {
+			return err
+		}
+	}
 	if len(job.Input()) > 0 {
 		rInput := jobValueRowsFromDomain(job.Input(), job.ID())
 		if err := r.insertJobInputValueRows(ctx, ec, rInput); err != nil {
@@ -49,6 +55,12 @@ func (r *Repository) saveJob(ctx context.Context, ec sqlx.ExtContext, job *entit
 			return err
 		}
 	}
+	if len(job.Attempts()) > 0 {
+		rAttempts := jobAttemptRowsFromDomain(job.Attempts(), job.ID())
+		if err := r.insertJobAttemptRows(ctx, ec, rAttempts); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -62,11 +74,18 @@ func (r *Repository) UpdateJob(
 		if err != nil {
 			return err
 		}
+		retryScheduled := job.NextAttemptAt() != nil
 		err = updateFn(ctx, job)
 		if err != nil {
 			return err
 		}
-		return r.updateJob(ctx, tx, job)
+		if err = r.updateJob(ctx, tx, job); err != nil {
+			return err
+		}
+		if !retryScheduled && job.NextAttemptAt() != nil {
+			return r.scheduleJobRetry(ctx, tx, job)
+		}
+		return nil
 	})
 	if errors.Is(err, sql.ErrNoRows) {
 		return fmt.Errorf("%w: %s", ports.ErrJobNotFound, id)
@@ -74,6 +93,21 @@ func (r *Repository) UpdateJob(
 	return err
 }
 
+// scheduleJobRetry ставит задачу, вернувшуюся в ожидание после неуспешной попытки, на публикацию не раньше
+// времени следующей попытки. Запись очереди прошлой доставки удаляется сразу, иначе повторная доставка не
+// попадёт в очередь (см. insertQueueRow); подтверждение прошлой доставки удаляет только запись своего захвата
+// (см. ackQueueRow), поэтому запись повторной доставки сохранится, даже если появится раньше подтверждения.
+func (r *Repository) scheduleJobRetry(ctx context.Context, ec sqlx.ExecerContext, job *entity.Job) error {
+	if err := r.deleteQueueRow(ctx, ec, string(job.ID())); err != nil {
+		return err
+	}
+	return r.insertDelayedOutboxRow(ctx, ec, string(job.ID()), *job.NextAttemptAt())
+}
+
+func (r *Repository) RepublishJob(ctx context.Context, id value.JobID) error {
+	return r.insertOutboxRow(ctx, r.db, string(id))
+}
+
 func (r *Repository) job(ctx context.Context, qc sqlx.QueryerContext, id value.JobID) (*entity.Job, error) {
 	rJob, err := r.selectJobRow(ctx, qc, string(id))
 	if err != nil {
@@ -91,7 +125,11 @@ func (r *Repository) job(ctx context.Context, qc sqlx.QueryerContext, id value.J
 	if err != nil {
 		return nil, err
 	}
-	job, err := jobRowToDomain(rJob, rInput, rOutput, rOut)
+	rAttempts, err := r.selectJobAttemptRows(ctx, qc, string(id))
+	if err != nil {
+		return nil, err
+	}
+	job, err := jobRowToDomain(rJob, rInput, rOutput, rOut, rAttempts)
 	if err != nil {
 		return nil, err
 	}
@@ -123,5 +161,11 @@ func (r *Repository) updateJob(ctx context.Context, ec sqlx.ExtContext, job *ent
 			return err
 		}
 	}
+	if len(job.Attempts()) > 0 {
+		rAttempts := jobAttemptRowsFromDomain(job.Attempts(), job.ID())
+		if err := r.insertJobAttemptRows(ctx, ec, rAttempts); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -1,6 +1,9 @@
 package postgres
 
 import (
+	"errors"
+	"time"
+
 	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
 	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
 	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
@@ -11,7 +14,11 @@ func blueprintFieldRowToDomain(row blueprintFieldRow) (value.Field, error) {
 	if err != nil {
 		return value.Field{}, err
 	}
-	return value.NewField(t, row.Name, row.Desc, row.Unit)
+	c, err := value.NewFieldConstraints(t, row.Name, row.MinValue, row.MaxValue, row.MinLength, row.MaxLength, row.Pattern)
+	if err != nil {
+		return value.Field{}, err
+	}
+	return value.NewField(t, row.Name, row.Desc, row.Unit, row.Options, c, row.Default)
 }
 
 func blueprintFieldRowsToDomain(rows []blueprintFieldRow) ([]value.Field, error) {
@@ -56,6 +63,22 @@ func blueprintRowToDomain(rB blueprintRow, rInput []blueprintFieldRow, rOutput [
 	if err != nil {
 		return nil, err
 	}
+	status, err := value.BuildStatusFromString(rB.BuildStatus)
+	if err != nil {
+		return nil, err
+	}
+	var image value.ImageTag
+	if rB.Image != nil {
+		image = value.ImageTag(*rB.Image)
+	}
+	limits, err := resourceLimitsColumnsToDomain(rB.resourceLimitsColumns)
+	if err != nil {
+		return nil, err
+	}
+	retryPolicy, err := retryPolicyColumnsToDomain(rB.retryPolicyColumns)
+	if err != nil {
+		return nil, err
+	}
 	return entity.RestoreBlueprint(
 		value.BlueprintID(rB.ID),
 		value.UserID(rB.OwnerID),
@@ -65,7 +88,13 @@ func blueprintRowToDomain(rB blueprintRow, rInput []blueprintFieldRow, rOutput [
 		vis,
 		in,
 		out,
+		limits,
+		rB.Network,
+		time.Duration(rB.TimeoutSec)*time.Second,
+		retryPolicy,
 		rB.CreatedAt,
+		status,
+		image,
 	)
 }
 
@@ -73,17 +102,30 @@ func blueprintWithUserRowToDTO(rB blueprintWithUserRow, rInput []blueprintFieldR
 	in := blueprintFieldRowsToDTO(rInput)
 	out := blueprintFieldRowsToDTO(rOutput)
 	return dto.BlueprintWithUser{
-		ID:         rB.ID,
-		ArchiveID:  rB.ArchiveID,
-		Name:       rB.Name,
-		Desc:       rB.Desc,
-		Visibility: rB.Vis,
-		In:         in,
-		Out:        out,
-		OwnerID:    rB.OwnerID,
-		OwnerName:  rB.OwnerName,
-		CreatedAt:  rB.CreatedAt,
+		ID:          rB.ID,
+		ArchiveID:   rB.ArchiveID,
+		Name:        rB.Name,
+		Desc:        rB.Desc,
+		Visibility:  rB.Vis,
+		In:          in,
+		Out:         out,
+		OwnerID:     rB.OwnerID,
+		OwnerName:   rB.OwnerName,
+		CreatedAt:   rB.CreatedAt,
+		BuildStatus: rB.BuildStatus,
+		Limits:      resourceLimitsColumnsToDTO(rB.resourceLimitsColumns),
+		Network:     rB.Network,
+		Timeout:     time.Duration(rB.TimeoutSec) * time.Second,
+		RetryPolicy: retryPolicyColumnsToDTO(rB.retryPolicyColumns),
+	}
+}
+
+func defaultRowFromDomain(v *value.Value) *string {
+	if v == nil {
+		return nil
 	}
+	s := v.String()
+	return &s
 }
 
 func blueprintFieldRowsFromDomain(fields []value.Field, blueprintID value.BlueprintID) []blueprintFieldRow {
@@ -96,20 +138,84 @@ func blueprintFieldRowsFromDomain(fields []value.Field, blueprintID value.Bluepr
 			Name:        field.Name(),
 			Desc:        field.Desc(),
 			Unit:        field.Unit(),
+			Options:     field.Options(),
+			MinValue:    field.Constraints().Min(),
+			MaxValue:    field.Constraints().Max(),
+			MinLength:   field.Constraints().MinLength(),
+			MaxLength:   field.Constraints().MaxLength(),
+			Pattern:     field.Constraints().Pattern(),
+			Default:     defaultRowFromDomain(field.Default()),
 		}
 	}
 	return res
 }
 
 func blueprintRowFromDomain(b *entity.Blueprint) blueprintRow {
+	var optImage *string
+	if b.Image() != "" {
+		image := string(b.Image())
+		optImage = &image
+	}
 	return blueprintRow{
-		ID:        string(b.ID()),
-		OwnerID:   string(b.OwnerID()),
-		ArchiveID: string(b.ArchiveID()),
-		Name:      b.Name(),
-		Desc:      b.Desc(),
-		Vis:       b.Vis().String(),
-		CreatedAt: b.CreatedAt(),
+		ID:                    string(b.ID()),
+		OwnerID:               string(b.OwnerID()),
+		ArchiveID:             string(b.ArchiveID()),
+		Name:                  b.Name(),
+		Desc:                  b.Desc(),
+		Vis:                   b.Vis().String(),
+		CreatedAt:             b.CreatedAt(),
+		BuildStatus:           b.BuildStatus().String(),
+		Image:                 optImage,
+		Network:               b.Network(),
+		TimeoutSec:            int64(b.Timeout() / time.Second),
+		resourceLimitsColumns: resourceLimitsColumnsFromDomain(b.Limits()),
+		retryPolicyColumns:    retryPolicyColumnsFromDomain(b.RetryPolicy()),
+	}
+}
+
+func resourceLimitsColumnsToDomain(c resourceLimitsColumns) (value.ResourceLimits, error) {
+	return value.NewResourceLimits(c.LimitMemoryMB, c.LimitCPUs, c.LimitPids, c.LimitTmpfsMB)
+}
+
+func resourceLimitsColumnsToDTO(c resourceLimitsColumns) dto.ResourceLimits {
+	return dto.ResourceLimits{
+		MemoryMB: c.LimitMemoryMB,
+		CPUs:     c.LimitCPUs,
+		Pids:     c.LimitPids,
+		TmpfsMB:  c.LimitTmpfsMB,
+	}
+}
+
+func resourceLimitsColumnsFromDomain(l value.ResourceLimits) resourceLimitsColumns {
+	return resourceLimitsColumns{
+		LimitMemoryMB: l.MemoryMB(),
+		LimitCPUs:     l.CPUs(),
+		LimitPids:     l.Pids(),
+		LimitTmpfsMB:  l.TmpfsMB(),
+	}
+}
+
+func retryPolicyColumnsToDomain(c retryPolicyColumns) (value.RetryPolicy, error) {
+	on, err := value.RetryOnFromString(c.RetryOn)
+	if err != nil {
+		return value.RetryPolicy{}, err
+	}
+	return value.NewRetryPolicy(c.RetryMaxAttempts, time.Duration(c.RetryBackoffSec)*time.Second, on)
+}
+
+func retryPolicyColumnsToDTO(c retryPolicyColumns) dto.RetryPolicy {
+	return dto.RetryPolicy{
+		MaxAttempts: c.RetryMaxAttempts,
+		Backoff:     time.Duration(c.RetryBackoffSec) * time.Second,
+		On:          c.RetryOn,
+	}
+}
+
+func retryPolicyColumnsFromDomain(p value.RetryPolicy) retryPolicyColumns {
+	return retryPolicyColumns{
+		RetryMaxAttempts: p.MaxAttempts(),
+		RetryBackoffSec:  int64(p.Backoff() / time.Second),
+		RetryOn:          p.On().String(),
 	}
 }
 
@@ -138,7 +244,11 @@ func jobFieldRowToDomain(row jobFieldRow) (value.Field, error) {
 	if err != nil {
 		return value.Field{}, err
 	}
-	return value.NewField(t, row.Name, row.Desc, row.Unit)
+	c, err := value.NewFieldConstraints(t, row.Name, row.MinValue, row.MaxValue, row.MinLength, row.MaxLength, row.Pattern)
+	if err != nil {
+		return value.Field{}, err
+	}
+	return value.NewField(t, row.Name, row.Desc, row.Unit, row.Options, c, row.Default)
 }
 
 func jobFieldRowsToDomain(rows []jobFieldRow) ([]value.Field, error) {
@@ -154,7 +264,7 @@ func jobFieldRowsToDomain(rows []jobFieldRow) ([]value.Field, error) {
 }
 
 func jobRowToDomain(
-	rJob jobRow, rInput []jobValueRow, rOutput []jobValueRow, rOut []jobFieldRow,
+	rJob jobRow, rInput []jobValueRow, rOutput []jobValueRow, rOut []jobFieldRow, rAttempts []jobAttemptRow,
 ) (*entity.Job, error) {
 	input, err := jobValueRowsToDomain(rInput)
 	if err != nil {
@@ -172,26 +282,127 @@ func jobRowToDomain(
 	if err != nil {
 		return nil, err
 	}
+	var image value.ImageTag
+	if rJob.Image != nil {
+		image = value.ImageTag(*rJob.Image)
+	}
+	limits, err := resourceLimitsColumnsToDomain(rJob.resourceLimitsColumns)
+	if err != nil {
+		return nil, err
+	}
+	retryPolicy, err := retryPolicyColumnsToDomain(rJob.retryPolicyColumns)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := jobAttemptRowsToDomain(rAttempts)
+	if err != nil {
+		return nil, err
+	}
 	var result *value.JobResult
 	if rJob.ResultCode != nil {
-		r := value.NewJobResult(value.ExitCode(*rJob.ResultCode), output, rJob.ResultMsg)
+		reason, err := failureReasonFromColumn(rJob.ResultReason)
+		if err != nil {
+			return nil, err
+		}
+		r := value.NewJobResult(value.ExitCode(*rJob.ResultCode), output, rJob.ResultMsg, reason)
 		result = &r
 	}
+	var parentID *value.JobID
+	if rJob.ParentJobID != nil {
+		id := value.JobID(*rJob.ParentJobID)
+		parentID = &id
+	}
+	var batchID *value.BatchID
+	if rJob.BatchID != nil {
+		id := value.BatchID(*rJob.BatchID)
+		batchID = &id
+	}
+	var scheduleID *value.ScheduleID
+	if rJob.ScheduleID != nil {
+		id := value.ScheduleID(*rJob.ScheduleID)
+		scheduleID = &id
+	}
 	return entity.RestoreJob(
 		value.JobID(rJob.ID),
+		parentID,
+		batchID,
+		scheduleID,
 		value.BlueprintID(rJob.BlueprintID),
 		value.FileID(rJob.ArchiveID),
+		image,
+		limits,
+		rJob.Network,
+		time.Duration(rJob.TimeoutSec)*time.Second,
+		retryPolicy,
 		value.UserID(rJob.OwnerID),
 		state,
 		input,
 		out,
 		rJob.CreatedAt,
+		attempts,
+		rJob.NextAttemptAt,
 		rJob.StartedAt,
 		result,
 		rJob.FinishedAt,
 	)
 }
 
+func failureReasonFromColumn(s *string) (value.FailureReason, error) {
+	if s == nil {
+		return value.FailureReason{}, nil
+	}
+	return value.FailureReasonFromString(*s)
+}
+
+func jobAttemptRowsToDomain(rows []jobAttemptRow) ([]value.JobAttempt, error) {
+	res := make([]value.JobAttempt, len(rows))
+	for i, row := range rows {
+		reason, err := failureReasonFromColumn(row.ResultReason)
+		if err != nil {
+			return nil, err
+		}
+		r := value.NewJobResult(value.ExitCode(row.ResultCode), nil, row.ResultMsg, reason)
+		res[i] = value.NewJobAttempt(row.Number, row.StartedAt, row.FinishedAt, r)
+	}
+	return res, nil
+}
+
+func jobAttemptRowsFromDomain(attempts []value.JobAttempt, jobID value.JobID) []jobAttemptRow {
+	res := make([]jobAttemptRow, len(attempts))
+	for i, a := range attempts {
+		var optReason *string
+		if !a.Result().Reason().IsZero() {
+			reason := a.Result().Reason().String()
+			optReason = &reason
+		}
+		res[i] = jobAttemptRow{
+			JobID:        string(jobID),
+			Number:       a.Number(),
+			StartedAt:    a.StartedAt(),
+			FinishedAt:   a.FinishedAt(),
+			ResultCode:   int(a.Result().Code()),
+			ResultMsg:    a.Result().Message(),
+			ResultReason: optReason,
+		}
+	}
+	return res
+}
+
+func jobAttemptRowsToDTOs(rs []jobAttemptRow) []dto.JobAttempt {
+	res := make([]dto.JobAttempt, len(rs))
+	for i, r := range rs {
+		res[i] = dto.JobAttempt{
+			Number:       r.Number,
+			StartedAt:    r.StartedAt,
+			FinishedAt:   r.FinishedAt,
+			ResultCode:   r.ResultCode,
+			ResultMsg:    r.ResultMsg,
+			ResultReason: r.ResultReason,
+		}
+	}
+	return res
+}
+
 func jobFieldsToDTOs(rs []jobFieldRow) []dto.Field {
 	res := make([]dto.Field, len(rs))
 	for i, r := range rs {
@@ -217,10 +428,18 @@ func jobValuesToDTOs(rs []jobValueRow) []dto.Value {
 }
 
 func readJobRowToDTO(
-	rJ readJobRow, rIFs []jobFieldRow, rOSs []jobFieldRow, rIVs []jobValueRow, rOVs []jobValueRow,
+	rJ readJobRow,
+	rIFs []jobFieldRow,
+	rOSs []jobFieldRow,
+	rIVs []jobValueRow,
+	rOVs []jobValueRow,
+	rAs []jobAttemptRow,
 ) dto.Job {
 	return dto.Job{
 		ID:            rJ.ID,
+		ParentJobID:   rJ.ParentJobID,
+		BatchID:       rJ.BatchID,
+		ScheduleID:    rJ.ScheduleID,
 		OwnerID:       rJ.OwnerID,
 		BlueprintID:   rJ.BlueprintID,
 		BlueprintName: rJ.BlueprintName,
@@ -231,7 +450,10 @@ func readJobRowToDTO(
 		Output:        jobValuesToDTOs(rOVs),
 		ResultCode:    rJ.ResultCode,
 		ResultMsg:     rJ.ResultMsg,
+		ResultReason:  rJ.ResultReason,
+		Attempts:      jobAttemptRowsToDTOs(rAs),
 		CreatedAt:     rJ.CreatedAt,
+		NextAttemptAt: rJ.NextAttemptAt,
 		StartedAt:     rJ.StartedAt,
 		FinishedAt:    rJ.FinishedAt,
 	}
@@ -292,35 +514,359 @@ func jobFieldRowsFromDomain(fields []value.Field, jobID value.JobID) []jobFieldR
 	res := make([]jobFieldRow, len(fields))
 	for i, f := range fields {
 		res[i] = jobFieldRow{
-			JobID: string(jobID),
-			Index: i,
-			Type:  f.Type().String(),
-			Name:  f.Name(),
-			Desc:  f.Desc(),
-			Unit:  f.Unit(),
+			JobID:   string(jobID),
+			Index:   i,
+			Type:    f.Type().String(),
+			Name:    f.Name(),
+			Desc:    f.Desc(),
+			Unit:    f.Unit(),
+			Options: f.Options(),
 		}
 	}
 	return res
 }
 
 func jobRowFromDomain(job *entity.Job) jobRow {
+	var optImage *string
+	if job.Image() != "" {
+		image := string(job.Image())
+		optImage = &image
+	}
+	var optParentID *string
+	if id := job.ParentID(); id != nil {
+		parentID := string(*id)
+		optParentID = &parentID
+	}
+	var optBatchID *string
+	if id := job.BatchID(); id != nil {
+		batchID := string(*id)
+		optBatchID = &batchID
+	}
+	var optScheduleID *string
+	if id := job.ScheduleID(); id != nil {
+		scheduleID := string(*id)
+		optScheduleID = &scheduleID
+	}
 	var optCode *int
 	var optMsg *string
+	var optReason *string
 	if r := job.Result(); r != nil {
 		code := int(r.Code())
 		optCode = &code
 		optMsg = r.Message()
+		if !r.Reason().IsZero() {
+			reason := r.Reason().String()
+			optReason = &reason
+		}
 	}
 	return jobRow{
-		ID:          string(job.ID()),
-		BlueprintID: string(job.BlueprintID()),
-		ArchiveID:   string(job.ArchiveID()),
-		OwnerID:     string(job.OwnerID()),
-		State:       job.State().String(),
-		CreatedAt:   job.CreatedAt(),
-		StartedAt:   job.StartedAt(),
-		ResultCode:  optCode,
-		ResultMsg:   optMsg,
-		FinishedAt:  job.FinishedAt(),
+		ID:                    string(job.ID()),
+		ParentJobID:           optParentID,
+		BatchID:               optBatchID,
+		ScheduleID:            optScheduleID,
+		BlueprintID:           string(job.BlueprintID()),
+		ArchiveID:             string(job.ArchiveID()),
+		Image:                 optImage,
+		Network:               job.Network(),
+		TimeoutSec:            int64(job.Timeout() / time.Second),
+		OwnerID:               string(job.OwnerID()),
+		State:                 job.State().String(),
+		CreatedAt:             job.CreatedAt(),
+		NextAttemptAt:         job.NextAttemptAt(),
+		StartedAt:             job.StartedAt(),
+		ResultCode:            optCode,
+		ResultMsg:             optMsg,
+		ResultReason:          optReason,
+		resourceLimitsColumns: resourceLimitsColumnsFromDomain(job.Limits()),
+		retryPolicyColumns:    retryPolicyColumnsFromDomain(job.RetryPolicy()),
+		FinishedAt:            job.FinishedAt(),
+	}
+}
+
+func batchRowFromDomain(b *entity.Batch) batchRow {
+	return batchRow{
+		ID:          string(b.ID()),
+		BlueprintID: string(b.BlueprintID()),
+		OwnerID:     string(b.OwnerID()),
+		Size:        b.Size(),
+		CreatedAt:   b.CreatedAt(),
+	}
+}
+
+func readBatchRowToDTO(r readBatchRow, jobIDs []string) dto.Batch {
+	return dto.Batch{
+		ID:            r.ID,
+		BlueprintID:   r.BlueprintID,
+		BlueprintName: r.BlueprintName,
+		OwnerID:       r.OwnerID,
+		Size:          r.Size,
+		Progress: dto.BatchProgress{
+			Pending:   r.Pending,
+			Running:   r.Running,
+			Finished:  r.Finished,
+			Failed:    r.Failed,
+			Cancelled: r.Cancelled,
+		},
+		JobIDs:    jobIDs,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func scheduleRowFromDomain(s *entity.Schedule) scheduleRow {
+	var optLastJobID *string
+	if id := s.LastJobID(); id != nil {
+		lastJobID := string(*id)
+		optLastJobID = &lastJobID
+	}
+	return scheduleRow{
+		ID:            string(s.ID()),
+		BlueprintID:   string(s.BlueprintID()),
+		OwnerID:       string(s.OwnerID()),
+		Cron:          s.Cron().String(),
+		Timezone:      s.Location().String(),
+		OverlapPolicy: s.OverlapPolicy().String(),
+		Enabled:       s.Enabled(),
+		LastJobID:     optLastJobID,
+		NextRunAt:     s.NextRunAt(),
+		CreatedAt:     s.CreatedAt(),
+	}
+}
+
+func scheduleValueRowsFromDomain(values []value.Value, scheduleID value.ScheduleID) []scheduleValueRow {
+	res := make([]scheduleValueRow, len(values))
+	for i, v := range values {
+		res[i] = scheduleValueRow{
+			ScheduleID: string(scheduleID),
+			Index:      i,
+			Type:       v.Type().String(),
+			Value:      v.String(),
+		}
+	}
+	return res
+}
+
+func scheduleRowToDomain(row scheduleRow, rValues []scheduleValueRow) (*entity.Schedule, error) {
+	input := make([]value.Value, len(rValues))
+	for i, rV := range rValues {
+		t, err := value.TypeFromString(rV.Type)
+		if err != nil {
+			return nil, err
+		}
+		input[i], err = value.NewValue(t, rV.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cron, err := value.CronExprFromString(row.Cron)
+	if err != nil {
+		return nil, err
+	}
+	location, err := value.LocationFromString(row.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	overlap, err := value.OverlapPolicyFromString(row.OverlapPolicy)
+	if err != nil {
+		return nil, err
+	}
+	var lastJobID *value.JobID
+	if row.LastJobID != nil {
+		id := value.JobID(*row.LastJobID)
+		lastJobID = &id
+	}
+	return entity.RestoreSchedule(
+		value.ScheduleID(row.ID),
+		value.BlueprintID(row.BlueprintID),
+		value.UserID(row.OwnerID),
+		input,
+		cron,
+		location,
+		overlap,
+		row.Enabled,
+		lastJobID,
+		row.NextRunAt,
+		row.CreatedAt,
+	)
+}
+
+func scheduleRowToDTO(row scheduleRow, rValues []scheduleValueRow) dto.Schedule {
+	input := make([]dto.Value, len(rValues))
+	for i, rV := range rValues {
+		input[i] = dto.Value{
+			Type:  rV.Type,
+			Value: rV.Value,
+		}
+	}
+	return dto.Schedule{
+		ID:            row.ID,
+		BlueprintID:   row.BlueprintID,
+		OwnerID:       row.OwnerID,
+		Input:         input,
+		Cron:          row.Cron,
+		Timezone:      row.Timezone,
+		OverlapPolicy: row.OverlapPolicy,
+		Enabled:       row.Enabled,
+		LastJobID:     row.LastJobID,
+		NextRunAt:     row.NextRunAt,
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+func pipelineRowsFromDomain(
+	p *entity.Pipeline,
+) (pipelineRow, []pipelineStepRow, []pipelineStepInputRow) {
+	rSteps := make([]pipelineStepRow, len(p.Steps()))
+	rInputs := make([]pipelineStepInputRow, 0)
+	for i, step := range p.Steps() {
+		rSteps[i] = pipelineStepRow{
+			PipelineID:  string(p.ID()),
+			Index:       i,
+			Name:        step.Name(),
+			BlueprintID: string(step.BlueprintID()),
+		}
+		for k, in := range step.Input() {
+			rIn := pipelineStepInputRow{
+				PipelineID: string(p.ID()),
+				StepIndex:  i,
+				Index:      k,
+			}
+			if v, ok := in.Const(); ok {
+				t := v.Type().String()
+				s := v.String()
+				rIn.Type = &t
+				rIn.Value = &s
+			} else {
+				step := in.Step()
+				field := in.Field()
+				rIn.SourceStep = &step
+				rIn.SourceField = &field
+			}
+			rInputs = append(rInputs, rIn)
+		}
+	}
+	return pipelineRow{
+		ID:          string(p.ID()),
+		OwnerID:     string(p.OwnerID()),
+		Name:        p.Name(),
+		Description: p.Desc(),
+		CreatedAt:   p.CreatedAt(),
+	}, rSteps, rInputs
+}
+
+func pipelineStepInputRowToDomain(row pipelineStepInputRow) (value.StepInput, error) {
+	if row.Type == nil || row.Value == nil {
+		if row.SourceStep == nil || row.SourceField == nil {
+			return value.StepInput{}, errors.New("pipeline step input has neither value nor source")
+		}
+		return value.NewOutputStepInput(*row.SourceStep, *row.SourceField)
+	}
+	t, err := value.TypeFromString(*row.Type)
+	if err != nil {
+		return value.StepInput{}, err
+	}
+	v, err := value.NewValue(t, *row.Value)
+	if err != nil {
+		return value.StepInput{}, err
+	}
+	return value.NewConstStepInput(v), nil
+}
+
+func pipelineRowToDomain(
+	row pipelineRow, rSteps []pipelineStepRow, rInputs []pipelineStepInputRow,
+) (*entity.Pipeline, error) {
+	inputs := make(map[int][]value.StepInput, len(rSteps))
+	for _, rIn := range rInputs {
+		in, err := pipelineStepInputRowToDomain(rIn)
+		if err != nil {
+			return nil, err
+		}
+		inputs[rIn.StepIndex] = append(inputs[rIn.StepIndex], in)
+	}
+	steps := make([]value.PipelineStep, len(rSteps))
+	for i, rStep := range rSteps {
+		var err error
+		steps[i], err = value.NewPipelineStep(rStep.Name, value.BlueprintID(rStep.BlueprintID), inputs[rStep.Index])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entity.RestorePipeline(
+		value.PipelineID(row.ID),
+		value.UserID(row.OwnerID),
+		row.Name,
+		row.Description,
+		steps,
+		row.CreatedAt,
+	)
+}
+
+func pipelineRunRowFromDomain(r *entity.PipelineRun) pipelineRunRow {
+	return pipelineRunRow{
+		ID:         string(r.ID()),
+		PipelineID: string(r.PipelineID()),
+		OwnerID:    string(r.OwnerID()),
+		State:      r.State().String(),
+		CreatedAt:  r.CreatedAt(),
+		FinishedAt: r.FinishedAt(),
+	}
+}
+
+func pipelineRunStepRowsFromDomain(r *entity.PipelineRun) []pipelineRunStepRow {
+	res := make([]pipelineRunStepRow, len(r.Steps()))
+	for i, s := range r.Steps() {
+		var optJobID *string
+		if id := s.JobID(); id != nil {
+			jobID := string(*id)
+			optJobID = &jobID
+		}
+		res[i] = pipelineRunStepRow{
+			RunID:   string(r.ID()),
+			Index:   i,
+			Name:    s.Name(),
+			State:   s.State().String(),
+			JobID:   optJobID,
+			Message: s.Message(),
+		}
+	}
+	return res
+}
+
+func pipelineRunRowToDomain(row pipelineRunRow, rSteps []pipelineRunStepRow) (*entity.PipelineRun, error) {
+	state, err := value.PipelineRunStateFromString(row.State)
+	if err != nil {
+		return nil, err
+	}
+	steps := make([]value.StepRun, len(rSteps))
+	for i, rStep := range rSteps {
+		stepState, err := value.StepStateFromString(rStep.State)
+		if err != nil {
+			return nil, err
+		}
+		var jobID *value.JobID
+		if rStep.JobID != nil {
+			id := value.JobID(*rStep.JobID)
+			jobID = &id
+		}
+		steps[i] = value.NewStepRun(rStep.Name, stepState, jobID, rStep.Message)
+	}
+	return entity.RestorePipelineRun(
+		value.PipelineRunID(row.ID),
+		value.PipelineID(row.PipelineID),
+		value.UserID(row.OwnerID),
+		state,
+		steps,
+		row.CreatedAt,
+		row.FinishedAt,
+	)
+}
+
+func deadLetterRowToDTO(r readDeadLetterRow) dto.DeadLetter {
+	return dto.DeadLetter{
+		JobID:     r.JobID,
+		OwnerID:   r.OwnerID,
+		State:     r.State,
+		Error:     r.Error,
+		Attempts:  r.Attempts,
+		CreatedAt: r.CreatedAt,
 	}
 }
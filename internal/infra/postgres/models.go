@@ -1,60 +1,247 @@
 package postgres
 
-import "time"
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
 
 type blueprintRow struct {
-	ID        string    `db:"id"`
-	OwnerID   string    `db:"owner_id"`
-	ArchiveID string    `db:"archive_id"`
-	Name      string    `db:"name"`
-	Desc      *string   `db:"desc"`
-	Vis       string    `db:"vis"`
-	CreatedAt time.Time `db:"created_at"`
+	ID          string    `db:"id"`
+	OwnerID     string    `db:"owner_id"`
+	ArchiveID   string    `db:"archive_id"`
+	Name        string    `db:"name"`
+	Desc        *string   `db:"desc"`
+	Vis         string    `db:"vis"`
+	CreatedAt   time.Time `db:"created_at"`
+	BuildStatus string    `db:"build_status"`
+	Image       *string   `db:"image"`
+	Network     bool      `db:"network"`
+	TimeoutSec  int64     `db:"timeout_seconds"`
+	resourceLimitsColumns
+	retryPolicyColumns
+}
+
+type resourceLimitsColumns struct {
+	LimitMemoryMB int64   `db:"limit_memory_mb"`
+	LimitCPUs     float64 `db:"limit_cpus"`
+	LimitPids     int64   `db:"limit_pids"`
+	LimitTmpfsMB  int64   `db:"limit_tmpfs_mb"`
+}
+
+type retryPolicyColumns struct {
+	RetryMaxAttempts int    `db:"retry_max_attempts"`
+	RetryBackoffSec  int64  `db:"retry_backoff_seconds"`
+	RetryOn          string `db:"retry_on"`
 }
 
 type blueprintWithUserRow struct {
-	ID        string    `db:"id"`
-	ArchiveID string    `db:"archive_id"`
-	Name      string    `db:"name"`
-	Desc      *string   `db:"desc"`
-	Vis       string    `db:"vis"`
+	ID          string    `db:"id"`
+	ArchiveID   string    `db:"archive_id"`
+	Name        string    `db:"name"`
+	Desc        *string   `db:"desc"`
+	Vis         string    `db:"vis"`
+	OwnerID     string    `db:"owner_id"`
+	OwnerName   string    `db:"owner_name"`
+	CreatedAt   time.Time `db:"created_at"`
+	BuildStatus string    `db:"build_status"`
+	Network     bool      `db:"network"`
+	TimeoutSec  int64     `db:"timeout_seconds"`
+	resourceLimitsColumns
+	retryPolicyColumns
+}
+
+type buildLogRow struct {
+	BlueprintID string `db:"blueprint_id"`
+	Log         string `db:"log"`
+}
+
+type fileRow struct {
+	ID      string `db:"id"`
+	OwnerID string `db:"owner_id"`
+	Name    string `db:"name"`
+}
+
+type outboxRow struct {
+	ID    int64  `db:"id"`
+	JobID string `db:"job_id"`
+}
+
+// queueClaim -- захват записи очереди. ClaimID отличает его от последующих захватов той же задачи.
+type queueClaim struct {
+	JobID   string `db:"job_id"`
+	ClaimID int64  `db:"claim_id"`
+}
+
+type deadLetterRow struct {
+	JobID    string `db:"job_id"`
+	Error    string `db:"error"`
+	Attempts int    `db:"attempts"`
+}
+
+type readDeadLetterRow struct {
+	JobID     string    `db:"job_id"`
 	OwnerID   string    `db:"owner_id"`
-	OwnerName string    `db:"owner_name"`
+	State     string    `db:"state"`
+	Error     string    `db:"error"`
+	Attempts  int       `db:"attempts"`
 	CreatedAt time.Time `db:"created_at"`
 }
 
+type jobLogRow struct {
+	JobID string `db:"job_id"`
+	Log   string `db:"log"`
+}
+
 type blueprintFieldRow struct {
-	BlueprintID string  `db:"blueprint_id"`
-	Index       int     `db:"index"`
-	Type        string  `db:"type"`
-	Name        string  `db:"name"`
-	Desc        *string `db:"desc"`
-	Unit        *string `db:"unit"`
+	BlueprintID string         `db:"blueprint_id"`
+	Index       int            `db:"index"`
+	Type        string         `db:"type"`
+	Name        string         `db:"name"`
+	Desc        *string        `db:"desc"`
+	Unit        *string        `db:"unit"`
+	Options     pq.StringArray `db:"options"`
+	MinValue    *string        `db:"min_value"`
+	MaxValue    *string        `db:"max_value"`
+	MinLength   *int           `db:"min_length"`
+	MaxLength   *int           `db:"max_length"`
+	Pattern     *string        `db:"pattern"`
+	Default     *string        `db:"default_value"`
 }
 
 type jobRow struct {
-	ID          string     `db:"id"`
-	BlueprintID string     `db:"blueprint_id"`
-	ArchiveID   string     `db:"archive_id"`
-	OwnerID     string     `db:"owner_id"`
-	State       string     `db:"state"`
-	CreatedAt   time.Time  `db:"created_at"`
-	StartedAt   *time.Time `db:"started_at"`
-	ResultCode  *int       `db:"result_code"`
-	ResultMsg   *string    `db:"result_msg"`
-	FinishedAt  *time.Time `db:"finished_at"`
+	ID            string     `db:"id"`
+	ParentJobID   *string    `db:"parent_job_id"`
+	BatchID       *string    `db:"batch_id"`
+	ScheduleID    *string    `db:"schedule_id"`
+	BlueprintID   string     `db:"blueprint_id"`
+	ArchiveID     string     `db:"archive_id"`
+	Image         *string    `db:"image"`
+	Network       bool       `db:"network"`
+	TimeoutSec    int64      `db:"timeout_seconds"`
+	OwnerID       string     `db:"owner_id"`
+	State         string     `db:"state"`
+	CreatedAt     time.Time  `db:"created_at"`
+	NextAttemptAt *time.Time `db:"next_attempt_at"`
+	StartedAt     *time.Time `db:"started_at"`
+	ResultCode    *int       `db:"result_code"`
+	ResultMsg     *string    `db:"result_msg"`
+	ResultReason  *string    `db:"result_reason"`
+	FinishedAt    *time.Time `db:"finished_at"`
+	resourceLimitsColumns
+	retryPolicyColumns
+}
+
+type batchRow struct {
+	ID          string    `db:"id"`
+	BlueprintID string    `db:"blueprint_id"`
+	OwnerID     string    `db:"owner_id"`
+	Size        int       `db:"size"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type readBatchRow struct {
+	ID            string    `db:"id"`
+	BlueprintID   string    `db:"blueprint_id"`
+	BlueprintName string    `db:"blueprint_name"`
+	OwnerID       string    `db:"owner_id"`
+	Size          int       `db:"size"`
+	CreatedAt     time.Time `db:"created_at"`
+	Pending       int       `db:"pending"`
+	Running       int       `db:"running"`
+	Finished      int       `db:"finished"`
+	Failed        int       `db:"failed"`
+	Cancelled     int       `db:"cancelled"`
+}
+
+type scheduleRow struct {
+	ID            string     `db:"id"`
+	BlueprintID   string     `db:"blueprint_id"`
+	OwnerID       string     `db:"owner_id"`
+	Cron          string     `db:"cron"`
+	Timezone      string     `db:"timezone"`
+	OverlapPolicy string     `db:"overlap_policy"`
+	Enabled       bool       `db:"enabled"`
+	LastJobID     *string    `db:"last_job_id"`
+	NextRunAt     *time.Time `db:"next_run_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+type scheduleValueRow struct {
+	ScheduleID string `db:"schedule_id"`
+	Index      int    `db:"index"`
+	Type       string `db:"type"`
+	Value      string `db:"value"`
+}
+
+type pipelineRow struct {
+	ID          string    `db:"id"`
+	OwnerID     string    `db:"owner_id"`
+	Name        string    `db:"name"`
+	Description *string   `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type pipelineStepRow struct {
+	PipelineID  string `db:"pipeline_id"`
+	Index       int    `db:"index"`
+	Name        string `db:"name"`
+	BlueprintID string `db:"blueprint_id"`
+}
+
+type pipelineStepInputRow struct {
+	PipelineID  string  `db:"pipeline_id"`
+	StepIndex   int     `db:"step_index"`
+	Index       int     `db:"index"`
+	Type        *string `db:"type"`
+	Value       *string `db:"value"`
+	SourceStep  *string `db:"source_step"`
+	SourceField *int    `db:"source_field"`
+}
+
+type pipelineRunRow struct {
+	ID         string     `db:"id"`
+	PipelineID string     `db:"pipeline_id"`
+	OwnerID    string     `db:"owner_id"`
+	State      string     `db:"state"`
+	CreatedAt  time.Time  `db:"created_at"`
+	FinishedAt *time.Time `db:"finished_at"`
+}
+
+type pipelineRunStepRow struct {
+	RunID   string  `db:"run_id"`
+	Index   int     `db:"index"`
+	Name    string  `db:"name"`
+	State   string  `db:"state"`
+	JobID   *string `db:"job_id"`
+	Message *string `db:"message"`
+}
+
+type jobAttemptRow struct {
+	JobID        string    `db:"job_id"`
+	Number       int       `db:"number"`
+	StartedAt    time.Time `db:"started_at"`
+	FinishedAt   time.Time `db:"finished_at"`
+	ResultCode   int       `db:"result_code"`
+	ResultMsg    *string   `db:"result_msg"`
+	ResultReason *string   `db:"result_reason"`
 }
 
 type readJobRow struct {
 	ID            string     `db:"id"`
+	ParentJobID   *string    `db:"parent_job_id"`
+	BatchID       *string    `db:"batch_id"`
+	ScheduleID    *string    `db:"schedule_id"`
 	OwnerID       string     `db:"owner_id"`
 	BlueprintID   string     `db:"blueprint_id"`
 	BlueprintName string     `db:"blueprint_name"`
 	State         string     `db:"state"`
 	CreatedAt     time.Time  `db:"created_at"`
+	NextAttemptAt *time.Time `db:"next_attempt_at"`
 	StartedAt     *time.Time `db:"started_at"`
 	ResultCode    *int       `db:"result_code"`
 	ResultMsg     *string    `db:"result_msg"`
+	ResultReason  *string    `db:"result_reason"`
 	FinishedAt    *time.Time `db:"finished_at"`
 }
 
@@ -66,12 +253,20 @@ type jobValueRow struct {
 }
 
 type jobFieldRow struct {
-	JobID string  `db:"job_id"`
-	Index int     `db:"index"`
-	Type  string  `db:"type"`
-	Name  string  `db:"name"`
-	Desc  *string `db:"desc"`
-	Unit  *string `db:"unit"`
+	JobID   string         `db:"job_id"`
+	Index   int            `db:"index"`
+	Type    string         `db:"type"`
+	Name    string         `db:"name"`
+	Desc    *string        `db:"desc"`
+	Unit    *string        `db:"unit"`
+	Options pq.StringArray `db:"options"`
+	// Ограничения значений хранятся только для входных полей.
+	MinValue  *string `db:"min_value"`
+	MaxValue  *string `db:"max_value"`
+	MinLength *int    `db:"min_length"`
+	MaxLength *int    `db:"max_length"`
+	Pattern   *string `db:"pattern"`
+	Default   *string `db:"default_value"`
 }
 
 type userRow struct {
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/zhikh23/pgutils"
@@ -18,11 +19,23 @@ func (r *Repository) selectBlueprintRow(ctx context.Context, qc sqlx.QueryerCont
 			name,
 			"desc",
 			vis,
-			created_at
+			created_at,
+			build_status,
+			image,
+			limit_memory_mb,
+			limit_cpus,
+			limit_pids,
+			limit_tmpfs_mb,
+			network,
+			timeout_seconds,
+			retry_max_attempts,
+			retry_backoff_seconds,
+			retry_on
 		FROM blueprint.blueprints
 		WHERE 
 			id = $1
 			AND deleted_at IS NULL
+		FOR UPDATE
 		`,
 		blueprintID,
 	)
@@ -43,7 +56,17 @@ func (r *Repository) selectBlueprintWithUserRow(ctx context.Context, qc sqlx.Que
 			b.vis,
 			b.owner_id,
 			u.name AS owner_name,
-			b.created_at
+			b.created_at,
+			b.build_status,
+			b.limit_memory_mb,
+			b.limit_cpus,
+			b.limit_pids,
+			b.limit_tmpfs_mb,
+			b.network,
+			b.timeout_seconds,
+			b.retry_max_attempts,
+			b.retry_backoff_seconds,
+			b.retry_on
 		FROM blueprint.blueprints b
 		LEFT JOIN users u
 			ON u.id = b.owner_id
@@ -75,7 +98,17 @@ func (r *Repository) selectPublicAndUserBlueprintWithUserRows(
 			b.vis,
 			b.owner_id,
 			u.name AS owner_name,
-			b.created_at
+			b.created_at,
+			b.build_status,
+			b.limit_memory_mb,
+			b.limit_cpus,
+			b.limit_pids,
+			b.limit_tmpfs_mb,
+			b.network,
+			b.timeout_seconds,
+			b.retry_max_attempts,
+			b.retry_backoff_seconds,
+			b.retry_on
 		FROM blueprint.blueprints b
 		LEFT JOIN users u
 			ON u.id = b.owner_id
@@ -112,7 +145,17 @@ func (r *Repository) selectPublicAndUserBlueprintWithUserByNameRows(
 			b.vis,
 			b.owner_id,
 			u.name AS owner_name,
-			b.created_at
+			b.created_at,
+			b.build_status,
+			b.limit_memory_mb,
+			b.limit_cpus,
+			b.limit_pids,
+			b.limit_tmpfs_mb,
+			b.network,
+			b.timeout_seconds,
+			b.retry_max_attempts,
+			b.retry_backoff_seconds,
+			b.retry_on
 		FROM blueprint.blueprints b
 		LEFT JOIN users u
 			ON u.id = b.owner_id
@@ -144,7 +187,18 @@ func (r *Repository) insertBlueprintRow(ctx context.Context, ec sqlx.ExtContext,
 			name,
 			"desc",
 			vis,
-			created_at
+			created_at,
+			build_status,
+			image,
+			limit_memory_mb,
+			limit_cpus,
+			limit_pids,
+			limit_tmpfs_mb,
+			network,
+			timeout_seconds,
+			retry_max_attempts,
+			retry_backoff_seconds,
+			retry_on
 		)
 		VALUES (
 			:id, 
@@ -153,7 +207,18 @@ func (r *Repository) insertBlueprintRow(ctx context.Context, ec sqlx.ExtContext,
 			:name, 
 			:desc, 
 			:vis, 
-			:created_at
+			:created_at,
+			:build_status,
+			:image,
+			:limit_memory_mb,
+			:limit_cpus,
+			:limit_pids,
+			:limit_tmpfs_mb,
+			:network,
+			:timeout_seconds,
+			:retry_max_attempts,
+			:retry_backoff_seconds,
+			:retry_on
 		)
 		`,
 		row,
@@ -164,6 +229,138 @@ func (r *Repository) insertBlueprintRow(ctx context.Context, ec sqlx.ExtContext,
 	return nil
 }
 
+func (r *Repository) updateBlueprintRow(ctx context.Context, ec sqlx.ExtContext, row blueprintRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		UPDATE blueprint.blueprints
+		SET
+			build_status = :build_status,
+			image = :image
+		WHERE 
+			id = :id
+			AND deleted_at IS NULL
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("update blueprint row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectUnbuiltBlueprintRows(ctx context.Context, qc sqlx.QueryerContext) ([]blueprintRow, error) {
+	var rows []blueprintRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			id,
+			owner_id,
+			archive_id,
+			name,
+			"desc",
+			vis,
+			created_at,
+			build_status,
+			image,
+			limit_memory_mb,
+			limit_cpus,
+			limit_pids,
+			limit_tmpfs_mb,
+			network,
+			timeout_seconds,
+			retry_max_attempts,
+			retry_backoff_seconds,
+			retry_on
+		FROM blueprint.blueprints
+		WHERE 
+			build_status IN ('queued', 'building')
+			AND deleted_at IS NULL
+		ORDER BY created_at
+		`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select unbuilt blueprint rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *Repository) selectBuildLogRow(ctx context.Context, qc sqlx.QueryerContext, blueprintID string) (buildLogRow, error) {
+	var row buildLogRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			blueprint_id,
+			log
+		FROM blueprint.build_logs
+		WHERE blueprint_id = $1
+		`,
+		blueprintID,
+	)
+	if err != nil {
+		return buildLogRow{}, fmt.Errorf("select build log row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) upsertBuildLogRow(ctx context.Context, ec sqlx.ExtContext, row buildLogRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		INSERT INTO blueprint.build_logs (
+			blueprint_id,
+			log
+		)
+		VALUES (
+			:blueprint_id,
+			:log
+		)
+		ON CONFLICT (blueprint_id) DO UPDATE
+		SET
+			log = EXCLUDED.log,
+			created_at = now()
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("upsert build log row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectFileRow(ctx context.Context, qc sqlx.QueryerContext, fileID string) (fileRow, error) {
+	var row fileRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			id,
+			owner_id,
+			name
+		FROM files
+		WHERE id = $1
+		`,
+		fileID,
+	)
+	if err != nil {
+		return fileRow{}, fmt.Errorf("select file row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) insertFileRow(ctx context.Context, ec sqlx.ExtContext, row fileRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		INSERT INTO files (
+			id,
+			owner_id,
+			name
+		)
+		VALUES (
+			:id,
+			:owner_id,
+			:name
+		)
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("insert file row: %w", err)
+	}
+	return nil
+}
+
 func (r *Repository) softDeleteBlueprintRow(ctx context.Context, ec sqlx.ExecerContext, blueprintID string) error {
 	err := pgutils.RequireAffected(pgutils.Exec(ctx, ec, `
 		UPDATE blueprint.blueprints
@@ -207,7 +404,14 @@ func (r *Repository) selectBlueprintInputFieldRows(
 			type, 
 			name, 
 			"desc", 
-			unit
+			unit,
+			options,
+			min_value,
+			max_value,
+			min_length,
+			max_length,
+			pattern,
+			default_value
 		FROM blueprint.input_fields
 		WHERE blueprint_id = $1
 		ORDER BY index
@@ -235,7 +439,14 @@ func (r *Repository) selectBlueprintsInputFieldRows(
 			type, 
 			name, 
 			"desc", 
-			unit
+			unit,
+			options,
+			min_value,
+			max_value,
+			min_length,
+			max_length,
+			pattern,
+			default_value
 		FROM blueprint.input_fields
 		WHERE
 			blueprint_id IN (?)
@@ -277,7 +488,14 @@ func (r *Repository) insertBlueprintInputFieldRows(
 			type, 
 			name, 
 			"desc", 
-			unit
+			unit,
+			options,
+			min_value,
+			max_value,
+			min_length,
+			max_length,
+			pattern,
+			default_value
 		)
 		VALUES (
 		    :blueprint_id,
@@ -285,7 +503,14 @@ func (r *Repository) insertBlueprintInputFieldRows(
 			:type,
 			:name,
 			:desc,
-			:unit
+			:unit,
+			:options,
+			:min_value,
+			:max_value,
+			:min_length,
+			:max_length,
+			:pattern,
+			:default_value
 		)	
 		`,
 		rows,
@@ -309,7 +534,8 @@ func (r *Repository) selectBlueprintOutputFieldRows(
 			type, 
 			name, 
 			"desc", 
-			unit
+			unit,
+			options
 		FROM blueprint.output_fields
 		WHERE blueprint_id = $1
 		ORDER BY index
@@ -338,7 +564,8 @@ func (r *Repository) selectBlueprintsOutputFieldRows(
 			type, 
 			name, 
 			"desc", 
-			unit
+			unit,
+			options
 		FROM blueprint.output_fields
 		WHERE
 			blueprint_id IN (?)
@@ -369,7 +596,8 @@ func (r *Repository) insertBlueprintOutputFieldRows(
 			type, 
 			name, 
 			"desc", 
-			unit
+			unit,
+			options
 		)
 		VALUES (
 		    :blueprint_id,
@@ -377,7 +605,8 @@ func (r *Repository) insertBlueprintOutputFieldRows(
 			:type,
 			:name,
 			:desc,
-			:unit
+			:unit,
+			:options
 		)	
 		`,
 		rows,
@@ -393,19 +622,35 @@ func (r *Repository) selectJobRow(ctx context.Context, qc sqlx.QueryerContext, j
 	err := pgutils.Get(ctx, qc, &row, `
 		SELECT
 			id, 
+			parent_job_id,
+			batch_id,
+			schedule_id,
 			blueprint_id, 
 			archive_id, 
+			image, 
+			limit_memory_mb, 
+			limit_cpus, 
+			limit_pids, 
+			limit_tmpfs_mb, 
+			network, 
+			timeout_seconds,
+			retry_max_attempts,
+			retry_backoff_seconds,
+			retry_on,
 			owner_id, 
 			state, 
 			created_at, 
+			next_attempt_at,
 			started_at, 
 			result_code, 
 			result_msg, 
+			result_reason, 
 			finished_at
 		FROM job.jobs
 		WHERE 
 			id = $1
 			AND deleted_at IS NULL
+		FOR UPDATE
 		`,
 		jobID,
 	)
@@ -420,14 +665,19 @@ func (r *Repository) selectReadJobRow(ctx context.Context, qc sqlx.QueryerContex
 	err := pgutils.Get(ctx, qc, &row, `
 		SELECT
 			j.id, 
+			j.parent_job_id,
+			j.batch_id,
+			j.schedule_id,
 			j.owner_id,
 			j.blueprint_id, 
 			b.name AS blueprint_name,
 			j.state, 
 			j.created_at, 
+			j.next_attempt_at,
 			j.started_at, 
 			j.result_code, 
 			j.result_msg, 
+			j.result_reason, 
 			j.finished_at
 		FROM job.jobs j
 		JOIN blueprint.blueprints b 
@@ -451,14 +701,19 @@ func (r *Repository) selectUserReadJobRows(
 	err := pgutils.Select(ctx, qc, &rows, `
 		SELECT
 			j.id, 
+			j.parent_job_id,
+			j.batch_id,
+			j.schedule_id,
 			j.owner_id,
 			j.blueprint_id, 
 			b.name AS blueprint_name,
 			j.state, 
 			j.created_at, 
+			j.next_attempt_at,
 			j.started_at, 
 			j.result_code, 
 			j.result_msg, 
+			j.result_reason, 
 			j.finished_at
 		FROM job.jobs j
 		JOIN blueprint.blueprints b 
@@ -487,14 +742,19 @@ func (r *Repository) selectUserReadJobRowsWithState(
 	err := pgutils.Select(ctx, qc, &rows, `
 		SELECT
 			j.id, 
+			j.parent_job_id,
+			j.batch_id,
+			j.schedule_id,
 			j.owner_id,
 			j.blueprint_id, 
 			b.name AS blueprint_name,
 			j.state, 
 			j.created_at, 
+			j.next_attempt_at,
 			j.started_at, 
 			j.result_code, 
 			j.result_msg, 
+			j.result_reason, 
 			j.finished_at
 		FROM job.jobs j
 		JOIN blueprint.blueprints b 
@@ -515,30 +775,100 @@ func (r *Repository) selectUserReadJobRowsWithState(
 	return rows, nil
 }
 
+func (r *Repository) selectBatchReadJobRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	batchID string,
+) ([]readJobRow, error) {
+	var rows []readJobRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			j.id, 
+			j.parent_job_id,
+			j.batch_id,
+			j.schedule_id,
+			j.owner_id,
+			j.blueprint_id, 
+			b.name AS blueprint_name,
+			j.state, 
+			j.created_at, 
+			j.next_attempt_at,
+			j.started_at, 
+			j.result_code, 
+			j.result_msg, 
+			j.result_reason, 
+			j.finished_at
+		FROM job.jobs j
+		JOIN blueprint.blueprints b 
+			ON j.blueprint_id = b.id
+			AND b.deleted_at IS NULL
+		WHERE 
+			j.batch_id = $1
+			AND j.deleted_at IS NULL
+		ORDER BY j.created_at, j.id
+		`,
+		batchID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select batch job rows: %w", err)
+	}
+	return rows, nil
+}
+
 func (r *Repository) insertJobRow(ctx context.Context, ec sqlx.ExtContext, row jobRow) error {
 	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
 		INSERT INTO job.jobs (
 		    id, 
+			parent_job_id,
+			batch_id,
+			schedule_id,
 			blueprint_id, 
 			archive_id, 
+			image, 
+			limit_memory_mb, 
+			limit_cpus, 
+			limit_pids, 
+			limit_tmpfs_mb, 
+			network, 
+			timeout_seconds,
+			retry_max_attempts,
+			retry_backoff_seconds,
+			retry_on,
 			owner_id, 
 			state, 
 			created_at, 
+			next_attempt_at,
 			started_at, 
 			result_code, 
 			result_msg, 
+			result_reason, 
 			finished_at
 		) 
 		VALUES (
 			:id,
+			:parent_job_id,
+			:batch_id,
+			:schedule_id,
 			:blueprint_id,
 			:archive_id,
+			:image,
+			:limit_memory_mb,
+			:limit_cpus,
+			:limit_pids,
+			:limit_tmpfs_mb,
+			:network,
+			:timeout_seconds,
+			:retry_max_attempts,
+			:retry_backoff_seconds,
+			:retry_on,
 			:owner_id,
 			:state,
 			:created_at,
+			:next_attempt_at,
 			:started_at,
 			:result_code,
 			:result_msg,
+			:result_reason,
 			:finished_at
 		)
 		`,
@@ -555,9 +885,11 @@ func (r *Repository) updateJobRow(ctx context.Context, ec sqlx.ExtContext, row j
 		UPDATE job.jobs
 		SET
 			state = :state,
+			next_attempt_at = :next_attempt_at,
 			started_at = :started_at,
 			result_code = :result_code,
 			result_msg = :result_msg,
+			result_reason = :result_reason,
 			finished_at = :finished_at
 		WHERE 
 			id = :id
@@ -767,7 +1099,8 @@ func (r *Repository) selectJobOutputFieldRows(
 			type, 
 			name, 
 			"desc", 
-			unit
+			unit,
+			options
 		FROM job.output_fields
 		WHERE job_id = $1
 		ORDER BY index
@@ -793,7 +1126,14 @@ func (r *Repository) selectJobInputFieldRows(
 			bif.type,
 			bif.name,
 			bif."desc",
-			bif.unit
+			bif.unit,
+			bif.options,
+			bif.min_value,
+			bif.max_value,
+			bif.min_length,
+			bif.max_length,
+			bif.pattern,
+			bif.default_value
 		FROM blueprint.input_fields bif
 		JOIN job.jobs j
 			ON j.blueprint_id = bif.blueprint_id
@@ -822,7 +1162,14 @@ func (r *Repository) selectJobsInputFieldsRows(
 			bif.type, 
 			bif.name,
 			bif."desc",
-			bif.unit
+			bif.unit,
+			bif.options,
+			bif.min_value,
+			bif.max_value,
+			bif.min_length,
+			bif.max_length,
+			bif.pattern,
+			bif.default_value
 		FROM blueprint.input_fields bif
 		JOIN job.jobs j
 			ON j.blueprint_id = bif.blueprint_id
@@ -858,7 +1205,8 @@ func (r *Repository) selectJobOutputFieldsRows(
 			type, 
 			name,
 			"desc",
-			unit
+			unit,
+			options
 		FROM job.output_fields
 		WHERE job_id = $1
 		`,
@@ -885,7 +1233,8 @@ func (r *Repository) selectJobsOutputFieldsRows(
 			type, 
 			name,
 			"desc",
-			unit
+			unit,
+			options
 		FROM job.output_fields
 		WHERE job_id IN (?)
 		ORDER BY index
@@ -927,7 +1276,8 @@ func (r *Repository) insertJobOutputFieldRows(
 			type, 
 			name, 
 			"desc", 
-			unit
+			unit,
+			options
 		)
 		VALUES (
 		    :job_id,
@@ -935,7 +1285,8 @@ func (r *Repository) insertJobOutputFieldRows(
 			:type,
 			:name,
 			:desc,
-			:unit
+			:unit,
+			:options
 		)	
 		ON CONFLICT (job_id, index)
 		DO NOTHING
@@ -948,141 +1299,1071 @@ func (r *Repository) insertJobOutputFieldRows(
 	return nil
 }
 
-func (r *Repository) selectUserRow(
+func (r *Repository) selectJobAttemptRows(
 	ctx context.Context,
 	qc sqlx.QueryerContext,
-	userID string,
-) (userRow, error) {
-	var row userRow
-	err := pgutils.Get(ctx, qc, &row, `
+	jobID string,
+) ([]jobAttemptRow, error) {
+	var rows []jobAttemptRow
+	err := pgutils.Select(ctx, qc, &rows, `
 		SELECT
-			id,
-			email,
-			name,
-			passhash,
-			role,
-			created_at
-		FROM public.users
-		WHERE 
-			id = $1
-			AND deleted_at IS NULL
+			job_id,
+			number,
+			started_at,
+			finished_at,
+			result_code,
+			result_msg,
+			result_reason
+		FROM job.attempts
+		WHERE job_id = $1
+		ORDER BY number
 		`,
-		userID,
+		jobID,
 	)
 	if err != nil {
-		return userRow{}, fmt.Errorf("select user row: %w", err)
+		return nil, fmt.Errorf("select job attempt rows: %w", err)
 	}
-	return row, nil
+	return rows, nil
 }
 
-func (r *Repository) selectUserRows(
+func (r *Repository) selectJobsAttemptRows(
 	ctx context.Context,
 	qc sqlx.QueryerContext,
-) ([]userRow, error) {
-	var rows []userRow
-	err := pgutils.Select(ctx, qc, &rows, `
+	jobIDs []string,
+) (map[string][]jobAttemptRow, error) {
+	if len(jobIDs) == 0 {
+		return map[string][]jobAttemptRow{}, nil
+	}
+	query, args, err := sqlx.In(`
 		SELECT
-			id,
-			email,
-			name,
-			passhash,
-			role,
-			created_at
-		FROM public.users
-		WHERE 
-			deleted_at IS NULL
+			job_id,
+			number,
+			started_at,
+			finished_at,
+			result_code,
+			result_msg,
+			result_reason
+		FROM job.attempts
+		WHERE job_id IN (?)
+		ORDER BY number
 		`,
+		jobIDs,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("select user rows: %w", err)
+		return nil, fmt.Errorf("sqlx.In: %w", err)
 	}
-	return rows, nil
+	query = r.db.Rebind(query)
+
+	var rows []jobAttemptRow
+	err = pgutils.Select(ctx, qc, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select jobs attempt rows: %w", err)
+	}
+
+	m := make(map[string][]jobAttemptRow)
+	for _, row := range rows {
+		m[row.JobID] = append(m[row.JobID], row)
+	}
+	return m, nil
 }
 
-func (r *Repository) selectUserRowByEmail(
-	ctx context.Context,
-	qc sqlx.QueryerContext,
-	email string,
-) (userRow, error) {
-	var row userRow
-	err := pgutils.Get(ctx, qc, &row, `
-		SELECT
-			id,
-			email,
-			name,
-			passhash,
-			role,
-			created_at
-		FROM public.users
-		WHERE 
-			email = $1
-			AND deleted_at IS NULL
+func (r *Repository) insertJobAttemptRows(ctx context.Context, ec sqlx.ExtContext, rows []jobAttemptRow) error {
+	_, err := pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.attempts (
+			job_id,
+			number,
+			started_at,
+			finished_at,
+			result_code,
+			result_msg,
+			result_reason
+		)
+		VALUES (
+			:job_id,
+			:number,
+			:started_at,
+			:finished_at,
+			:result_code,
+			:result_msg,
+			:result_reason
+		)
+		ON CONFLICT (job_id, number)
+		DO NOTHING
 		`,
-		email,
+		rows,
 	)
 	if err != nil {
-		return userRow{}, fmt.Errorf("select user row: %w", err)
+		return fmt.Errorf("insert job attempt rows: %w", err)
 	}
-	return row, nil
+	return nil
 }
 
-func (r *Repository) insertUserRow(
-	ctx context.Context,
-	ec sqlx.ExtContext,
-	row userRow,
-) error {
+func (r *Repository) insertBatchRow(ctx context.Context, ec sqlx.ExtContext, row batchRow) error {
 	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
-		INSERT INTO users (
-			id, 
-			email, 
-			name, 
-			role, 
-			passhash, 
+		INSERT INTO job.batches (
+			id,
+			blueprint_id,
+			owner_id,
+			size,
 			created_at
 		)
 		VALUES (
 			:id,
-			:email,
-			:name,
-			:role,
-			:passhash,
+			:blueprint_id,
+			:owner_id,
+			:size,
 			:created_at
 		)
 		`,
 		row,
 	))
 	if err != nil {
-		return fmt.Errorf("insert user row: %w", err)
+		return fmt.Errorf("insert batch row: %w", err)
 	}
 	return nil
 }
 
-func (r *Repository) updateUserRow(ctx context.Context, ec sqlx.ExtContext, row userRow) error {
-	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
-		UPDATE users
-		SET
-			email = :email,
-			passhash = :passhash,
-			name = :name,
-			role = :role
+// selectReadBatchRow возвращает пакет вместе с числом его задач в каждом состоянии.
+func (r *Repository) selectReadBatchRow(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	batchID string,
+) (readBatchRow, error) {
+	var row readBatchRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			s.id,
+			s.blueprint_id,
+			b.name AS blueprint_name,
+			s.owner_id,
+			s.size,
+			s.created_at,
+			count(j.id) FILTER (WHERE j.state = 'pending') AS pending,
+			count(j.id) FILTER (WHERE j.state = 'running') AS running,
+			count(j.id) FILTER (WHERE j.state = 'finished' AND j.result_code = 0) AS finished,
+			count(j.id) FILTER (WHERE j.state = 'finished' AND j.result_code <> 0) AS failed,
+			count(j.id) FILTER (WHERE j.state = 'cancelled') AS cancelled
+		FROM job.batches s
+		JOIN blueprint.blueprints b
+			ON s.blueprint_id = b.id
+			AND b.deleted_at IS NULL
+		LEFT JOIN job.jobs j
+			ON j.batch_id = s.id
+			AND j.deleted_at IS NULL
+		WHERE s.id = $1
+		GROUP BY s.id, b.name
+		`,
+		batchID,
+	)
+	if err != nil {
+		return readBatchRow{}, fmt.Errorf("select batch row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) selectBatchJobIDs(ctx context.Context, qc sqlx.QueryerContext, batchID string) ([]string, error) {
+	var ids []string
+	err := pgutils.Select(ctx, qc, &ids, `
+		SELECT id
+		FROM job.jobs
 		WHERE 
-			id = :id
+			batch_id = $1
 			AND deleted_at IS NULL
+		ORDER BY created_at, id
 		`,
-		row,
-	))
+		batchID,
+	)
 	if err != nil {
-		return fmt.Errorf("update user row: %w", err)
+		return nil, fmt.Errorf("select batch job ids: %w", err)
 	}
-	return nil
+	return ids, nil
 }
 
-func (r *Repository) softDeleteUserRow(ctx context.Context, ec sqlx.ExecerContext, uid string) error {
-	err := pgutils.RequireAffected(pgutils.Exec(ctx, ec, `
-		UPDATE users
-		SET
-			deleted_at = NOW()
-		WHERE id = $1
+func (r *Repository) selectScheduleRow(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	scheduleID string,
+) (scheduleRow, error) {
+	var row scheduleRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			id,
+			blueprint_id,
+			owner_id,
+			cron,
+			timezone,
+			overlap_policy,
+			enabled,
+			last_job_id,
+			next_run_at,
+			created_at
+		FROM job.schedules
+		WHERE id = $1
+		FOR UPDATE
+		`,
+		scheduleID,
+	)
+	if err != nil {
+		return scheduleRow{}, fmt.Errorf("select schedule row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) selectReadScheduleRow(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	scheduleID string,
+) (scheduleRow, error) {
+	var row scheduleRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			id,
+			blueprint_id,
+			owner_id,
+			cron,
+			timezone,
+			overlap_policy,
+			enabled,
+			last_job_id,
+			next_run_at,
+			created_at
+		FROM job.schedules
+		WHERE id = $1
+		`,
+		scheduleID,
+	)
+	if err != nil {
+		return scheduleRow{}, fmt.Errorf("select schedule row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) selectUserScheduleRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	uid string,
+) ([]scheduleRow, error) {
+	var rows []scheduleRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			id,
+			blueprint_id,
+			owner_id,
+			cron,
+			timezone,
+			overlap_policy,
+			enabled,
+			last_job_id,
+			next_run_at,
+			created_at
+		FROM job.schedules
+		WHERE owner_id = $1
+		ORDER BY created_at DESC
+		`,
+		uid,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select user schedule rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *Repository) selectDueScheduleIDs(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	now time.Time,
+) ([]string, error) {
+	var ids []string
+	err := pgutils.Select(ctx, qc, &ids, `
+		SELECT id
+		FROM job.schedules
+		WHERE 
+			enabled
+			AND next_run_at <= $1
+		ORDER BY next_run_at
+		`,
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select due schedule ids: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *Repository) insertScheduleRow(ctx context.Context, ec sqlx.ExtContext, row scheduleRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.schedules (
+			id,
+			blueprint_id,
+			owner_id,
+			cron,
+			timezone,
+			overlap_policy,
+			enabled,
+			last_job_id,
+			next_run_at,
+			created_at
+		)
+		VALUES (
+			:id,
+			:blueprint_id,
+			:owner_id,
+			:cron,
+			:timezone,
+			:overlap_policy,
+			:enabled,
+			:last_job_id,
+			:next_run_at,
+			:created_at
+		)
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("insert schedule row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) updateScheduleRow(ctx context.Context, ec sqlx.ExtContext, row scheduleRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		UPDATE job.schedules
+		SET
+			cron = :cron,
+			timezone = :timezone,
+			overlap_policy = :overlap_policy,
+			enabled = :enabled,
+			last_job_id = :last_job_id,
+			next_run_at = :next_run_at
+		WHERE id = :id
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("update schedule row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) deleteScheduleRow(ctx context.Context, ec sqlx.ExecerContext, scheduleID string) error {
+	_, err := pgutils.Exec(ctx, ec, `
+		DELETE FROM job.schedules
+		WHERE id = $1
+		`,
+		scheduleID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete schedule row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectScheduleValueRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	scheduleID string,
+) ([]scheduleValueRow, error) {
+	var rows []scheduleValueRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			schedule_id,
+			index,
+			type,
+			value
+		FROM job.schedule_values
+		WHERE schedule_id = $1
+		ORDER BY index
+		`,
+		scheduleID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select schedule value rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *Repository) selectSchedulesValueRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	scheduleIDs []string,
+) (map[string][]scheduleValueRow, error) {
+	if len(scheduleIDs) == 0 {
+		return map[string][]scheduleValueRow{}, nil
+	}
+	query, args, err := sqlx.In(`
+		SELECT
+			schedule_id,
+			index,
+			type,
+			value
+		FROM job.schedule_values
+		WHERE schedule_id IN (?)
+		ORDER BY index
+		`,
+		scheduleIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("sqlx.In: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	var rows []scheduleValueRow
+	err = pgutils.Select(ctx, qc, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgutils.Select: %w", err)
+	}
+
+	res := make(map[string][]scheduleValueRow)
+	for _, row := range rows {
+		res[row.ScheduleID] = append(res[row.ScheduleID], row)
+	}
+	return res, nil
+}
+
+func (r *Repository) insertScheduleValueRows(ctx context.Context, ec sqlx.ExtContext, rows []scheduleValueRow) error {
+	_, err := pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.schedule_values (
+			schedule_id,
+			index,
+			type,
+			value
+		)
+		VALUES (
+			:schedule_id,
+			:index,
+			:type,
+			:value
+		)
+		`,
+		rows,
+	)
+	if err != nil {
+		return fmt.Errorf("insert schedule value rows: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) deleteScheduleValueRows(ctx context.Context, ec sqlx.ExecerContext, scheduleID string) error {
+	_, err := pgutils.Exec(ctx, ec, `
+		DELETE FROM job.schedule_values
+		WHERE schedule_id = $1
+		`,
+		scheduleID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete schedule value rows: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectScheduleReadJobRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	scheduleID string,
+) ([]readJobRow, error) {
+	var rows []readJobRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			j.id, 
+			j.parent_job_id,
+			j.batch_id,
+			j.schedule_id,
+			j.owner_id,
+			j.blueprint_id, 
+			b.name AS blueprint_name,
+			j.state, 
+			j.created_at, 
+			j.next_attempt_at,
+			j.started_at, 
+			j.result_code, 
+			j.result_msg, 
+			j.result_reason, 
+			j.finished_at
+		FROM job.jobs j
+		JOIN blueprint.blueprints b 
+			ON j.blueprint_id = b.id
+			AND b.deleted_at IS NULL
+		WHERE 
+			j.schedule_id = $1
+			AND j.deleted_at IS NULL
+		ORDER BY j.created_at DESC
+		`,
+		scheduleID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select schedule job rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *Repository) selectPipelineRow(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	pipelineID string,
+) (pipelineRow, error) {
+	var row pipelineRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			id,
+			owner_id,
+			name,
+			description,
+			created_at
+		FROM job.pipelines
+		WHERE id = $1
+		`,
+		pipelineID,
+	)
+	if err != nil {
+		return pipelineRow{}, fmt.Errorf("select pipeline row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) selectUserPipelineRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	uid string,
+) ([]pipelineRow, error) {
+	var rows []pipelineRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			id,
+			owner_id,
+			name,
+			description,
+			created_at
+		FROM job.pipelines
+		WHERE owner_id = $1
+		ORDER BY created_at DESC
+		`,
+		uid,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select user pipeline rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *Repository) insertPipelineRow(ctx context.Context, ec sqlx.ExtContext, row pipelineRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.pipelines (
+			id,
+			owner_id,
+			name,
+			description,
+			created_at
+		)
+		VALUES (
+			:id,
+			:owner_id,
+			:name,
+			:description,
+			:created_at
+		)
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("insert pipeline row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) deletePipelineRow(ctx context.Context, ec sqlx.ExecerContext, pipelineID string) error {
+	_, err := pgutils.Exec(ctx, ec, `
+		DELETE FROM job.pipelines
+		WHERE id = $1
+		`,
+		pipelineID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete pipeline row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectPipelinesStepRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	pipelineIDs []string,
+) (map[string][]pipelineStepRow, error) {
+	if len(pipelineIDs) == 0 {
+		return map[string][]pipelineStepRow{}, nil
+	}
+	query, args, err := sqlx.In(`
+		SELECT
+			pipeline_id,
+			index,
+			name,
+			blueprint_id
+		FROM job.pipeline_steps
+		WHERE pipeline_id IN (?)
+		ORDER BY index
+		`,
+		pipelineIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("sqlx.In: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	var rows []pipelineStepRow
+	err = pgutils.Select(ctx, qc, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgutils.Select: %w", err)
+	}
+
+	res := make(map[string][]pipelineStepRow)
+	for _, row := range rows {
+		res[row.PipelineID] = append(res[row.PipelineID], row)
+	}
+	return res, nil
+}
+
+func (r *Repository) insertPipelineStepRows(ctx context.Context, ec sqlx.ExtContext, rows []pipelineStepRow) error {
+	_, err := pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.pipeline_steps (
+			pipeline_id,
+			index,
+			name,
+			blueprint_id
+		)
+		VALUES (
+			:pipeline_id,
+			:index,
+			:name,
+			:blueprint_id
+		)
+		`,
+		rows,
+	)
+	if err != nil {
+		return fmt.Errorf("insert pipeline step rows: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectPipelinesStepInputRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	pipelineIDs []string,
+) (map[string][]pipelineStepInputRow, error) {
+	if len(pipelineIDs) == 0 {
+		return map[string][]pipelineStepInputRow{}, nil
+	}
+	query, args, err := sqlx.In(`
+		SELECT
+			pipeline_id,
+			step_index,
+			index,
+			type,
+			value,
+			source_step,
+			source_field
+		FROM job.pipeline_step_inputs
+		WHERE pipeline_id IN (?)
+		ORDER BY step_index, index
+		`,
+		pipelineIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("sqlx.In: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	var rows []pipelineStepInputRow
+	err = pgutils.Select(ctx, qc, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgutils.Select: %w", err)
+	}
+
+	res := make(map[string][]pipelineStepInputRow)
+	for _, row := range rows {
+		res[row.PipelineID] = append(res[row.PipelineID], row)
+	}
+	return res, nil
+}
+
+func (r *Repository) insertPipelineStepInputRows(
+	ctx context.Context,
+	ec sqlx.ExtContext,
+	rows []pipelineStepInputRow,
+) error {
+	_, err := pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.pipeline_step_inputs (
+			pipeline_id,
+			step_index,
+			index,
+			type,
+			value,
+			source_step,
+			source_field
+		)
+		VALUES (
+			:pipeline_id,
+			:step_index,
+			:index,
+			:type,
+			:value,
+			:source_step,
+			:source_field
+		)
+		`,
+		rows,
+	)
+	if err != nil {
+		return fmt.Errorf("insert pipeline step input rows: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectPipelineRunRow(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	runID string,
+) (pipelineRunRow, error) {
+	var row pipelineRunRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			id,
+			pipeline_id,
+			owner_id,
+			state,
+			created_at,
+			finished_at
+		FROM job.pipeline_runs
+		WHERE id = $1
+		FOR UPDATE
+		`,
+		runID,
+	)
+	if err != nil {
+		return pipelineRunRow{}, fmt.Errorf("select pipeline run row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) selectReadPipelineRunRow(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	runID string,
+) (pipelineRunRow, error) {
+	var row pipelineRunRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			id,
+			pipeline_id,
+			owner_id,
+			state,
+			created_at,
+			finished_at
+		FROM job.pipeline_runs
+		WHERE id = $1
+		`,
+		runID,
+	)
+	if err != nil {
+		return pipelineRunRow{}, fmt.Errorf("select pipeline run row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) selectPipelineRunRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	pipelineID string,
+) ([]pipelineRunRow, error) {
+	var rows []pipelineRunRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			id,
+			pipeline_id,
+			owner_id,
+			state,
+			created_at,
+			finished_at
+		FROM job.pipeline_runs
+		WHERE pipeline_id = $1
+		ORDER BY created_at DESC
+		`,
+		pipelineID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select pipeline run rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *Repository) selectRunningPipelineRunIDs(ctx context.Context, qc sqlx.QueryerContext) ([]string, error) {
+	var ids []string
+	err := pgutils.Select(ctx, qc, &ids, `
+		SELECT id
+		FROM job.pipeline_runs
+		WHERE state = 'running'
+		ORDER BY created_at
+		`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select running pipeline run ids: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *Repository) insertPipelineRunRow(ctx context.Context, ec sqlx.ExtContext, row pipelineRunRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.pipeline_runs (
+			id,
+			pipeline_id,
+			owner_id,
+			state,
+			created_at,
+			finished_at
+		)
+		VALUES (
+			:id,
+			:pipeline_id,
+			:owner_id,
+			:state,
+			:created_at,
+			:finished_at
+		)
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("insert pipeline run row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) updatePipelineRunRow(ctx context.Context, ec sqlx.ExtContext, row pipelineRunRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		UPDATE job.pipeline_runs
+		SET
+			state = :state,
+			finished_at = :finished_at
+		WHERE id = :id
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("update pipeline run row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectPipelineRunsStepRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	runIDs []string,
+) (map[string][]pipelineRunStepRow, error) {
+	if len(runIDs) == 0 {
+		return map[string][]pipelineRunStepRow{}, nil
+	}
+	query, args, err := sqlx.In(`
+		SELECT
+			run_id,
+			index,
+			name,
+			state,
+			job_id,
+			message
+		FROM job.pipeline_run_steps
+		WHERE run_id IN (?)
+		ORDER BY index
+		`,
+		runIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("sqlx.In: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	var rows []pipelineRunStepRow
+	err = pgutils.Select(ctx, qc, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgutils.Select: %w", err)
+	}
+
+	res := make(map[string][]pipelineRunStepRow)
+	for _, row := range rows {
+		res[row.RunID] = append(res[row.RunID], row)
+	}
+	return res, nil
+}
+
+func (r *Repository) upsertPipelineRunStepRows(
+	ctx context.Context,
+	ec sqlx.ExtContext,
+	rows []pipelineRunStepRow,
+) error {
+	_, err := pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.pipeline_run_steps (
+			run_id,
+			index,
+			name,
+			state,
+			job_id,
+			message
+		)
+		VALUES (
+			:run_id,
+			:index,
+			:name,
+			:state,
+			:job_id,
+			:message
+		)
+		ON CONFLICT (run_id, index) DO UPDATE
+		SET
+			state = EXCLUDED.state,
+			job_id = EXCLUDED.job_id,
+			message = EXCLUDED.message
+		`,
+		rows,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert pipeline run step rows: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectUserRow(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	userID string,
+) (userRow, error) {
+	var row userRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			id,
+			email,
+			name,
+			passhash,
+			role,
+			created_at
+		FROM public.users
+		WHERE 
+			id = $1
+			AND deleted_at IS NULL
+		`,
+		userID,
+	)
+	if err != nil {
+		return userRow{}, fmt.Errorf("select user row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) selectUserRows(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+) ([]userRow, error) {
+	var rows []userRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			id,
+			email,
+			name,
+			passhash,
+			role,
+			created_at
+		FROM public.users
+		WHERE 
+			deleted_at IS NULL
+		`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select user rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *Repository) selectUserRowByEmail(
+	ctx context.Context,
+	qc sqlx.QueryerContext,
+	email string,
+) (userRow, error) {
+	var row userRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			id,
+			email,
+			name,
+			passhash,
+			role,
+			created_at
+		FROM public.users
+		WHERE 
+			email = $1
+			AND deleted_at IS NULL
+		`,
+		email,
+	)
+	if err != nil {
+		return userRow{}, fmt.Errorf("select user row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) insertUserRow(
+	ctx context.Context,
+	ec sqlx.ExtContext,
+	row userRow,
+) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		INSERT INTO users (
+			id, 
+			email, 
+			name, 
+			role, 
+			passhash, 
+			created_at
+		)
+		VALUES (
+			:id,
+			:email,
+			:name,
+			:role,
+			:passhash,
+			:created_at
+		)
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("insert user row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) updateUserRow(ctx context.Context, ec sqlx.ExtContext, row userRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		UPDATE users
+		SET
+			email = :email,
+			passhash = :passhash,
+			name = :name,
+			role = :role
+		WHERE 
+			id = :id
+			AND deleted_at IS NULL
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("update user row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) softDeleteUserRow(ctx context.Context, ec sqlx.ExecerContext, uid string) error {
+	err := pgutils.RequireAffected(pgutils.Exec(ctx, ec, `
+		UPDATE users
+		SET
+			deleted_at = NOW()
+		WHERE id = $1
 		`,
 		uid,
 	))
@@ -1091,3 +2372,445 @@ func (r *Repository) softDeleteUserRow(ctx context.Context, ec sqlx.ExecerContex
 	}
 	return nil
 }
+
+func (r *Repository) selectJobLogRow(ctx context.Context, qc sqlx.QueryerContext, jobID string) (jobLogRow, error) {
+	var row jobLogRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			job_id,
+			log
+		FROM job.job_logs
+		WHERE job_id = $1
+		`,
+		jobID,
+	)
+	if err != nil {
+		return jobLogRow{}, fmt.Errorf("select job log row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) upsertJobLogRow(ctx context.Context, ec sqlx.ExtContext, row jobLogRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.job_logs (
+			job_id,
+			log
+		)
+		VALUES (
+			:job_id,
+			:log
+		)
+		ON CONFLICT (job_id) DO UPDATE
+		SET
+			log = EXCLUDED.log,
+			created_at = now()
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("upsert job log row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) insertQueueRow(ctx context.Context, ec sqlx.ExecerContext, jobID string) error {
+	_, err := pgutils.Exec(ctx, ec, `
+		INSERT INTO job.queue (job_id)
+		VALUES ($1)
+		ON CONFLICT (job_id) DO NOTHING
+		`,
+		jobID,
+	)
+	if err != nil {
+		return fmt.Errorf("insert queue row: %w", err)
+	}
+	return nil
+}
+
+// claimQueueRow захватывает самую раннюю свободную задачу очереди на время lease. Если свободных задач нет,
+// возвращает sql.ErrNoRows.
+func (r *Repository) claimQueueRow(
+	ctx context.Context, qc sqlx.QueryerContext, lease time.Duration,
+) (queueClaim, error) {
+	var claim queueClaim
+	err := pgutils.Get(ctx, qc, &claim, `
+		UPDATE job.queue
+		SET
+			locked_until = now() + make_interval(secs => $1),
+			claim_id = nextval('job.queue_claim_id_seq')
+		WHERE job_id = (
+			SELECT job_id
+			FROM job.queue
+			WHERE locked_until IS NULL OR locked_until < now()
+			ORDER BY enqueued_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING job_id, claim_id
+		`,
+		lease.Seconds(),
+	)
+	if err != nil {
+		return queueClaim{}, fmt.Errorf("claim queue row: %w", err)
+	}
+	return claim, nil
+}
+
+// ackQueueRow удаляет запись очереди захвата claim. Запись повторной доставки той же задачи и её новый захват
+// не удаляются: в этом случае возвращается pgutils.ErrNoAffectedRows.
+func (r *Repository) ackQueueRow(ctx context.Context, ec sqlx.ExecerContext, claim queueClaim) error {
+	err := pgutils.RequireAffected(pgutils.Exec(ctx, ec, `
+		DELETE FROM job.queue
+		WHERE job_id = $1 AND claim_id = $2
+		`,
+		claim.JobID,
+		claim.ClaimID,
+	))
+	if err != nil {
+		return fmt.Errorf("ack queue row: %w", err)
+	}
+	return nil
+}
+
+// releaseQueueRow снимает захват claim с записи очереди, не учитывая попытку обработки: задача сразу становится
+// доступной для захвата. Запись, захваченная заново, не изменяется.
+func (r *Repository) releaseQueueRow(ctx context.Context, ec sqlx.ExecerContext, claim queueClaim) error {
+	_, err := pgutils.Exec(ctx, ec, `
+		UPDATE job.queue
+		SET
+			locked_until = NULL,
+			claim_id = NULL
+		WHERE job_id = $1 AND claim_id = $2
+		`,
+		claim.JobID,
+		claim.ClaimID,
+	)
+	if err != nil {
+		return fmt.Errorf("release queue row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) deleteQueueRow(ctx context.Context, ec sqlx.ExecerContext, jobID string) error {
+	_, err := pgutils.Exec(ctx, ec, `
+		DELETE FROM job.queue
+		WHERE job_id = $1
+		`,
+		jobID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete queue row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) insertOutboxRow(ctx context.Context, ec sqlx.ExecerContext, jobID string) error {
+	err := pgutils.RequireAffected(pgutils.Exec(ctx, ec, `
+		INSERT INTO job.outbox (job_id)
+		VALUES ($1)
+		`,
+		jobID,
+	))
+	if err != nil {
+		return fmt.Errorf("insert outbox row: %w", err)
+	}
+	return nil
+}
+
+// insertDelayedOutboxRow ставит задачу на публикацию не раньше availableAt.
+func (r *Repository) insertDelayedOutboxRow(
+	ctx context.Context, ec sqlx.ExecerContext, jobID string, availableAt time.Time,
+) error {
+	err := pgutils.RequireAffected(pgutils.Exec(ctx, ec, `
+		INSERT INTO job.outbox (job_id, available_at)
+		VALUES ($1, $2)
+		`,
+		jobID,
+		availableAt,
+	))
+	if err != nil {
+		return fmt.Errorf("insert delayed outbox row: %w", err)
+	}
+	return nil
+}
+
+// selectOutboxRowsForUpdate блокирует до limit самых ранних доступных для публикации записей outbox,
+// пропуская заблокированные другими экземплярами сервиса.
+func (r *Repository) selectOutboxRowsForUpdate(
+	ctx context.Context, qc sqlx.QueryerContext, limit int,
+) ([]outboxRow, error) {
+	var rows []outboxRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			id,
+			job_id
+		FROM job.outbox
+		WHERE available_at <= now()
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+		`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select outbox rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *Repository) deleteOutboxRows(ctx context.Context, ec sqlx.ExecerContext, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In(`
+		DELETE FROM job.outbox
+		WHERE
+			id IN (?)
+		`,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("sqlx.In: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	_, err = pgutils.Exec(ctx, ec, query, args...)
+	if err != nil {
+		return fmt.Errorf("delete outbox rows: %w", err)
+	}
+	return nil
+}
+
+// failQueueRow отмечает неуспешную попытку обработки задачи захвата claim и откладывает её повторную доставку
+// на backoff, удвоенный за каждую предыдущую неуспешную попытку. Возвращает число неуспешных попыток. Если
+// запись захвата уже заменена, возвращает sql.ErrNoRows.
+func (r *Repository) failQueueRow(
+	ctx context.Context, qc sqlx.QueryerContext, claim queueClaim, backoff time.Duration,
+) (int, error) {
+	var attempts int
+	err := pgutils.Get(ctx, qc, &attempts, `
+		UPDATE job.queue
+		SET
+			attempts = attempts + 1,
+			locked_until = now() + make_interval(secs => $3 * power(2, attempts))
+		WHERE job_id = $1 AND claim_id = $2
+		RETURNING attempts
+		`,
+		claim.JobID,
+		claim.ClaimID,
+		backoff.Seconds(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("fail queue row: %w", err)
+	}
+	return attempts, nil
+}
+
+func (r *Repository) upsertDeadLetterRow(ctx context.Context, ec sqlx.ExtContext, row deadLetterRow) error {
+	err := pgutils.RequireAffected(pgutils.NamedExec(ctx, ec, `
+		INSERT INTO job.dead_letters (
+			job_id,
+			error,
+			attempts
+		)
+		VALUES (
+			:job_id,
+			:error,
+			:attempts
+		)
+		ON CONFLICT (job_id) DO UPDATE
+		SET
+			error = EXCLUDED.error,
+			attempts = EXCLUDED.attempts,
+			created_at = now()
+		`,
+		row,
+	))
+	if err != nil {
+		return fmt.Errorf("upsert dead letter row: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) selectDeadLetterRow(
+	ctx context.Context, qc sqlx.QueryerContext, jobID string,
+) (readDeadLetterRow, error) {
+	var row readDeadLetterRow
+	err := pgutils.Get(ctx, qc, &row, `
+		SELECT
+			d.job_id,
+			j.owner_id,
+			j.state,
+			d.error,
+			d.attempts,
+			d.created_at
+		FROM job.dead_letters d
+		JOIN job.jobs j ON j.id = d.job_id
+		WHERE d.job_id = $1
+		`,
+		jobID,
+	)
+	if err != nil {
+		return readDeadLetterRow{}, fmt.Errorf("select dead letter row: %w", err)
+	}
+	return row, nil
+}
+
+func (r *Repository) selectDeadLetterRows(ctx context.Context, qc sqlx.QueryerContext) ([]readDeadLetterRow, error) {
+	var rows []readDeadLetterRow
+	err := pgutils.Select(ctx, qc, &rows, `
+		SELECT
+			d.job_id,
+			j.owner_id,
+			j.state,
+			d.error,
+			d.attempts,
+			d.created_at
+		FROM job.dead_letters d
+		JOIN job.jobs j ON j.id = d.job_id
+		ORDER BY d.created_at DESC
+		`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select dead letter rows: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *Repository) deleteDeadLetterRow(ctx context.Context, ec sqlx.ExecerContext, jobID string) error {
+	err := pgutils.RequireAffected(pgutils.Exec(ctx, ec, `
+		DELETE FROM job.dead_letters
+		WHERE job_id = $1
+		`,
+		jobID,
+	))
+	if err != nil {
+		return fmt.Errorf("delete dead letter row: %w", err)
+	}
+	return nil
+}
+
+// countReadyQueueRows возвращает число задач очереди, не захваченных ни одним экземпляром сервиса.
+func (r *Repository) countReadyQueueRows(ctx context.Context, qc sqlx.QueryerContext) (int, error) {
+	var n int
+	err := pgutils.Get(ctx, qc, &n, `
+		SELECT count(*)
+		FROM job.queue
+		WHERE locked_until IS NULL OR locked_until < now()
+		`,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("count ready queue rows: %w", err)
+	}
+	return n, nil
+}
+
+// countRunningJobRows возвращает число задач, выполняемых всеми экземплярами сервиса.
+func (r *Repository) countRunningJobRows(ctx context.Context, qc sqlx.QueryerContext) (int, error) {
+	var n int
+	err := pgutils.Get(ctx, qc, &n, `
+		SELECT count(*)
+		FROM job.jobs
+		WHERE state = 'running'
+		`,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("count running job rows: %w", err)
+	}
+	return n, nil
+}
+
+// claimBuildRow захватывает самую раннюю ожидающую сборки Blueprint на время lease, переводя её в статус
+// 'building'. Сборка, аренда которой истекла, захватывается повторно. Если таких Blueprint нет, возвращает
+// sql.ErrNoRows.
+func (r *Repository) claimBuildRow(ctx context.Context, qc sqlx.QueryerContext, lease time.Duration) (string, error) {
+	var blueprintID string
+	err := pgutils.Get(ctx, qc, &blueprintID, `
+		UPDATE blueprint.blueprints
+		SET
+			build_status       = 'building',
+			build_locked_until = now() + make_interval(secs => $1)
+		WHERE id = (
+			SELECT id
+			FROM blueprint.blueprints
+			WHERE
+				build_status = 'queued' OR
+				build_status = 'building' AND (build_locked_until IS NULL OR build_locked_until < now())
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+		`,
+		lease.Seconds(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("claim build row: %w", err)
+	}
+	return blueprintID, nil
+}
+
+func (r *Repository) notifyJobEvent(ctx context.Context, ec sqlx.ExecerContext, payload []byte) error {
+	_, err := pgutils.Exec(ctx, ec, `
+		SELECT pg_notify($1, $2)
+		`,
+		jobEventsChannel, string(payload),
+	)
+	if err != nil {
+		return fmt.Errorf("notify job event: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) updateJobHeartbeat(ctx context.Context, ec sqlx.ExecerContext, jobID string) error {
+	err := pgutils.RequireAffected(pgutils.Exec(ctx, ec, `
+		UPDATE job.jobs
+		SET heartbeat_at = now()
+		WHERE id = $1
+		`,
+		jobID,
+	))
+	if err != nil {
+		return fmt.Errorf("update job heartbeat: %w", err)
+	}
+	return nil
+}
+
+// selectOrphanedJobIDs возвращает выполняющиеся задачи, heartbeat которых (или, до первого heartbeat, время
+// запуска) старше timeout.
+func (r *Repository) selectOrphanedJobIDs(
+	ctx context.Context, qc sqlx.QueryerContext, timeout time.Duration,
+) ([]string, error) {
+	var ids []string
+	err := pgutils.Select(ctx, qc, &ids, `
+		SELECT id
+		FROM job.jobs
+		WHERE
+			state = 'running' AND
+			GREATEST(heartbeat_at, started_at) < now() - make_interval(secs => $1)
+		`,
+		timeout.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select orphaned job ids: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *Repository) selectUndeliveredJobIDs(ctx context.Context, qc sqlx.QueryerContext) ([]string, error) {
+	var ids []string
+	err := pgutils.Select(ctx, qc, &ids, `
+		SELECT j.id
+		FROM job.jobs j
+		WHERE
+			j.state = 'pending' AND
+			NOT EXISTS (SELECT 1 FROM job.outbox o WHERE o.job_id = j.id) AND
+			NOT EXISTS (SELECT 1 FROM job.queue q WHERE q.job_id = j.id) AND
+			NOT EXISTS (SELECT 1 FROM job.dead_letters d WHERE d.job_id = j.id)
+		`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select undelivered job ids: %w", err)
+	}
+	return ids, nil
+}
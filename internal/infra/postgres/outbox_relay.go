@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+const outboxBatchSize = 100
+
+// OutboxRelay публикует задачи, поставленные на публикацию SaveJob и UpdateJob, в JobPublisher; повторные
+// попытки -- не раньше назначенного им времени. Запись outbox удаляется только после успешной публикации,
+// поэтому доставка -- не менее одного раза: при сбое между публикацией и удалением записи задача будет
+// опубликована повторно. Несколько экземпляров сервиса не мешают друг другу.
+type OutboxRelay struct {
+	r        *Repository
+	p        ports.JobPublisher
+	interval time.Duration
+	l        *slog.Logger
+}
+
+func NewOutboxRelay(r *Repository, p ports.JobPublisher, interval time.Duration, l *slog.Logger) *OutboxRelay {
+	return &OutboxRelay{r, p, interval, l}
+}
+
+func (o *OutboxRelay) Run(ctx context.Context) error {
+	l := o.l.With(slog.String("op", "postgres.OutboxRelay.Run"))
+
+	l.InfoContext(ctx, "relaying job outbox", slog.Duration("interval", o.interval))
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+	for {
+		n, err := o.relay(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		} else if err != nil {
+			l.ErrorContext(ctx, "failed to relay job outbox", slog.String("error", err.Error()))
+		} else if n > 0 {
+			l.DebugContext(ctx, "relayed job outbox", slog.Int("published", n))
+		}
+		if err == nil && n == outboxBatchSize {
+			// Outbox ещё не опустел
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *OutboxRelay) relay(ctx context.Context) (int, error) {
+	var published []int64
+	var pubErr error
+	err := pgutils.RunTx(ctx, o.r.db, func(tx *sqlx.Tx) error {
+		rows, err := o.r.selectOutboxRowsForUpdate(ctx, tx, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			// Задача читается вне транзакции: её блокировка помешала бы публикации в очередь Postgres, которая
+			// ссылается на задачу внешним ключом.
+			job, err2 := o.r.job(ctx, o.r.db, value.JobID(row.JobID))
+			if errors.Is(err2, sql.ErrNoRows) {
+				// Задача удалена до публикации
+				published = append(published, row.ID)
+				continue
+			} else if err2 != nil {
+				return err2
+			}
+
+			// Опубликованные записи удаляются, даже если публикация следующей не удалась.
+			if pubErr = o.p.PublishJob(ctx, job); pubErr != nil {
+				break
+			}
+			published = append(published, row.ID)
+		}
+
+		return o.r.deleteOutboxRows(ctx, tx, published)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(published), pubErr
+}
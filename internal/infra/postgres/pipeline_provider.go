@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) Pipeline(ctx context.Context, id value.PipelineID) (*entity.Pipeline, error) {
+	var row pipelineRow
+	var rSteps map[string][]pipelineStepRow
+	var rInputs map[string][]pipelineStepInputRow
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		row, err = r.selectPipelineRow(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		rSteps, err = r.selectPipelinesStepRows(ctx, tx, []string{string(id)})
+		if err != nil {
+			return err
+		}
+		rInputs, err = r.selectPipelinesStepInputRows(ctx, tx, []string{string(id)})
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrPipelineNotFound, string(id))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pipelineRowToDomain(row, rSteps[row.ID], rInputs[row.ID])
+}
+
+func (r *Repository) UserPipelines(ctx context.Context, uid value.UserID) ([]*entity.Pipeline, error) {
+	var rows []pipelineRow
+	var rSteps map[string][]pipelineStepRow
+	var rInputs map[string][]pipelineStepInputRow
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		rows, err = r.selectUserPipelineRows(ctx, tx, string(uid))
+		if err != nil {
+			return err
+		}
+		ids := make([]string, len(rows))
+		for i, row := range rows {
+			ids[i] = row.ID
+		}
+		rSteps, err = r.selectPipelinesStepRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rInputs, err = r.selectPipelinesStepInputRows(ctx, tx, ids)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*entity.Pipeline, len(rows))
+	for i, row := range rows {
+		res[i], err = pipelineRowToDomain(row, rSteps[row.ID], rInputs[row.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (r *Repository) PipelineRun(ctx context.Context, id value.PipelineRunID) (*entity.PipelineRun, error) {
+	var row pipelineRunRow
+	var rSteps map[string][]pipelineRunStepRow
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		row, err = r.selectReadPipelineRunRow(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		rSteps, err = r.selectPipelineRunsStepRows(ctx, tx, []string{string(id)})
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ports.ErrPipelineRunNotFound, string(id))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pipelineRunRowToDomain(row, rSteps[row.ID])
+}
+
+func (r *Repository) PipelineRuns(ctx context.Context, id value.PipelineID) ([]*entity.PipelineRun, error) {
+	var rows []pipelineRunRow
+	var rSteps map[string][]pipelineRunStepRow
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		rows, err = r.selectPipelineRunRows(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		ids := make([]string, len(rows))
+		for i, row := range rows {
+			ids[i] = row.ID
+		}
+		rSteps, err = r.selectPipelineRunsStepRows(ctx, tx, ids)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*entity.PipelineRun, len(rows))
+	for i, row := range rows {
+		res[i], err = pipelineRunRowToDomain(row, rSteps[row.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (r *Repository) RunningPipelineRuns(ctx context.Context) ([]value.PipelineRunID, error) {
+	ids, err := r.selectRunningPipelineRunIDs(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]value.PipelineRunID, len(ids))
+	for i, id := range ids {
+		res[i] = value.PipelineRunID(id)
+	}
+	return res, nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) SavePipeline(ctx context.Context, pipeline *entity.Pipeline) error {
+	rPipeline, rSteps, rInputs := pipelineRowsFromDomain(pipeline)
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := r.insertPipelineRow(ctx, tx, rPipeline); err != nil {
+			return err
+		}
+		if err := r.insertPipelineStepRows(ctx, tx, rSteps); err != nil {
+			return err
+		}
+		if len(rInputs) > 0 {
+			if err := r.insertPipelineStepInputRows(ctx, tx, rInputs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) DeletePipeline(ctx context.Context, id value.PipelineID) error {
+	return r.deletePipelineRow(ctx, r.db, string(id))
+}
+
+func (r *Repository) SavePipelineRun(ctx context.Context, run *entity.PipelineRun, jobs []*entity.Job) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		// Задачи сохраняются раньше шагов, которые ссылаются на них.
+		for _, job := range jobs {
+			if err := r.saveJob(ctx, tx, job); err != nil {
+				return err
+			}
+		}
+		if err := r.insertPipelineRunRow(ctx, tx, pipelineRunRowFromDomain(run)); err != nil {
+			return err
+		}
+		return r.upsertPipelineRunStepRows(ctx, tx, pipelineRunStepRowsFromDomain(run))
+	})
+}
+
+func (r *Repository) AdvancePipelineRun(
+	ctx context.Context,
+	id value.PipelineRunID,
+	advanceFn func(
+		ctx2 context.Context, run *entity.PipelineRun, jobs map[value.JobID]*entity.Job,
+	) ([]*entity.Job, error),
+) error {
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		rRun, err := r.selectPipelineRunRow(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		rSteps, err := r.selectPipelineRunsStepRows(ctx, tx, []string{string(id)})
+		if err != nil {
+			return err
+		}
+		run, err := pipelineRunRowToDomain(rRun, rSteps[string(id)])
+		if err != nil {
+			return err
+		}
+
+		jobs := make(map[value.JobID]*entity.Job)
+		for _, jobID := range run.JobIDs() {
+			job, err2 := r.job(ctx, tx, jobID)
+			if errors.Is(err2, sql.ErrNoRows) {
+				// Удалённая задача не попадает в jobs, и её шаг завершится неуспешно.
+				continue
+			} else if err2 != nil {
+				return err2
+			}
+			jobs[jobID] = job
+		}
+
+		started, err := advanceFn(ctx, run, jobs)
+		if err != nil {
+			return err
+		}
+		for _, job := range started {
+			if err = r.saveJob(ctx, tx, job); err != nil {
+				return err
+			}
+		}
+		if err = r.updatePipelineRunRow(ctx, tx, pipelineRunRowFromDomain(run)); err != nil {
+			return err
+		}
+		return r.upsertPipelineRunStepRows(ctx, tx, pipelineRunStepRowsFromDomain(run))
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ports.ErrPipelineRunNotFound, id)
+	}
+	return err
+}
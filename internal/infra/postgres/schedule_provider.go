@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) Schedule(ctx context.Context, id value.ScheduleID) (dto.Schedule, error) {
+	var row scheduleRow
+	var rValues []scheduleValueRow
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		row, err = r.selectReadScheduleRow(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		rValues, err = r.selectScheduleValueRows(ctx, tx, string(id))
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.Schedule{}, fmt.Errorf("%w: %s", ports.ErrScheduleNotFound, string(id))
+	}
+	if err != nil {
+		return dto.Schedule{}, err
+	}
+
+	return scheduleRowToDTO(row, rValues), nil
+}
+
+func (r *Repository) UserSchedules(ctx context.Context, uid value.UserID) ([]dto.Schedule, error) {
+	var rows []scheduleRow
+	var rValues map[string][]scheduleValueRow
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		rows, err = r.selectUserScheduleRows(ctx, tx, string(uid))
+		if err != nil {
+			return err
+		}
+		ids := make([]string, len(rows))
+		for i, row := range rows {
+			ids[i] = row.ID
+		}
+		rValues, err = r.selectSchedulesValueRows(ctx, tx, ids)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.Schedule, len(rows))
+	for i, row := range rows {
+		res[i] = scheduleRowToDTO(row, rValues[row.ID])
+	}
+	return res, nil
+}
+
+func (r *Repository) ScheduleJobs(ctx context.Context, id value.ScheduleID) ([]dto.Job, error) {
+	var rJs []readJobRow
+	var rIFs map[string][]jobFieldRow
+	var rOFs map[string][]jobFieldRow
+	var rIVs map[string][]jobValueRow
+	var rOVs map[string][]jobValueRow
+	var rAs map[string][]jobAttemptRow
+
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var err error
+		rJs, err = r.selectScheduleReadJobRows(ctx, tx, string(id))
+		if err != nil {
+			return err
+		}
+		ids := idsFromJobs(rJs)
+		rIFs, err = r.selectJobsInputFieldsRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rOFs, err = r.selectJobsOutputFieldsRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rIVs, err = r.selectJobsInputValuesRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rOVs, err = r.selectJobsOutputValuesRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		rAs, err = r.selectJobsAttemptRows(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	js := make([]dto.Job, len(rJs))
+	for i, rJ := range rJs {
+		js[i] = readJobRowToDTO(rJ, rIFs[rJ.ID], rOFs[rJ.ID], rIVs[rJ.ID], rOVs[rJ.ID], rAs[rJ.ID])
+	}
+
+	return js, nil
+}
+
+func (r *Repository) DueSchedules(ctx context.Context, now time.Time) ([]value.ScheduleID, error) {
+	ids, err := r.selectDueScheduleIDs(ctx, r.db, now)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]value.ScheduleID, len(ids))
+	for i, id := range ids {
+		res[i] = value.ScheduleID(id)
+	}
+	return res, nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+func (r *Repository) SaveSchedule(ctx context.Context, schedule *entity.Schedule) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := r.insertScheduleRow(ctx, tx, scheduleRowFromDomain(schedule)); err != nil {
+			return err
+		}
+		if len(schedule.Input()) > 0 {
+			rValues := scheduleValueRowsFromDomain(schedule.Input(), schedule.ID())
+			if err := r.insertScheduleValueRows(ctx, tx, rValues); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) UpdateSchedule(
+	ctx context.Context,
+	id value.ScheduleID,
+	updateFn func(ctx2 context.Context, s *entity.Schedule) error,
+) error {
+	return r.fireSchedule(
+		ctx, id, false, func(ctx2 context.Context, s *entity.Schedule, _ *entity.Job) (*entity.Job, error) {
+			return nil, updateFn(ctx2, s)
+		},
+	)
+}
+
+func (r *Repository) FireSchedule(
+	ctx context.Context,
+	id value.ScheduleID,
+	fireFn func(ctx2 context.Context, s *entity.Schedule, prev *entity.Job) (*entity.Job, error),
+) error {
+	return r.fireSchedule(ctx, id, true, fireFn)
+}
+
+// fireSchedule изменяет расписание в транзакции; задача прошлого срабатывания блокируется и передаётся в fireFn,
+// только если withPrev.
+func (r *Repository) fireSchedule(
+	ctx context.Context,
+	id value.ScheduleID,
+	withPrev bool,
+	fireFn func(ctx2 context.Context, s *entity.Schedule, prev *entity.Job) (*entity.Job, error),
+) error {
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		schedule, err := r.schedule(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		var prev *entity.Job
+		if lastID := schedule.LastJobID(); withPrev && lastID != nil {
+			prev, err = r.job(ctx, tx, *lastID)
+			if errors.Is(err, sql.ErrNoRows) {
+				// Задача прошлого срабатывания удалена.
+				prev = nil
+			} else if err != nil {
+				return err
+			}
+		}
+		var prevState value.JobState
+		if prev != nil {
+			prevState = prev.State()
+		}
+		input := schedule.Input()
+		job, err := fireFn(ctx, schedule, prev)
+		if err != nil {
+			return err
+		}
+		if prev != nil && prev.State() != prevState {
+			if err = r.updateJob(ctx, tx, prev); err != nil {
+				return err
+			}
+		}
+		// Задача сохраняется раньше расписания, которое ссылается на неё как на последнюю.
+		if job != nil {
+			if err = r.saveJob(ctx, tx, job); err != nil {
+				return err
+			}
+		}
+		if err = r.updateScheduleRow(ctx, tx, scheduleRowFromDomain(schedule)); err != nil {
+			return err
+		}
+		if !slices.Equal(input, schedule.Input()) {
+			return r.replaceScheduleValues(ctx, tx, schedule)
+		}
+		return nil
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ports.ErrScheduleNotFound, id)
+	}
+	return err
+}
+
+func (r *Repository) DeleteSchedule(ctx context.Context, id value.ScheduleID) error {
+	return r.deleteScheduleRow(ctx, r.db, string(id))
+}
+
+func (r *Repository) schedule(
+	ctx context.Context, qc sqlx.QueryerContext, id value.ScheduleID,
+) (*entity.Schedule, error) {
+	row, err := r.selectScheduleRow(ctx, qc, string(id))
+	if err != nil {
+		return nil, err
+	}
+	rValues, err := r.selectScheduleValueRows(ctx, qc, string(id))
+	if err != nil {
+		return nil, err
+	}
+	return scheduleRowToDomain(row, rValues)
+}
+
+func (r *Repository) replaceScheduleValues(ctx context.Context, ec sqlx.ExtContext, schedule *entity.Schedule) error {
+	if err := r.deleteScheduleValueRows(ctx, ec, string(schedule.ID())); err != nil {
+		return err
+	}
+	if len(schedule.Input()) == 0 {
+		return nil
+	}
+	return r.insertScheduleValueRows(ctx, ec, scheduleValueRowsFromDomain(schedule.Input(), schedule.ID()))
+}
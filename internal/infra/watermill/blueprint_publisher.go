@@ -0,0 +1,35 @@
+package watermill
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/entity"
+)
+
+type BlueprintPublisher struct {
+	p message.Publisher
+}
+
+func NewBlueprintPublisher(p message.Publisher) BlueprintPublisher {
+	return BlueprintPublisher{p}
+}
+
+func (p BlueprintPublisher) PublishBlueprintBuild(_ context.Context, blueprint *entity.Blueprint) error {
+	pl := blueprintPayload{
+		BlueprintID: string(blueprint.ID()),
+	}
+	msg, err := json.Marshal(pl)
+	if err != nil {
+		return fmt.Errorf("failed to marshall blueprint: %w", err)
+	}
+	wMsg := message.NewMessage(
+		watermill.NewShortUUID(),
+		msg,
+	)
+	return p.p.Publish(topicBuildBlueprint, wMsg)
+}
@@ -0,0 +1,75 @@
+package watermill
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+type BlueprintSubscriber struct {
+	s message.Subscriber
+	l *slog.Logger
+}
+
+func NewBlueprintSubscriber(s message.Subscriber, l *slog.Logger) BlueprintSubscriber {
+	return BlueprintSubscriber{s, l}
+}
+
+func (s BlueprintSubscriber) Listen(
+	ctx context.Context, callback func(ctx context.Context, blueprintID string) error,
+) error {
+	l := s.l.With(slog.String("op", "watermill.BlueprintSubscriber.Listen"))
+
+	msgCh, err := s.s.Subscribe(ctx, topicBuildBlueprint)
+	if err != nil {
+		l.ErrorContext(
+			ctx,
+			"failed to subscribe to topic build blueprint",
+			slog.String("topic", topicBuildBlueprint),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	l.InfoContext(ctx, "successfully subscribed to topic build blueprint, listening")
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case msg, ok := <-msgCh:
+			if !ok {
+				// Закрытие канала
+				l.InfoContext(ctx, "channel closed")
+				return nil
+			}
+			go func() { s.handle(ctx, msg, callback) }()
+		}
+	}
+}
+
+func (s BlueprintSubscriber) handle(
+	ctx context.Context,
+	msg *message.Message,
+	callback func(ctx context.Context, blueprintID string) error,
+) {
+	l := s.l.With(
+		slog.String("op", "watermill.BlueprintSubscriber.handle"),
+		slog.String("message", msg.UUID),
+	)
+
+	var pl blueprintPayload
+	if err := json.Unmarshal(msg.Payload, &pl); err != nil {
+		l.ErrorContext(ctx, "failed to unmarshal payload", slog.String("error", err.Error()))
+		return
+	}
+
+	l = l.With(slog.String("blueprintID", pl.BlueprintID))
+	if err := callback(context.Background(), pl.BlueprintID); err != nil {
+		l.ErrorContext(ctx, "failed to handle payload", slog.String("error", err.Error()))
+		return
+	}
+	l.InfoContext(ctx, "handled blueprint build")
+}
@@ -3,13 +3,32 @@ package watermill
 import (
 	"log/slog"
 
+	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
 
+	"github.com/bmstu-itstech/scriptum-back/internal/config"
+
 	"github.com/bmstu-itstech/scriptum-back/pkg/logs/sl"
 )
 
-func NewJobPubSubGoChannels(l *slog.Logger) (JobPublisher, JobSubscriber) {
+func NewJobPubSubGoChannels(cfg config.Queue, l *slog.Logger) (JobPublisher, *JobSubscriber) {
 	logger := sl.NewWatermillLoggerAdapter(l)
 	pubSub := gochannel.NewGoChannel(gochannel.Config{}, logger)
-	return NewJobPublisher(pubSub), NewJobSubscriber(pubSub, l)
+	return NewJobPublisher(pubSub), NewJobSubscriber(pubSub, pubSub, cfg, l)
+}
+
+func NewBlueprintPubSubGoChannels(l *slog.Logger) (BlueprintPublisher, BlueprintSubscriber) {
+	logger := sl.NewWatermillLoggerAdapter(l)
+	// Сборки ставятся в очередь при запуске сервиса, возможно, раньше подписки на топик, поэтому сообщения
+	// сохраняются до появления подписчика. Сборки редки, так что накопление сообщений в памяти некритично.
+	pubSub := gochannel.NewGoChannel(gochannel.Config{Persistent: true}, logger)
+	return NewBlueprintPublisher(pubSub), NewBlueprintSubscriber(pubSub, l)
+}
+
+func NewJobEventPubSubGoChannels(l *slog.Logger) (JobEventPublisher, JobEventSubscriber) {
+	// Публикация дожидается подтверждения подписчиками, чтобы строки вывода доставлялись по порядку.
+	// Большинство событий публикуется без подписчиков, о чём gochannel сообщает на уровне Info, поэтому
+	// его журнал отключён.
+	pubSub := gochannel.NewGoChannel(gochannel.Config{BlockPublishUntilSubscriberAck: true}, watermill.NopLogger{})
+	return NewJobEventPublisher(pubSub), NewJobEventSubscriber(pubSub, l)
 }
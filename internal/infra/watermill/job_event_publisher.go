@@ -0,0 +1,38 @@
+package watermill
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+)
+
+type JobEventPublisher struct {
+	p message.Publisher
+}
+
+func NewJobEventPublisher(p message.Publisher) JobEventPublisher {
+	return JobEventPublisher{p}
+}
+
+func (p JobEventPublisher) PublishJobEvent(_ context.Context, e dto.JobEvent) error {
+	pl := jobEventPayload{
+		Kind:   e.Kind,
+		State:  e.State,
+		Stream: e.Stream,
+		Line:   e.Line,
+	}
+	msg, err := json.Marshal(pl)
+	if err != nil {
+		return fmt.Errorf("failed to marshall job event: %w", err)
+	}
+	wMsg := message.NewMessage(
+		watermill.NewShortUUID(),
+		msg,
+	)
+	return p.p.Publish(topicJobEvents(e.JobID), wMsg)
+}
@@ -0,0 +1,76 @@
+package watermill
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+)
+
+// jobEventsBuffer -- число событий, ожидающих медленного подписчика. Строки вывода сверх него отбрасываются,
+// чтобы подписчик не задерживал выполнение задачи; смены состояния не отбрасываются никогда.
+const jobEventsBuffer = 256
+
+type JobEventSubscriber struct {
+	s message.Subscriber
+	l *slog.Logger
+}
+
+func NewJobEventSubscriber(s message.Subscriber, l *slog.Logger) JobEventSubscriber {
+	return JobEventSubscriber{s, l}
+}
+
+func (s JobEventSubscriber) SubscribeJobEvents(ctx context.Context, id value.JobID) (<-chan dto.JobEvent, error) {
+	l := s.l.With(
+		slog.String("op", "watermill.JobEventSubscriber.SubscribeJobEvents"),
+		slog.String("jobID", string(id)),
+	)
+
+	msgCh, err := s.s.Subscribe(ctx, topicJobEvents(string(id)))
+	if err != nil {
+		l.ErrorContext(ctx, "failed to subscribe to job events", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	out := make(chan dto.JobEvent, jobEventsBuffer)
+	go func() {
+		defer close(out)
+		// Канал сообщений закрывается после отмены ctx
+		for msg := range msgCh {
+			msg.Ack()
+
+			var pl jobEventPayload
+			if err2 := json.Unmarshal(msg.Payload, &pl); err2 != nil {
+				l.ErrorContext(ctx, "failed to unmarshal payload", slog.String("error", err2.Error()))
+				continue
+			}
+			e := dto.JobEvent{
+				JobID:  string(id),
+				Kind:   pl.Kind,
+				State:  pl.State,
+				Stream: pl.Stream,
+				Line:   pl.Line,
+			}
+
+			if e.Kind == dto.JobEventOutput {
+				select {
+				case out <- e:
+				default:
+					l.WarnContext(ctx, "subscriber is too slow, job output dropped")
+				}
+				continue
+			}
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
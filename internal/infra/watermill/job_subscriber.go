@@ -4,20 +4,60 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto"
+	"github.com/bmstu-itstech/scriptum-back/internal/config"
+	"github.com/bmstu-itstech/scriptum-back/pkg/workerpool"
+)
+
+const (
+	metadataError    = "error"
+	metadataAttempts = "attempts"
 )
 
+// JobSubscriber доставляет задачи из топика run-job, обрабатывая не более cfg.MaxConcurrentJobs одновременно.
+// Успешно обработанное сообщение подтверждается (Ack), неуспешное -- отклоняется (Nack) для повторной доставки
+// после задержки. Когда попытки исчерпаны, сообщение перекладывается в топик недоставленных run-job-poison и
+// подтверждается.
 type JobSubscriber struct {
-	s message.Subscriber
-	l *slog.Logger
+	s    message.Subscriber
+	p    message.Publisher
+	cfg  config.Queue
+	pool *workerpool.Pool
+	l    *slog.Logger
+
+	mu       sync.Mutex
+	attempts map[string]int // UUID сообщения -> число неуспешных попыток
+
+	subscribed     chan struct{}
+	subscribedOnce sync.Once
 }
 
-func NewJobSubscriber(s message.Subscriber, l *slog.Logger) JobSubscriber {
-	return JobSubscriber{s, l}
+func NewJobSubscriber(s message.Subscriber, p message.Publisher, cfg config.Queue, l *slog.Logger) *JobSubscriber {
+	return &JobSubscriber{
+		s:          s,
+		p:          p,
+		cfg:        cfg,
+		pool:       workerpool.New(cfg.MaxConcurrentJobs),
+		l:          l,
+		attempts:   make(map[string]int),
+		subscribed: make(chan struct{}),
+	}
+}
+
+// Subscribed возвращает канал, который закрывается после подписки Listen на топик run-job. Очередь в памяти
+// не сохраняет сообщения, опубликованные до подписки, поэтому публиковать задачи можно только после неё.
+func (s *JobSubscriber) Subscribed() <-chan struct{} {
+	return s.subscribed
 }
 
-func (s JobSubscriber) Listen(ctx context.Context, callback func(ctx context.Context, jobID string) error) error {
+func (s *JobSubscriber) Listen(ctx context.Context, callback func(ctx context.Context, jobID string) error) error {
 	l := s.l.With(slog.String("op", "watermill.JobSubscriber.Listen"))
 
 	msgCh, err := s.s.Subscribe(ctx, topicRunJob)
@@ -31,6 +71,7 @@ func (s JobSubscriber) Listen(ctx context.Context, callback func(ctx context.Con
 		return err
 	}
 
+	s.subscribedOnce.Do(func() { close(s.subscribed) })
 	l.InfoContext(ctx, "successfully subscribed to topic run job subscriber, listening")
 	for {
 		select {
@@ -43,12 +84,56 @@ func (s JobSubscriber) Listen(ctx context.Context, callback func(ctx context.Con
 				l.InfoContext(ctx, "channel closed")
 				return nil
 			}
-			go func() { s.handle(ctx, msg, callback) }()
+			// Сообщение ожидает свободного обработчика, а задача остаётся в состоянии pending.
+			go func() { _ = s.pool.Go(ctx, func() { s.handle(ctx, msg, callback) }) }()
 		}
 	}
 }
 
-func (s JobSubscriber) handle(
+func (s *JobSubscriber) WorkerStats(_ context.Context) (dto.WorkerStats, error) {
+	st := s.pool.Stats()
+	return dto.WorkerStats{
+		MaxConcurrentJobs: st.Size,
+		BusyWorkers:       st.Busy,
+		QueueDepth:        st.Waiting,
+	}, nil
+}
+
+// ListenDeadLetters передаёт в callback задачи из топика недоставленных вместе с ошибкой последней попытки.
+func (s *JobSubscriber) ListenDeadLetters(
+	ctx context.Context, callback func(ctx context.Context, jobID string, reason string, attempts int) error,
+) error {
+	l := s.l.With(slog.String("op", "watermill.JobSubscriber.ListenDeadLetters"))
+
+	msgCh, err := s.s.Subscribe(ctx, topicRunJobPoison)
+	if err != nil {
+		l.ErrorContext(
+			ctx,
+			"failed to subscribe to topic run job poison",
+			slog.String("topic", topicRunJobPoison),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	l.InfoContext(ctx, "successfully subscribed to topic run job poison, listening")
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case msg, ok := <-msgCh:
+			if !ok {
+				// Закрытие канала
+				l.InfoContext(ctx, "channel closed")
+				return nil
+			}
+			go func() { s.handleDeadLetter(ctx, msg, callback) }()
+		}
+	}
+}
+
+func (s *JobSubscriber) handle(
 	ctx context.Context,
 	msg *message.Message,
 	callback func(ctx context.Context, jobID string) error,
@@ -60,14 +145,102 @@ func (s JobSubscriber) handle(
 
 	var pl payload
 	if err := json.Unmarshal(msg.Payload, &pl); err != nil {
+		// Повторная доставка не исправит повреждённое сообщение.
 		l.ErrorContext(ctx, "failed to unmarshal payload", slog.String("error", err.Error()))
+		msg.Ack()
 		return
 	}
 
 	l = l.With(slog.String("jobID", pl.JobID))
-	if err := callback(context.Background(), pl.JobID); err != nil {
-		l.ErrorContext(ctx, "failed to handle payload", slog.String("error", err.Error()))
+	err := callback(context.Background(), pl.JobID)
+	if err == nil {
+		s.forget(msg.UUID)
+		msg.Ack()
+		l.InfoContext(ctx, "handled job")
 		return
 	}
-	l.InfoContext(ctx, "handled job")
+
+	attempt := s.fail(msg.UUID)
+	if attempt <= s.cfg.MaxRetries {
+		delay := s.cfg.RetryDelay(attempt)
+		l.WarnContext(
+			ctx, "failed to handle job, retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+			slog.String("error", err.Error()),
+		)
+		// Задержка не должна занимать обработчик.
+		go func() {
+			select {
+			case <-time.After(delay):
+				msg.Nack()
+			case <-ctx.Done():
+			}
+		}()
+		return
+	}
+
+	s.forget(msg.UUID)
+	l.ErrorContext(
+		ctx, "failed to handle job, moving to dead letters",
+		slog.Int("attempts", attempt),
+		slog.String("error", err.Error()),
+	)
+	poison := message.NewMessage(watermill.NewShortUUID(), msg.Payload)
+	poison.Metadata.Set(metadataError, err.Error())
+	poison.Metadata.Set(metadataAttempts, strconv.Itoa(attempt))
+	if err = s.p.Publish(topicRunJobPoison, poison); err != nil {
+		l.ErrorContext(ctx, "failed to publish dead letter", slog.String("error", err.Error()))
+		msg.Nack()
+		return
+	}
+	msg.Ack()
+}
+
+func (s *JobSubscriber) handleDeadLetter(
+	ctx context.Context,
+	msg *message.Message,
+	callback func(ctx context.Context, jobID string, reason string, attempts int) error,
+) {
+	l := s.l.With(
+		slog.String("op", "watermill.JobSubscriber.handleDeadLetter"),
+		slog.String("message", msg.UUID),
+	)
+
+	var pl payload
+	if err := json.Unmarshal(msg.Payload, &pl); err != nil {
+		l.ErrorContext(ctx, "failed to unmarshal payload", slog.String("error", err.Error()))
+		msg.Ack()
+		return
+	}
+	attempts, _ := strconv.Atoi(msg.Metadata.Get(metadataAttempts))
+
+	l = l.With(slog.String("jobID", pl.JobID))
+	if err := callback(context.Background(), pl.JobID, msg.Metadata.Get(metadataError), attempts); err != nil {
+		l.ErrorContext(ctx, "failed to handle dead letter", slog.String("error", err.Error()))
+		// Недоставленное сообщение не должно потеряться, поэтому повторяется без ограничения числа попыток.
+		select {
+		case <-time.After(s.cfg.RetryBackoff):
+			msg.Nack()
+		case <-ctx.Done():
+		}
+		return
+	}
+	msg.Ack()
+	l.InfoContext(ctx, "handled dead letter")
+}
+
+// fail увеличивает и возвращает число неуспешных попыток обработки сообщения. Повторно доставленное
+// gochannel сообщение сохраняет UUID.
+func (s *JobSubscriber) fail(uuid string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts[uuid]++
+	return s.attempts[uuid]
+}
+
+func (s *JobSubscriber) forget(uuid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, uuid)
 }
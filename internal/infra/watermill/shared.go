@@ -1,7 +1,24 @@
 package watermill
 
 const topicRunJob = "run-job"
+const topicRunJobPoison = "run-job-poison"
+const topicBuildBlueprint = "build-blueprint"
+
+func topicJobEvents(jobID string) string {
+	return "job-events." + jobID
+}
 
 type payload struct {
 	JobID string `json:"job_id"`
 }
+
+type blueprintPayload struct {
+	BlueprintID string `json:"blueprint_id"`
+}
+
+type jobEventPayload struct {
+	Kind   string `json:"kind"`
+	State  string `json:"state,omitempty"`
+	Stream string `json:"stream,omitempty"`
+	Line   string `json:"line,omitempty"`
+}
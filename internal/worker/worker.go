@@ -0,0 +1,257 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/scriptum-back/internal/app"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/dto/request"
+	"github.com/bmstu-itstech/scriptum-back/internal/app/ports"
+	"github.com/bmstu-itstech/scriptum-back/internal/config"
+	"github.com/bmstu-itstech/scriptum-back/internal/domain/value"
+	"github.com/bmstu-itstech/scriptum-back/internal/infra/postgres"
+	"github.com/bmstu-itstech/scriptum-back/internal/infra/watermill"
+)
+
+type JobSubscriber interface {
+	ports.WorkerStatsProvider
+	Listen(ctx context.Context, callback func(ctx context.Context, jobID string) error) error
+}
+
+type BlueprintSubscriber interface {
+	Listen(ctx context.Context, callback func(ctx context.Context, blueprintID string) error) error
+}
+
+type DeadLetterSubscriber interface {
+	ListenDeadLetters(
+		ctx context.Context, callback func(ctx context.Context, jobID string, reason string, attempts int) error,
+	) error
+}
+
+// SubscriptionNotifier сообщает о подписке очереди, которая теряет задачи, опубликованные до подписки.
+type SubscriptionNotifier interface {
+	Subscribed() <-chan struct{}
+}
+
+// Queues -- очереди задач и сборок образов, выбранные драйвером очереди.
+type Queues struct {
+	JobPublisher        ports.JobPublisher
+	JobSubscriber       JobSubscriber
+	BlueprintPublisher  ports.BlueprintPublisher
+	BlueprintSubscriber BlueprintSubscriber
+}
+
+func MustNewQueues(cfg config.Queue, repos *postgres.Repository, l *slog.Logger) Queues {
+	switch cfg.Driver {
+	case config.QueueDriverPostgres:
+		jq := postgres.NewJobQueue(repos, cfg, l)
+		bq := postgres.NewBuildQueue(repos, cfg, l)
+		return Queues{jq, jq, bq, bq}
+	case config.QueueDriverGoChannel:
+		jPub, jSub := watermill.NewJobPubSubGoChannels(cfg, l)
+		bPub, bSub := watermill.NewBlueprintPubSubGoChannels(l)
+		return Queues{jPub, jSub, bPub, bSub}
+	}
+	panic(fmt.Sprintf("unknown queue driver: %q", cfg.Driver))
+}
+
+// Worker -- исполнитель: выполняет задачи и сборки образов из очередей, переносит задачи из outbox в очередь,
+// останавливает контейнеры отменённых задач, восстанавливает задачи, прерванные аварийным завершением
+// исполнителей, запускает задачи по расписаниям и шаги конвейеров. Работает как в отдельном процессе
+// cmd/worker, так и встроенным в HTTP-сервер.
+type Worker struct {
+	a     *app.App
+	q     Queues
+	relay *postgres.OutboxRelay
+	hb    ports.JobHeartbeatRepository
+	qCfg  config.Queue
+	wCfg  config.Worker
+	l     *slog.Logger
+}
+
+func New(
+	a *app.App,
+	q Queues,
+	relay *postgres.OutboxRelay,
+	hb ports.JobHeartbeatRepository,
+	qCfg config.Queue,
+	wCfg config.Worker,
+	l *slog.Logger,
+) *Worker {
+	return &Worker{a, q, relay, hb, qCfg, wCfg, l}
+}
+
+// Run запускает исполнителя и возвращает первую ошибку любой из его частей либо ошибку отмены ctx.
+func (w *Worker) Run(ctx context.Context) error {
+	errCh := make(chan error, 8)
+
+	// Сверка выполняется до запуска очередей: иначе опубликованная, но ещё не полученная задача очереди
+	// в памяти была бы опубликована повторно.
+	w.reconcile(ctx, true)
+	go func() {
+		errCh <- w.reconcileLoop(ctx)
+	}()
+
+	go func() {
+		err := w.q.JobSubscriber.Listen(ctx, w.runJob)
+		errCh <- err
+	}()
+
+	// Задачи, перенесённые из outbox в очередь в памяти до подписки на неё, были бы потеряны, а их записи
+	// в outbox -- удалены, поэтому перенос запускается только после подписки.
+	if sn, ok := w.q.JobSubscriber.(SubscriptionNotifier); ok {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errCh:
+			return err
+		case <-sn.Subscribed():
+		}
+	}
+
+	// Очередь Postgres хранит недоставленные задачи сама, очередь в памяти -- передаёт их в отдельный топик.
+	if dlSub, ok := w.q.JobSubscriber.(DeadLetterSubscriber); ok {
+		go func() {
+			err := dlSub.ListenDeadLetters(ctx, func(ctx2 context.Context, jobID, reason string, attempts int) error {
+				req := request.DeadLetterJob{JobID: jobID, Error: reason, Attempts: attempts}
+				return w.a.Commands.DeadLetterJob.Handle(ctx2, req)
+			})
+			errCh <- err
+		}()
+	}
+
+	go func() {
+		err := w.relay.Run(ctx)
+		errCh <- err
+	}()
+
+	go func() {
+		err := w.q.BlueprintSubscriber.Listen(ctx, func(ctx2 context.Context, blueprintID string) error {
+			return w.a.Commands.BuildBlueprint.Handle(ctx2, request.BuildBlueprint{BlueprintID: blueprintID})
+		})
+		errCh <- err
+	}()
+
+	go func() {
+		errCh <- w.stopCancelledJobs(ctx)
+	}()
+
+	go func() {
+		errCh <- w.runSchedules(ctx)
+	}()
+
+	go func() {
+		errCh <- w.advancePipelines(ctx)
+	}()
+
+	// Очередь в памяти теряет сборки при перезапуске, поэтому незавершённые сборки ставятся в неё заново.
+	// Очередь Postgres сама возвращает их исполнителям по истечении аренды.
+	if w.qCfg.Driver == config.QueueDriverGoChannel {
+		if err := w.a.Commands.RequeueBuilds.Handle(ctx); err != nil {
+			w.l.Error("failed to requeue blueprint builds", slog.String("error", err.Error()))
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	}
+}
+
+// runJob выполняет задачу, обновляя её heartbeat до завершения обработки.
+func (w *Worker) runJob(ctx context.Context, jobID string) error {
+	beatCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	go func() {
+		ticker := time.NewTicker(w.wCfg.HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-beatCtx.Done():
+				return
+			case <-ticker.C:
+				if err := w.hb.BeatJob(beatCtx, value.JobID(jobID)); err != nil && beatCtx.Err() == nil {
+					w.l.WarnContext(
+						ctx, "failed to update job heartbeat",
+						slog.String("job_id", jobID),
+						slog.String("error", err.Error()),
+					)
+				}
+			}
+		}
+	}()
+
+	return w.a.Commands.RunJob.Handle(ctx, request.RunJob{JobID: jobID})
+}
+
+// reconcile сверяет состояние задач. Очередь в памяти теряет ожидающие задачи только при перезапуске, а до
+// получения исполнителем они не видны ни в outbox, ни в Postgres, поэтому для неё потерянные задачи ищутся
+// только при запуске.
+func (w *Worker) reconcile(ctx context.Context, startup bool) {
+	// Ошибка уже записана в журнал обработчиком и будет повторена при следующей сверке.
+	_ = w.a.Commands.ReconcileJobs.Handle(ctx, request.ReconcileJobs{
+		HeartbeatTimeout: w.wCfg.HeartbeatTimeout,
+		RepublishPending: startup || w.qCfg.Driver == config.QueueDriverPostgres,
+	})
+}
+
+func (w *Worker) reconcileLoop(ctx context.Context) error {
+	ticker := time.NewTicker(w.wCfg.ReconcileInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.reconcile(ctx, false)
+		}
+	}
+}
+
+func (w *Worker) stopCancelledJobs(ctx context.Context) error {
+	ticker := time.NewTicker(w.qCfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// Ошибка уже записана в журнал обработчиком и будет повторена при следующем опросе.
+			_ = w.a.Commands.StopCancelledJobs.Handle(ctx)
+		}
+	}
+}
+
+func (w *Worker) runSchedules(ctx context.Context) error {
+	ticker := time.NewTicker(w.wCfg.ScheduleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// Ошибка уже записана в журнал обработчиком, несработавшие расписания будут запущены при следующем
+			// опросе.
+			_ = w.a.Commands.RunSchedules.Handle(ctx)
+		}
+	}
+}
+
+func (w *Worker) advancePipelines(ctx context.Context) error {
+	ticker := time.NewTicker(w.wCfg.PipelineInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// Ошибка уже записана в журнал обработчиком, непродвинутые запуски будут продвинуты при следующем
+			// опросе.
+			_ = w.a.Commands.AdvancePipelines.Handle(ctx)
+		}
+	}
+}
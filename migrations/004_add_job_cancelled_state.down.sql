@@ -0,0 +1,18 @@
+UPDATE job.jobs
+    SET state = 'finished'
+    WHERE state = 'cancelled';
+
+ALTER TYPE JOB_STATE_T
+    RENAME TO JOB_STATE_T_OLD;
+
+CREATE TYPE JOB_STATE_T
+AS ENUM (
+    'pending',
+    'running',
+    'finished'
+);
+
+ALTER TABLE job.jobs
+    ALTER COLUMN state TYPE JOB_STATE_T USING state::TEXT::JOB_STATE_T;
+
+DROP TYPE JOB_STATE_T_OLD;
@@ -0,0 +1,2 @@
+ALTER TYPE JOB_STATE_T
+    ADD VALUE IF NOT EXISTS 'cancelled';
@@ -0,0 +1,4 @@
+ALTER TABLE job.jobs
+    DROP COLUMN IF EXISTS result_reason;
+
+DROP TYPE IF EXISTS FAILURE_REASON_T;
@@ -0,0 +1,20 @@
+DO $$ BEGIN
+    CREATE TYPE FAILURE_REASON_T
+    AS ENUM (
+        'build_failed',
+        'timeout',
+        'output_parse_failed',
+        'runtime_error',
+        'infrastructure_error'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS result_reason FAILURE_REASON_T DEFAULT NULL;
+
+-- Для ранее завершённых задач достоверно известна только ошибка выполнения скрипта
+UPDATE job.jobs
+SET result_reason = 'runtime_error'
+WHERE result_code > 0;
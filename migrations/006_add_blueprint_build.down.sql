@@ -0,0 +1,8 @@
+ALTER TABLE job.jobs
+    DROP COLUMN IF EXISTS image;
+
+ALTER TABLE blueprint.blueprints
+    DROP COLUMN IF EXISTS image,
+    DROP COLUMN IF EXISTS build_status;
+
+DROP TYPE IF EXISTS BUILD_STATUS_T;
@@ -0,0 +1,19 @@
+DO $$ BEGIN
+    CREATE TYPE BUILD_STATUS_T
+    AS ENUM (
+        'queued',
+        'building',
+        'ready',
+        'failed'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- Существующие blueprint получают статус 'queued' и будут собраны при следующем запуске сервиса
+ALTER TABLE blueprint.blueprints
+    ADD COLUMN IF NOT EXISTS build_status BUILD_STATUS_T NOT NULL DEFAULT 'queued',
+    ADD COLUMN IF NOT EXISTS image        VARCHAR                 DEFAULT NULL;
+
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS image VARCHAR DEFAULT NULL;
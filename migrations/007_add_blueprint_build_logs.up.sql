@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS blueprint.build_logs (
+    blueprint_id VARCHAR(8)     PRIMARY KEY,
+    log          TEXT           NOT NULL,
+    created_at   TIMESTAMPTZ    NOT NULL    DEFAULT now(),
+
+    FOREIGN KEY (blueprint_id)
+        REFERENCES blueprint.blueprints (id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,31 @@
+ALTER TABLE job.jobs
+    DROP COLUMN IF EXISTS limit_tmpfs_mb,
+    DROP COLUMN IF EXISTS limit_pids,
+    DROP COLUMN IF EXISTS limit_cpus,
+    DROP COLUMN IF EXISTS limit_memory_mb;
+
+ALTER TABLE blueprint.blueprints
+    DROP COLUMN IF EXISTS limit_tmpfs_mb,
+    DROP COLUMN IF EXISTS limit_pids,
+    DROP COLUMN IF EXISTS limit_cpus,
+    DROP COLUMN IF EXISTS limit_memory_mb;
+
+UPDATE job.jobs
+SET result_reason = 'runtime_error'
+WHERE result_reason = 'out_of_memory';
+
+ALTER TYPE FAILURE_REASON_T RENAME TO FAILURE_REASON_T_OLD;
+
+CREATE TYPE FAILURE_REASON_T
+AS ENUM (
+    'build_failed',
+    'timeout',
+    'output_parse_failed',
+    'runtime_error',
+    'infrastructure_error'
+);
+
+ALTER TABLE job.jobs
+    ALTER COLUMN result_reason TYPE FAILURE_REASON_T USING result_reason::TEXT::FAILURE_REASON_T;
+
+DROP TYPE FAILURE_REASON_T_OLD;
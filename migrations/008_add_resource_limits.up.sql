@@ -0,0 +1,14 @@
+ALTER TYPE FAILURE_REASON_T
+    ADD VALUE IF NOT EXISTS 'out_of_memory';
+
+ALTER TABLE blueprint.blueprints
+    ADD COLUMN IF NOT EXISTS limit_memory_mb BIGINT           NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS limit_cpus      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS limit_pids      BIGINT           NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS limit_tmpfs_mb  BIGINT           NOT NULL DEFAULT 0;
+
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS limit_memory_mb BIGINT           NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS limit_cpus      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS limit_pids      BIGINT           NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS limit_tmpfs_mb  BIGINT           NOT NULL DEFAULT 0;
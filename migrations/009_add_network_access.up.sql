@@ -0,0 +1,5 @@
+ALTER TABLE blueprint.blueprints
+    ADD COLUMN IF NOT EXISTS network BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS network BOOLEAN NOT NULL DEFAULT FALSE;
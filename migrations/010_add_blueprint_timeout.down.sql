@@ -0,0 +1,5 @@
+ALTER TABLE job.jobs
+    DROP COLUMN IF EXISTS timeout_seconds;
+
+ALTER TABLE blueprint.blueprints
+    DROP COLUMN IF EXISTS timeout_seconds;
@@ -0,0 +1,5 @@
+ALTER TABLE blueprint.blueprints
+    ADD COLUMN IF NOT EXISTS timeout_seconds BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS timeout_seconds BIGINT NOT NULL DEFAULT 0;
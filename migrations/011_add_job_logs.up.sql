@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS job.job_logs (
+    job_id      VARCHAR(8)     PRIMARY KEY,
+    log         TEXT           NOT NULL,
+    created_at  TIMESTAMPTZ    NOT NULL    DEFAULT now(),
+
+    FOREIGN KEY (job_id)
+        REFERENCES job.jobs (id)
+        ON DELETE CASCADE
+);
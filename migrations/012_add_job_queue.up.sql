@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS job.queue (
+    job_id          VARCHAR(8)  PRIMARY KEY,
+    enqueued_at     TIMESTAMPTZ NOT NULL    DEFAULT now(),
+    locked_until    TIMESTAMPTZ             DEFAULT NULL,
+
+    FOREIGN KEY (job_id)
+        REFERENCES job.jobs (id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS queue_enqueued_at_idx ON job.queue (enqueued_at);
+
+-- Задачи, потерянные очередью в памяти при прошлых перезапусках
+INSERT INTO job.queue (job_id, enqueued_at)
+SELECT id, created_at
+FROM job.jobs
+WHERE state = 'pending' AND deleted_at IS NULL
+ON CONFLICT (job_id) DO NOTHING;
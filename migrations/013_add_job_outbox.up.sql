@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS job.outbox (
+    id          BIGSERIAL   PRIMARY KEY,
+    job_id      VARCHAR(8)  NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL    DEFAULT now(),
+
+    FOREIGN KEY (job_id)
+        REFERENCES job.jobs (id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS job.dead_letters;
+
+ALTER TABLE job.queue
+    DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,13 @@
+ALTER TABLE job.queue
+    ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS job.dead_letters (
+    job_id      VARCHAR(8)  PRIMARY KEY,
+    error       TEXT        NOT NULL,
+    attempts    INTEGER     NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL    DEFAULT now(),
+
+    FOREIGN KEY (job_id)
+        REFERENCES job.jobs (id)
+        ON DELETE CASCADE
+);
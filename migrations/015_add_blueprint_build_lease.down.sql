@@ -0,0 +1,2 @@
+ALTER TABLE blueprint.blueprints
+    DROP COLUMN IF EXISTS build_locked_until;
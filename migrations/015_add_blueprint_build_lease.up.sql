@@ -0,0 +1,3 @@
+-- Сборка захватывается исполнителем на время аренды, как и задачи очереди job.queue
+ALTER TABLE blueprint.blueprints
+    ADD COLUMN IF NOT EXISTS build_locked_until TIMESTAMPTZ DEFAULT NULL;
@@ -0,0 +1,23 @@
+ALTER TABLE job.jobs
+    DROP COLUMN IF EXISTS heartbeat_at;
+
+UPDATE job.jobs
+SET result_reason = 'infrastructure_error'
+WHERE result_reason = 'interrupted';
+
+ALTER TYPE FAILURE_REASON_T RENAME TO FAILURE_REASON_T_OLD;
+
+CREATE TYPE FAILURE_REASON_T
+AS ENUM (
+    'build_failed',
+    'timeout',
+    'out_of_memory',
+    'output_parse_failed',
+    'runtime_error',
+    'infrastructure_error'
+);
+
+ALTER TABLE job.jobs
+    ALTER COLUMN result_reason TYPE FAILURE_REASON_T USING result_reason::TEXT::FAILURE_REASON_T;
+
+DROP TYPE FAILURE_REASON_T_OLD;
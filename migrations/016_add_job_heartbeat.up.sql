@@ -0,0 +1,6 @@
+ALTER TYPE FAILURE_REASON_T
+    ADD VALUE IF NOT EXISTS 'interrupted';
+
+-- Исполнитель периодически обновляет heartbeat_at выполняемой задачи
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS heartbeat_at TIMESTAMPTZ DEFAULT NULL;
@@ -0,0 +1,17 @@
+ALTER TABLE job.outbox
+    DROP COLUMN IF EXISTS available_at;
+
+DROP TABLE IF EXISTS job.attempts;
+
+ALTER TABLE job.jobs
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS retry_on,
+    DROP COLUMN IF EXISTS retry_backoff_seconds,
+    DROP COLUMN IF EXISTS retry_max_attempts;
+
+ALTER TABLE blueprint.blueprints
+    DROP COLUMN IF EXISTS retry_on,
+    DROP COLUMN IF EXISTS retry_backoff_seconds,
+    DROP COLUMN IF EXISTS retry_max_attempts;
+
+DROP TYPE IF EXISTS RETRY_ON_T;
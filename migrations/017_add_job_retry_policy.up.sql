@@ -0,0 +1,39 @@
+DO $$ BEGIN
+    CREATE TYPE RETRY_ON_T
+    AS ENUM (
+        'infrastructure',
+        'any_failure'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+ALTER TABLE blueprint.blueprints
+    ADD COLUMN IF NOT EXISTS retry_max_attempts    INT        NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS retry_backoff_seconds BIGINT     NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS retry_on              RETRY_ON_T NOT NULL DEFAULT 'infrastructure';
+
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS retry_max_attempts    INT         NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS retry_backoff_seconds BIGINT      NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS retry_on              RETRY_ON_T  NOT NULL DEFAULT 'infrastructure',
+    ADD COLUMN IF NOT EXISTS next_attempt_at       TIMESTAMPTZ          DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS job.attempts (
+    job_id          VARCHAR(8)          NOT NULL,
+    number          INT                 NOT NULL,
+    started_at      TIMESTAMPTZ         NOT NULL,
+    finished_at     TIMESTAMPTZ         NOT NULL,
+    result_code     INT                 NOT NULL,
+    result_msg      VARCHAR                      DEFAULT NULL,
+    result_reason   FAILURE_REASON_T             DEFAULT NULL,
+
+    PRIMARY KEY (job_id, number),
+    FOREIGN KEY (job_id)
+        REFERENCES job.jobs (id)
+        ON DELETE CASCADE
+);
+
+-- Повторная попытка публикуется не раньше available_at
+ALTER TABLE job.outbox
+    ADD COLUMN IF NOT EXISTS available_at TIMESTAMPTZ NOT NULL DEFAULT now();
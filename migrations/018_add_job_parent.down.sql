@@ -0,0 +1,2 @@
+ALTER TABLE job.jobs
+    DROP COLUMN IF EXISTS parent_job_id;
@@ -0,0 +1,4 @@
+-- Задача, повторным запуском которой является эта задача
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS parent_job_id VARCHAR(8) DEFAULT NULL
+        REFERENCES job.jobs (id) ON DELETE SET NULL;
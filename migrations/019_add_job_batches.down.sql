@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS job.jobs_batch_id_idx;
+
+ALTER TABLE job.jobs
+    DROP COLUMN IF EXISTS batch_id;
+
+DROP TABLE IF EXISTS job.batches;
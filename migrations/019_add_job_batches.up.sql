@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS job.batches (
+    id              VARCHAR(8)  PRIMARY KEY,
+    blueprint_id    VARCHAR(8)  NOT NULL,
+    owner_id        VARCHAR(8)  NOT NULL,
+    size            INTEGER     NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL    DEFAULT now(),
+
+    FOREIGN KEY (blueprint_id)
+        REFERENCES blueprint.blueprints (id)
+        ON DELETE CASCADE
+);
+
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS batch_id VARCHAR(8) DEFAULT NULL
+        REFERENCES job.batches (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS jobs_batch_id_idx ON job.jobs (batch_id);
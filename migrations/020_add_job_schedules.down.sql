@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS job.jobs_schedule_id_idx;
+
+ALTER TABLE job.jobs
+    DROP COLUMN IF EXISTS schedule_id;
+
+DROP TABLE IF EXISTS job.schedule_values;
+
+DROP INDEX IF EXISTS job.schedules_next_run_at_idx;
+
+DROP TABLE IF EXISTS job.schedules;
+
+DROP TYPE IF EXISTS OVERLAP_POLICY_T;
@@ -0,0 +1,52 @@
+DO $$ BEGIN
+    CREATE TYPE OVERLAP_POLICY_T
+    AS ENUM (
+        'allow',
+        'forbid',
+        'replace'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS job.schedules (
+    id              VARCHAR(8)          PRIMARY KEY,
+    blueprint_id    VARCHAR(8)          NOT NULL,
+    owner_id        VARCHAR(8)          NOT NULL,
+    cron            VARCHAR             NOT NULL,
+    timezone        VARCHAR             NOT NULL,
+    overlap_policy  OVERLAP_POLICY_T    NOT NULL,
+    enabled         BOOLEAN             NOT NULL,
+    last_job_id     VARCHAR(8)                      DEFAULT NULL,
+    next_run_at     TIMESTAMPTZ                     DEFAULT NULL,
+    created_at      TIMESTAMPTZ         NOT NULL    DEFAULT now(),
+
+    FOREIGN KEY (blueprint_id)
+        REFERENCES blueprint.blueprints (id)
+        ON DELETE CASCADE,
+
+    FOREIGN KEY (last_job_id)
+        REFERENCES job.jobs (id)
+        ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS schedules_next_run_at_idx ON job.schedules (next_run_at) WHERE enabled;
+
+CREATE TABLE IF NOT EXISTS job.schedule_values (
+    schedule_id VARCHAR(8)      NOT NULL,
+    index       INTEGER         NOT NULL,
+    type        VALUE_TYPE_T    NOT NULL,
+    value       VARCHAR         NOT NULL,
+
+    PRIMARY KEY (schedule_id, index),
+
+    FOREIGN KEY (schedule_id)
+        REFERENCES job.schedules (id)
+        ON DELETE CASCADE
+);
+
+ALTER TABLE job.jobs
+    ADD COLUMN IF NOT EXISTS schedule_id VARCHAR(8) DEFAULT NULL
+        REFERENCES job.schedules (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS jobs_schedule_id_idx ON job.jobs (schedule_id);
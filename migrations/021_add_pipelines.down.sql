@@ -0,0 +1,19 @@
+DROP TABLE IF EXISTS job.pipeline_run_steps;
+
+DROP INDEX IF EXISTS job.pipeline_runs_running_idx;
+
+DROP INDEX IF EXISTS job.pipeline_runs_pipeline_id_idx;
+
+DROP TABLE IF EXISTS job.pipeline_runs;
+
+DROP TABLE IF EXISTS job.pipeline_step_inputs;
+
+DROP TABLE IF EXISTS job.pipeline_steps;
+
+DROP INDEX IF EXISTS job.pipelines_owner_id_idx;
+
+DROP TABLE IF EXISTS job.pipelines;
+
+DROP TYPE IF EXISTS PIPELINE_RUN_STATE_T;
+
+DROP TYPE IF EXISTS STEP_STATE_T;
@@ -0,0 +1,108 @@
+DO $$ BEGIN
+    CREATE TYPE STEP_STATE_T
+    AS ENUM (
+        'waiting',
+        'running',
+        'succeeded',
+        'failed',
+        'skipped'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+DO $$ BEGIN
+    CREATE TYPE PIPELINE_RUN_STATE_T
+    AS ENUM (
+        'running',
+        'succeeded',
+        'failed'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS job.pipelines (
+    id          VARCHAR(8)  PRIMARY KEY,
+    owner_id    VARCHAR(8)  NOT NULL,
+    name        VARCHAR     NOT NULL,
+    description VARCHAR                 DEFAULT NULL,
+    created_at  TIMESTAMPTZ NOT NULL    DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS pipelines_owner_id_idx ON job.pipelines (owner_id);
+
+-- Шаги хранятся в порядке зависимостей.
+CREATE TABLE IF NOT EXISTS job.pipeline_steps (
+    pipeline_id     VARCHAR(8)  NOT NULL,
+    index           INTEGER     NOT NULL,
+    name            VARCHAR     NOT NULL,
+    blueprint_id    VARCHAR(8)  NOT NULL,
+
+    PRIMARY KEY (pipeline_id, index),
+    UNIQUE (pipeline_id, name),
+
+    FOREIGN KEY (pipeline_id)
+        REFERENCES job.pipelines (id)
+        ON DELETE CASCADE,
+
+    FOREIGN KEY (blueprint_id)
+        REFERENCES blueprint.blueprints (id)
+);
+
+-- Источник входного поля шага: постоянное значение (type, value) либо выходное поле шага (source_step,
+-- source_field).
+CREATE TABLE IF NOT EXISTS job.pipeline_step_inputs (
+    pipeline_id     VARCHAR(8)      NOT NULL,
+    step_index      INTEGER         NOT NULL,
+    index           INTEGER         NOT NULL,
+    type            VALUE_TYPE_T                DEFAULT NULL,
+    value           VARCHAR                     DEFAULT NULL,
+    source_step     VARCHAR                     DEFAULT NULL,
+    source_field    INTEGER                     DEFAULT NULL,
+
+    PRIMARY KEY (pipeline_id, step_index, index),
+
+    FOREIGN KEY (pipeline_id, step_index)
+        REFERENCES job.pipeline_steps (pipeline_id, index)
+        ON DELETE CASCADE,
+
+    CHECK ((value IS NULL) = (type IS NULL)),
+    CHECK ((source_step IS NULL) = (source_field IS NULL)),
+    CHECK ((value IS NULL) <> (source_step IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS job.pipeline_runs (
+    id          VARCHAR(8)              PRIMARY KEY,
+    pipeline_id VARCHAR(8)              NOT NULL,
+    owner_id    VARCHAR(8)              NOT NULL,
+    state       PIPELINE_RUN_STATE_T    NOT NULL,
+    created_at  TIMESTAMPTZ             NOT NULL    DEFAULT now(),
+    finished_at TIMESTAMPTZ                         DEFAULT NULL,
+
+    FOREIGN KEY (pipeline_id)
+        REFERENCES job.pipelines (id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS pipeline_runs_pipeline_id_idx ON job.pipeline_runs (pipeline_id);
+CREATE INDEX IF NOT EXISTS pipeline_runs_running_idx ON job.pipeline_runs (created_at) WHERE state = 'running';
+
+CREATE TABLE IF NOT EXISTS job.pipeline_run_steps (
+    run_id  VARCHAR(8)      NOT NULL,
+    index   INTEGER         NOT NULL,
+    name    VARCHAR         NOT NULL,
+    state   STEP_STATE_T    NOT NULL,
+    job_id  VARCHAR(8)                  DEFAULT NULL,
+    message VARCHAR                     DEFAULT NULL,
+
+    PRIMARY KEY (run_id, index),
+
+    FOREIGN KEY (run_id)
+        REFERENCES job.pipeline_runs (id)
+        ON DELETE CASCADE,
+
+    FOREIGN KEY (job_id)
+        REFERENCES job.jobs (id)
+        ON DELETE SET NULL
+);
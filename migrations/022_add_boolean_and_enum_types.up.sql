@@ -0,0 +1,15 @@
+ALTER TYPE VALUE_TYPE_T
+    ADD VALUE IF NOT EXISTS 'boolean';
+
+ALTER TYPE VALUE_TYPE_T
+    ADD VALUE IF NOT EXISTS 'enum';
+
+-- Допустимые значения полей типа enum; для полей остальных типов -- NULL.
+ALTER TABLE blueprint.input_fields
+    ADD COLUMN IF NOT EXISTS options VARCHAR[] DEFAULT NULL;
+
+ALTER TABLE blueprint.output_fields
+    ADD COLUMN IF NOT EXISTS options VARCHAR[] DEFAULT NULL;
+
+ALTER TABLE job.output_fields
+    ADD COLUMN IF NOT EXISTS options VARCHAR[] DEFAULT NULL;
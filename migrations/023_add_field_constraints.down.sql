@@ -0,0 +1,6 @@
+ALTER TABLE blueprint.input_fields
+    DROP COLUMN IF EXISTS pattern,
+    DROP COLUMN IF EXISTS max_length,
+    DROP COLUMN IF EXISTS min_length,
+    DROP COLUMN IF EXISTS max_value,
+    DROP COLUMN IF EXISTS min_value;
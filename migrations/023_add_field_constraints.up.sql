@@ -0,0 +1,8 @@
+-- Необязательные ограничения значений входных полей: границы для integer и real, длина и регулярное
+-- выражение для string.
+ALTER TABLE blueprint.input_fields
+    ADD COLUMN IF NOT EXISTS min_value  VARCHAR DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS max_value  VARCHAR DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS min_length INTEGER DEFAULT NULL CHECK (min_length >= 0),
+    ADD COLUMN IF NOT EXISTS max_length INTEGER DEFAULT NULL CHECK (max_length >= 0),
+    ADD COLUMN IF NOT EXISTS pattern    VARCHAR DEFAULT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE blueprint.input_fields
+    DROP COLUMN IF EXISTS default_value;
@@ -0,0 +1,3 @@
+-- Значение по умолчанию необязательного входного поля; у обязательных полей -- NULL.
+ALTER TABLE blueprint.input_fields
+    ADD COLUMN IF NOT EXISTS default_value VARCHAR DEFAULT NULL;
@@ -0,0 +1,45 @@
+DROP TABLE IF EXISTS files;
+
+-- Ссылки на файлы сохраняются как строки с ID файла.
+UPDATE blueprint.input_fields SET type = 'string' WHERE type = 'file';
+UPDATE blueprint.output_fields SET type = 'string' WHERE type = 'file';
+UPDATE job.input_values SET type = 'string' WHERE type = 'file';
+UPDATE job.output_values SET type = 'string' WHERE type = 'file';
+UPDATE job.output_fields SET type = 'string' WHERE type = 'file';
+UPDATE job.schedule_values SET type = 'string' WHERE type = 'file';
+UPDATE job.pipeline_step_inputs SET type = 'string' WHERE type = 'file';
+
+ALTER TYPE VALUE_TYPE_T
+    RENAME TO VALUE_TYPE_T_OLD;
+
+CREATE TYPE VALUE_TYPE_T
+AS ENUM (
+    'integer',
+    'real',
+    'string',
+    'boolean',
+    'enum'
+);
+
+ALTER TABLE blueprint.input_fields
+    ALTER COLUMN type TYPE VALUE_TYPE_T USING type::TEXT::VALUE_TYPE_T;
+
+ALTER TABLE blueprint.output_fields
+    ALTER COLUMN type TYPE VALUE_TYPE_T USING type::TEXT::VALUE_TYPE_T;
+
+ALTER TABLE job.input_values
+    ALTER COLUMN type TYPE VALUE_TYPE_T USING type::TEXT::VALUE_TYPE_T;
+
+ALTER TABLE job.output_values
+    ALTER COLUMN type TYPE VALUE_TYPE_T USING type::TEXT::VALUE_TYPE_T;
+
+ALTER TABLE job.output_fields
+    ALTER COLUMN type TYPE VALUE_TYPE_T USING type::TEXT::VALUE_TYPE_T;
+
+ALTER TABLE job.schedule_values
+    ALTER COLUMN type TYPE VALUE_TYPE_T USING type::TEXT::VALUE_TYPE_T;
+
+ALTER TABLE job.pipeline_step_inputs
+    ALTER COLUMN type TYPE VALUE_TYPE_T USING type::TEXT::VALUE_TYPE_T;
+
+DROP TYPE VALUE_TYPE_T_OLD;
@@ -0,0 +1,11 @@
+ALTER TYPE VALUE_TYPE_T
+    ADD VALUE IF NOT EXISTS 'file';
+
+-- Владельцы загруженных файлов; содержимое файлов хранится в файловом хранилище. Файлы, загруженные до
+-- учёта владельцев, не могут быть переданы задачам.
+CREATE TABLE IF NOT EXISTS files (
+    id          VARCHAR(8)      PRIMARY KEY,
+    owner_id    VARCHAR(8)      NOT NULL,
+    name        VARCHAR         NOT NULL,
+    created_at  TIMESTAMPTZ     NOT NULL    DEFAULT now()
+);
@@ -0,0 +1,4 @@
+ALTER TABLE job.queue
+    DROP COLUMN IF EXISTS claim_id;
+
+DROP SEQUENCE IF EXISTS job.queue_claim_id_seq;
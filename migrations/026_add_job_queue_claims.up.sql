@@ -0,0 +1,6 @@
+-- Каждый захват записи очереди получает новый claim_id: подтверждение обработки удаляет запись, только если
+-- она не была заменена повторной доставкой и не захвачена заново после истечения аренды.
+CREATE SEQUENCE IF NOT EXISTS job.queue_claim_id_seq;
+
+ALTER TABLE job.queue
+    ADD COLUMN IF NOT EXISTS claim_id BIGINT DEFAULT NULL;
@@ -0,0 +1,28 @@
+UPDATE job.jobs
+SET result_reason = 'infrastructure_error'
+WHERE result_reason = 'input_file_missing';
+
+UPDATE job.attempts
+SET result_reason = 'infrastructure_error'
+WHERE result_reason = 'input_file_missing';
+
+ALTER TYPE FAILURE_REASON_T RENAME TO FAILURE_REASON_T_OLD;
+
+CREATE TYPE FAILURE_REASON_T
+AS ENUM (
+    'build_failed',
+    'timeout',
+    'out_of_memory',
+    'output_parse_failed',
+    'runtime_error',
+    'infrastructure_error',
+    'interrupted'
+);
+
+ALTER TABLE job.jobs
+    ALTER COLUMN result_reason TYPE FAILURE_REASON_T USING result_reason::TEXT::FAILURE_REASON_T;
+
+ALTER TABLE job.attempts
+    ALTER COLUMN result_reason TYPE FAILURE_REASON_T USING result_reason::TEXT::FAILURE_REASON_T;
+
+DROP TYPE FAILURE_REASON_T_OLD;
@@ -0,0 +1,2 @@
+ALTER TYPE FAILURE_REASON_T
+    ADD VALUE IF NOT EXISTS 'input_file_missing';
@@ -0,0 +1,63 @@
+package workerpool
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Pool ограничивает число одновременно выполняемых функций.
+type Pool struct {
+	slots   chan struct{}
+	waiting atomic.Int64
+}
+
+type Stats struct {
+	Size    int // Число обработчиков
+	Busy    int // Число занятых обработчиков
+	Waiting int // Число ожидающих свободного обработчика
+}
+
+// New создаёт пул из size обработчиков; size меньше 1 считается равным 1.
+func New(size int) *Pool {
+	if size < 1 {
+		size = 1
+	}
+	return &Pool{slots: make(chan struct{}, size)}
+}
+
+// Acquire занимает обработчик, ожидая его освобождения. Занятый обработчик освобождается вызовом Release.
+func (p *Pool) Acquire(ctx context.Context) error {
+	p.waiting.Add(1)
+	defer p.waiting.Add(-1)
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) Release() {
+	<-p.slots
+}
+
+// Go выполняет f в отдельной горутине, как только освободится обработчик. Возвращает ошибку, только если ctx
+// отменён раньше.
+func (p *Pool) Go(ctx context.Context, f func()) error {
+	if err := p.Acquire(ctx); err != nil {
+		return err
+	}
+	go func() {
+		defer p.Release()
+		f()
+	}()
+	return nil
+}
+
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Size:    cap(p.slots),
+		Busy:    len(p.slots),
+		Waiting: int(p.waiting.Load()),
+	}
+}